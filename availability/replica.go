@@ -0,0 +1,272 @@
+package availability
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/eventlog"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+// Replica keeps the objects of one event in memory, so that availability can be checked without calling
+// the API. It is loaded from the event report by label, and kept current by polling the status changes of the event,
+// by Refresh (for instance from a webhook handler), or by HandleEventLogItem. A periodic full resync corrects any
+// drift. Reads are safe for concurrent use.
+type Replica struct {
+	client         *seatsio.SeatsioClient
+	eventKey       string
+	pollInterval   time.Duration
+	pollPageSize   int
+	resyncInterval time.Duration
+	onError        func(err error)
+
+	// syncMutex serializes polls and resyncs; mutex guards the state that is read by queries.
+	syncMutex    sync.Mutex
+	mutex        sync.RWMutex
+	objects      map[string]events.EventObjectInfo
+	chartKey     string
+	lastChangeId int64
+	syncedAt     time.Time
+}
+
+type ReplicaOption func(replica *Replica)
+
+type replicaNS struct{}
+
+var ReplicaSupport replicaNS
+
+// PollInterval sets how often Run polls the status changes. Defaults to 5 seconds.
+func (replicaNS) PollInterval(pollInterval time.Duration) ReplicaOption {
+	return func(replica *Replica) {
+		replica.pollInterval = pollInterval
+	}
+}
+
+// PollPageSize sets how many status changes a poll reads per request. Defaults to 100.
+func (replicaNS) PollPageSize(pollPageSize int) ReplicaOption {
+	return func(replica *Replica) {
+		replica.pollPageSize = pollPageSize
+	}
+}
+
+// ResyncInterval sets how often Run reloads the whole event. Defaults to 15 minutes.
+func (replicaNS) ResyncInterval(resyncInterval time.Duration) ReplicaOption {
+	return func(replica *Replica) {
+		replica.resyncInterval = resyncInterval
+	}
+}
+
+// OnError receives the errors of the polls and resyncs done by Run, which keeps running after them.
+func (replicaNS) OnError(onError func(err error)) ReplicaOption {
+	return func(replica *Replica) {
+		replica.onError = onError
+	}
+}
+
+// NewReplica creates a replica for an event. It is empty until Resync or Run loads it.
+func NewReplica(client *seatsio.SeatsioClient, eventKey string, opts ...ReplicaOption) *Replica {
+	replica := &Replica{
+		client:         client,
+		eventKey:       eventKey,
+		pollInterval:   5 * time.Second,
+		pollPageSize:   100,
+		resyncInterval: 15 * time.Minute,
+		onError:        func(err error) {},
+		objects:        map[string]events.EventObjectInfo{},
+	}
+	for _, opt := range opts {
+		opt(replica)
+	}
+	return replica
+}
+
+// Run loads the event and keeps it current until the context is done.
+func (replica *Replica) Run(context context.Context) error {
+	if err := replica.Resync(context); err != nil {
+		replica.onError(err)
+	}
+	poll := time.NewTicker(replica.pollInterval)
+	defer poll.Stop()
+	resync := time.NewTicker(replica.resyncInterval)
+	defer resync.Stop()
+	for {
+		var err error
+		select {
+		case <-poll.C:
+			err = replica.Poll(context)
+		case <-resync.C:
+			err = replica.Resync(context)
+		case <-context.Done():
+			return context.Err()
+		}
+		if err != nil && context.Err() == nil {
+			replica.onError(err)
+		}
+	}
+}
+
+// Resync reloads all objects of the event.
+func (replica *Replica) Resync(context context.Context) error {
+	replica.syncMutex.Lock()
+	defer replica.syncMutex.Unlock()
+	// The status changes that happen while the report is loading are newer than lastChangeId, so the next poll
+	// applies them again.
+	lastChangeId, err := replica.latestStatusChangeId(context)
+	if err != nil {
+		return err
+	}
+	event, err := replica.client.Events.Retrieve(context, replica.eventKey)
+	if err != nil {
+		return err
+	}
+	report, err := replica.client.EventReports.ByLabel(context, replica.eventKey)
+	if err != nil {
+		return err
+	}
+	objects := make(map[string]events.EventObjectInfo, len(report.Items))
+	for label, infos := range report.Items {
+		if len(infos) > 0 {
+			objects[label] = infos[0]
+		}
+	}
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	replica.objects = objects
+	replica.chartKey = event.ChartKey
+	replica.lastChangeId = lastChangeId
+	replica.syncedAt = time.Now()
+	return nil
+}
+
+func (replica *Replica) latestStatusChangeId(context context.Context) (int64, error) {
+	page, err := replica.client.Events.StatusChanges(context, replica.eventKey, events.EventSupport.WithSortDesc("date")).
+		ListFirstPage(shared.Pagination.PageSize(1))
+	if err != nil || len(page.Items) == 0 {
+		return 0, err
+	}
+	return page.Items[0].Id, nil
+}
+
+// Poll applies the status changes since the last poll or resync, by reloading the objects they changed.
+func (replica *Replica) Poll(context context.Context) error {
+	replica.syncMutex.Lock()
+	defer replica.syncMutex.Unlock()
+	replica.mutex.RLock()
+	since := replica.lastChangeId
+	replica.mutex.RUnlock()
+
+	latest := since
+	labels := map[string]bool{}
+	err := replica.client.Events.StatusChanges(context, replica.eventKey, events.EventSupport.WithSortDesc("date")).
+		ForEach(func(statusChange events.StatusChange) error {
+			if statusChange.Id <= since {
+				return shared.ErrStopIteration
+			}
+			latest = max(latest, statusChange.Id)
+			labels[statusChange.ObjectLabel] = true
+			return nil
+		}, shared.Pagination.PageSize(replica.pollPageSize))
+	if err != nil {
+		return err
+	}
+	changed := make([]string, 0, len(labels))
+	for label := range labels {
+		changed = append(changed, label)
+	}
+	if err := replica.Refresh(context, changed...); err != nil {
+		return err
+	}
+	replica.mutex.Lock()
+	defer replica.mutex.Unlock()
+	replica.lastChangeId = max(replica.lastChangeId, latest)
+	return nil
+}
+
+// Refresh reloads the given objects. Webhook handlers can call it with the objects a webhook reports as changed.
+func (replica *Replica) Refresh(context context.Context, objectLabels ...string) error {
+	for start := 0; start < len(objectLabels); start += 100 {
+		infos, err := replica.client.Events.RetrieveObjectInfo(context, replica.eventKey, objectLabels[start:min(start+100, len(objectLabels))]...)
+		if err != nil {
+			return err
+		}
+		replica.mutex.Lock()
+		for label, info := range infos {
+			replica.objects[label] = info
+		}
+		replica.mutex.Unlock()
+	}
+	return nil
+}
+
+// HandleEventLogItem polls the status changes when the item concerns the event, and resyncs when its chart changed.
+func (replica *Replica) HandleEventLogItem(context context.Context, item eventlog.EventLogItem) error {
+	key, _ := item.Data["key"].(string)
+	replica.mutex.RLock()
+	chartKey := replica.chartKey
+	replica.mutex.RUnlock()
+	switch {
+	case key == "":
+		return nil
+	case key == replica.eventKey:
+		return replica.Poll(context)
+	case key == chartKey && strings.HasPrefix(item.Type, "chart."):
+		return replica.Resync(context)
+	}
+	return nil
+}
+
+// SyncedAt returns when the replica was last fully loaded, or the zero time if it never was.
+func (replica *Replica) SyncedAt() time.Time {
+	replica.mutex.RLock()
+	defer replica.mutex.RUnlock()
+	return replica.syncedAt
+}
+
+// Object returns the replicated information of an object.
+func (replica *Replica) Object(objectLabel string) (events.EventObjectInfo, bool) {
+	replica.mutex.RLock()
+	defer replica.mutex.RUnlock()
+	info, ok := replica.objects[objectLabel]
+	return info, ok
+}
+
+// IsAvailable tells whether an object can be selected: it is free, for sale, and not in a channel that restricts it.
+func (replica *Replica) IsAvailable(objectLabel string) bool {
+	info, ok := replica.Object(objectLabel)
+	return ok && info.IsAvailable
+}
+
+// FreeSeats counts the available seats of the objects that match filter: 1 for every available seat or booth, and
+// the free places of general admission areas. Tables are not counted, their seats are.
+func (replica *Replica) FreeSeats(filter func(info events.EventObjectInfo) bool) int {
+	replica.mutex.RLock()
+	defer replica.mutex.RUnlock()
+	free := 0
+	for _, info := range replica.objects {
+		if !info.IsAvailable || info.ObjectType == "table" || !filter(info) {
+			continue
+		}
+		if info.ObjectType == "generalAdmission" {
+			free += info.NumFree
+		} else {
+			free++
+		}
+	}
+	return free
+}
+
+func (replica *Replica) FreeSeatsInSection(section string) int {
+	return replica.FreeSeats(func(info events.EventObjectInfo) bool {
+		return info.Section == section
+	})
+}
+
+func (replica *Replica) FreeSeatsInCategory(categoryKey string) int {
+	return replica.FreeSeats(func(info events.EventObjectInfo) bool {
+		return info.CategoryKey.Key != nil && info.CategoryKey.KeyAsString() == categoryKey
+	})
+}
@@ -0,0 +1,129 @@
+package availability_test
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/availability"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	client   *seatsio.SeatsioClient
+	chartKey string
+	eventKey string
+}
+
+func newTestEvent(t *testing.T) *testEvent {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	return &testEvent{client: client, chartKey: chartKey, eventKey: event.Key}
+}
+
+// book books every object in a status change of its own.
+func (event *testEvent) book(t *testing.T, objects ...string) {
+	for _, object := range objects {
+		_, err := event.client.Events.Book(test_util.RequestContext(), event.eventKey, object)
+		require.NoError(t, err)
+	}
+}
+
+func (event *testEvent) release(t *testing.T, objects ...string) {
+	_, err := event.client.Events.Release(test_util.RequestContext(), event.eventKey, objects...)
+	require.NoError(t, err)
+}
+
+// countRequests counts the requests the client sends to paths that end with suffix.
+func (event *testEvent) countRequests(suffix string) *atomic.Int32 {
+	var count atomic.Int32
+	event.client.Events.Client.OnAfterResponse(func(_ *req.Client, response *req.Response) error {
+		if response.Request != nil && response.Request.RawRequest != nil && strings.HasSuffix(response.Request.RawRequest.URL.Path, suffix) {
+			count.Add(1)
+		}
+		return nil
+	})
+	return &count
+}
+
+func TestReplicaAnswersFromMemory(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	event.book(t, "A-1")
+	replica := availability.NewReplica(event.client, event.eventKey)
+
+	require.NoError(t, replica.Resync(test_util.RequestContext()))
+
+	require.False(t, replica.IsAvailable("A-1"))
+	require.True(t, replica.IsAvailable("A-2"))
+	require.False(t, replica.IsAvailable("unknown"))
+	require.Equal(t, 115, replica.FreeSeatsInCategory("9"))
+	require.Equal(t, 116, replica.FreeSeatsInCategory("10"))
+	require.Equal(t, 231, replica.FreeSeatsInSection(""))
+	require.False(t, replica.SyncedAt().IsZero())
+}
+
+func TestReplicaPollsTheStatusChangesSinceTheLastSync(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	event.book(t, "A-1")
+	statusChangeRequests := event.countRequests("/status-changes")
+	replica := availability.NewReplica(event.client, event.eventKey, availability.ReplicaSupport.PollPageSize(2))
+	require.NoError(t, replica.Resync(test_util.RequestContext()))
+	event.book(t, "A-2", "B-1", "C-1")
+	event.release(t, "A-1")
+
+	var readers sync.WaitGroup
+	readers.Add(1)
+	go func() {
+		defer readers.Done()
+		for i := 0; i < 100; i++ {
+			replica.FreeSeatsInCategory("9")
+		}
+	}()
+	statusChangeRequests.Store(0)
+	require.NoError(t, replica.Poll(test_util.RequestContext()))
+	readers.Wait()
+
+	// the four new status changes take two pages; the third page starts with the status change before the resync
+	require.Equal(t, int32(3), statusChangeRequests.Load())
+	require.True(t, replica.IsAvailable("A-1"))
+	require.False(t, replica.IsAvailable("A-2"))
+	require.False(t, replica.IsAvailable("C-1"))
+	require.Equal(t, 114, replica.FreeSeatsInCategory("9"))
+	require.Equal(t, 115, replica.FreeSeatsInCategory("10"))
+	info, _ := replica.Object("B-1")
+	require.Equal(t, events.BOOKED, info.Status)
+
+	statusChangeRequests.Store(0)
+	require.NoError(t, replica.Poll(test_util.RequestContext()))
+	require.Equal(t, int32(1), statusChangeRequests.Load())
+}
+
+func TestReplicaResyncsWhenTheChartChanges(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	reportRequests := event.countRequests("/byLabel")
+	replica := availability.NewReplica(event.client, event.eventKey)
+	require.NoError(t, replica.Resync(test_util.RequestContext()))
+	require.NoError(t, event.client.Charts.Update(test_util.RequestContext(), event.chartKey, &charts.UpdateChartParams{Name: "renamed"}))
+	time.Sleep(2 * time.Second)
+
+	items, err := event.client.EventLog.ListAll(test_util.RequestContext())
+	require.NoError(t, err)
+	for _, item := range items {
+		require.NoError(t, replica.HandleEventLogItem(test_util.RequestContext(), item))
+	}
+
+	require.Greater(t, reportRequests.Load(), int32(1))
+}
@@ -0,0 +1,324 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+)
+
+const FormatVersion = 1
+
+const manifestFile = "manifest.json"
+const eventsFile = "events.json"
+
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	// LastEventLogId is the id of the most recent event log item when the backup was made.
+	LastEventLogId int64 `json:"lastEventLogId"`
+	// UnchangedCharts lists the charts whose drawings were left out of an incremental backup, because they did not
+	// change since the previous backup.
+	UnchangedCharts []string `json:"unchangedCharts,omitempty"`
+	// Checksums holds the SHA-256 checksum of every file in the archive, by path.
+	Checksums map[string]string `json:"checksums"`
+}
+
+func (manifest *Manifest) IsIncremental() bool {
+	return len(manifest.UnchangedCharts) > 0
+}
+
+func (manifest *Manifest) hasDrawing(chartKey string) bool {
+	_, ok := manifest.Checksums[publishedDrawingFile(chartKey)]
+	return ok || slices.Contains(manifest.UnchangedCharts, chartKey)
+}
+
+// Archive is a workspace backup. It is written as a gzipped tar file, with a manifest.json that holds the checksums
+// of the other files. An Archive is also a migration.Source, which is how it is restored.
+type Archive struct {
+	Manifest Manifest
+	Files    map[string][]byte
+}
+
+func newArchive() *Archive {
+	return &Archive{
+		Manifest: Manifest{Version: FormatVersion, CreatedAt: time.Now().UTC(), Checksums: map[string]string{}},
+		Files:    map[string][]byte{},
+	}
+}
+
+func chartFile(chartKey string) string {
+	return "charts/" + url.PathEscape(chartKey) + ".json"
+}
+
+func publishedDrawingFile(chartKey string) string {
+	return "drawings/" + url.PathEscape(chartKey) + "/published.json"
+}
+
+func draftDrawingFile(chartKey string) string {
+	return "drawings/" + url.PathEscape(chartKey) + "/draft.json"
+}
+
+func seasonFile(seasonKey string) string {
+	return "seasons/" + url.PathEscape(seasonKey) + ".json"
+}
+
+func objectsFile(eventKey string) string {
+	return "objects/" + url.PathEscape(eventKey) + ".json"
+}
+
+func (archive *Archive) put(path string, value any) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	archive.Files[path] = data
+	archive.Manifest.Checksums[path] = checksum(data)
+	return nil
+}
+
+func (archive *Archive) get(path string, value any) error {
+	data, ok := archive.Files[path]
+	if !ok {
+		return fmt.Errorf("%s is not in the archive", path)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	return nil
+}
+
+func (archive *Archive) has(path string) bool {
+	_, ok := archive.Files[path]
+	return ok
+}
+
+// Write writes the archive as a gzipped tar file, with the manifest first.
+func (archive *Archive) Write(writer io.Writer) error {
+	archive.Manifest.Checksums = map[string]string{}
+	paths := make([]string, 0, len(archive.Files))
+	for path, data := range archive.Files {
+		archive.Manifest.Checksums[path] = checksum(data)
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	manifest, err := json.MarshalIndent(archive.Manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	write := func(path string, data []byte) error {
+		header := &tar.Header{Name: path, Mode: 0644, Size: int64(len(data)), ModTime: archive.Manifest.CreatedAt}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tarWriter.Write(data)
+		return err
+	}
+	if err := write(manifestFile, manifest); err != nil {
+		return err
+	}
+	for _, path := range paths {
+		if err := write(path, archive.Files[path]); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+// Read reads an archive written by Write, and verifies the checksum of every file.
+func Read(reader io.Reader) (*Archive, error) {
+	gzipReader, err := gzip.NewReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	tarReader := tar.NewReader(gzipReader)
+	archive := &Archive{Files: map[string][]byte{}}
+	var manifest []byte
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(tarReader)
+		if err != nil {
+			return nil, err
+		}
+		if header.Name == manifestFile {
+			manifest = data
+		} else {
+			archive.Files[header.Name] = data
+		}
+	}
+	if manifest == nil {
+		return nil, errors.New("the archive has no manifest.json")
+	}
+	if err := json.Unmarshal(manifest, &archive.Manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", manifestFile, err)
+	}
+	if archive.Manifest.Version != FormatVersion {
+		return nil, fmt.Errorf("unsupported archive version %d", archive.Manifest.Version)
+	}
+	return archive, archive.verify()
+}
+
+func (archive *Archive) verify() error {
+	var problems []string
+	for path, expected := range archive.Manifest.Checksums {
+		data, ok := archive.Files[path]
+		if !ok {
+			problems = append(problems, path+" is missing")
+		} else if checksum(data) != expected {
+			problems = append(problems, path+" has a wrong checksum")
+		}
+	}
+	for path := range archive.Files {
+		if _, ok := archive.Manifest.Checksums[path]; !ok {
+			problems = append(problems, path+" is not in the manifest")
+		}
+	}
+	if len(problems) > 0 {
+		slices.Sort(problems)
+		return fmt.Errorf("corrupt archive: %s", strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// Merge completes incremental archives with the drawings of the unchanged charts. base is a full archive, and
+// increments are the incremental archives made after it, oldest first; each of them may be based on the previous
+// one. The result is the most recent state, as a full archive.
+func Merge(base *Archive, increments ...*Archive) (*Archive, error) {
+	if base.Manifest.IsIncremental() {
+		return nil, errors.New("the base archive is incremental, pass the full archive it is based on instead")
+	}
+	merged := base
+	for _, increment := range increments {
+		if increment.Manifest.CreatedAt.Before(merged.Manifest.CreatedAt) {
+			return nil, errors.New("incremental archives must be passed oldest first")
+		}
+		var err error
+		if merged, err = mergeIncrement(merged, increment); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
+
+func mergeIncrement(base *Archive, increment *Archive) (*Archive, error) {
+	merged := &Archive{Manifest: increment.Manifest, Files: map[string][]byte{}}
+	merged.Manifest.UnchangedCharts = nil
+	merged.Manifest.Checksums = map[string]string{}
+	for path, data := range increment.Files {
+		merged.Files[path] = data
+	}
+	for _, chartKey := range increment.Manifest.UnchangedCharts {
+		found := false
+		for _, path := range []string{publishedDrawingFile(chartKey), draftDrawingFile(chartKey)} {
+			if data, ok := base.Files[path]; ok {
+				merged.Files[path] = data
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("the drawing of chart %s is not in the base archive", chartKey)
+		}
+	}
+	for path, data := range merged.Files {
+		merged.Manifest.Checksums[path] = checksum(data)
+	}
+	return merged, nil
+}
+
+func (archive *Archive) Charts(context context.Context) ([]charts.Chart, error) {
+	var result []charts.Chart
+	for _, path := range archive.paths("charts/") {
+		var chart charts.Chart
+		if err := archive.get(path, &chart); err != nil {
+			return nil, err
+		}
+		result = append(result, chart)
+	}
+	return result, nil
+}
+
+func (archive *Archive) Events(context context.Context) ([]seasons.Season, error) {
+	var result []seasons.Season
+	if err := archive.get(eventsFile, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (archive *Archive) Season(context context.Context, key string) (*seasons.Season, error) {
+	var season seasons.Season
+	if err := archive.get(seasonFile(key), &season); err != nil {
+		return nil, err
+	}
+	return &season, nil
+}
+
+func (archive *Archive) Objects(context context.Context, eventKey string) (map[string][]events.EventObjectInfo, error) {
+	var objects map[string][]events.EventObjectInfo
+	if err := archive.get(objectsFile(eventKey), &objects); err != nil {
+		return nil, err
+	}
+	return objects, nil
+}
+
+// PublishedDrawing returns the published version of a chart, as returned by Charts.RetrievePublishedVersion.
+func (archive *Archive) PublishedDrawing(chartKey string) (map[string]interface{}, error) {
+	return archive.drawing(publishedDrawingFile(chartKey))
+}
+
+// DraftDrawing returns the draft version of a chart, or nil if the chart had no draft.
+func (archive *Archive) DraftDrawing(chartKey string) (map[string]interface{}, error) {
+	if !archive.has(draftDrawingFile(chartKey)) {
+		return nil, nil
+	}
+	return archive.drawing(draftDrawingFile(chartKey))
+}
+
+func (archive *Archive) drawing(path string) (map[string]interface{}, error) {
+	var drawing map[string]interface{}
+	if err := archive.get(path, &drawing); err != nil {
+		return nil, err
+	}
+	return drawing, nil
+}
+
+func (archive *Archive) paths(prefix string) []string {
+	var paths []string
+	for path := range archive.Files {
+		if strings.HasPrefix(path, prefix) {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+	return paths
+}
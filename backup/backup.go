@@ -0,0 +1,140 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/eventlog"
+	"github.com/seatsio/seatsio-go/v12/migration"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+// Backup reads a workspace into an Archive.
+type Backup struct {
+	Client *seatsio.SeatsioClient
+	// Previous is the manifest of the previous backup. When set, the backup is incremental: the drawings of charts
+	// that have no event log items since the previous backup are left out.
+	Previous *Manifest
+}
+
+// Create backs up the charts with their drawings, the events, seasons and partial seasons, and the objects of every
+// event and season.
+func (backup *Backup) Create(context context.Context) (*Archive, error) {
+	archive := newArchive()
+	changedCharts, err := backup.readEventLog(context, archive)
+	if err != nil {
+		return nil, fmt.Errorf("reading the event log: %w", err)
+	}
+
+	source := migration.WorkspaceSource{Client: backup.Client}
+	allCharts, err := source.Charts(context)
+	if err != nil {
+		return nil, err
+	}
+	for _, chart := range allCharts {
+		if err := archive.put(chartFile(chart.Key), chart); err != nil {
+			return nil, err
+		}
+		if backup.Previous != nil && backup.Previous.hasDrawing(chart.Key) && !changedCharts[chart.Key] {
+			archive.Manifest.UnchangedCharts = append(archive.Manifest.UnchangedCharts, chart.Key)
+			continue
+		}
+		if err := backup.backUpDrawings(context, archive, chart.Key, chart.DraftVersionThumbnailUrl != ""); err != nil {
+			return nil, err
+		}
+	}
+
+	allEvents, err := source.Events(context)
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.put(eventsFile, allEvents); err != nil {
+		return nil, err
+	}
+	for _, event := range allEvents {
+		if event.IsSeason {
+			season, err := source.Season(context, event.Key)
+			if err != nil {
+				return nil, fmt.Errorf("season %s: %w", event.Key, err)
+			}
+			if err := archive.put(seasonFile(event.Key), season); err != nil {
+				return nil, err
+			}
+		}
+		if event.IsPartialSeason {
+			continue
+		}
+		objects, err := source.Objects(context, event.Key)
+		if err != nil {
+			return nil, fmt.Errorf("objects of %s: %w", event.Key, err)
+		}
+		if err := archive.put(objectsFile(event.Key), objects); err != nil {
+			return nil, err
+		}
+	}
+	return archive, nil
+}
+
+// readEventLog records the most recent event log item in the manifest, and returns the keys of the charts that
+// changed since the previous backup. A full backup only reads the most recent item; an incremental backup reads the
+// items after the previous backup.
+func (backup *Backup) readEventLog(context context.Context, archive *Archive) (map[string]bool, error) {
+	changedCharts := map[string]bool{}
+	if backup.Previous == nil {
+		// the event log is ordered oldest first, so the page before the highest possible id holds the most recent item
+		page, err := backup.Client.EventLog.ListPageBefore(context, math.MaxInt64, shared.Pagination.PageSize(1))
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			archive.Manifest.LastEventLogId = max(archive.Manifest.LastEventLogId, item.Id)
+		}
+		return changedCharts, nil
+	}
+	archive.Manifest.LastEventLogId = backup.Previous.LastEventLogId
+	var page *shared.Page[eventlog.EventLogItem]
+	var err error
+	if archive.Manifest.LastEventLogId == 0 {
+		page, err = backup.Client.EventLog.ListFirstPage(context)
+	} else {
+		page, err = backup.Client.EventLog.ListPageAfter(context, archive.Manifest.LastEventLogId)
+	}
+	for {
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range page.Items {
+			archive.Manifest.LastEventLogId = max(archive.Manifest.LastEventLogId, item.Id)
+			if strings.HasPrefix(item.Type, "chart.") {
+				if chartKey, ok := item.Data["key"].(string); ok {
+					changedCharts[chartKey] = true
+				}
+			}
+		}
+		if page.NextPageStartsAfter == 0 {
+			return changedCharts, nil
+		}
+		page, err = backup.Client.EventLog.ListPageAfter(context, page.NextPageStartsAfter)
+	}
+}
+
+func (backup *Backup) backUpDrawings(context context.Context, archive *Archive, chartKey string, hasDraft bool) error {
+	published, err := backup.Client.Charts.RetrievePublishedVersion(context, chartKey)
+	if err != nil {
+		return fmt.Errorf("published version of chart %s: %w", chartKey, err)
+	}
+	if err := archive.put(publishedDrawingFile(chartKey), published); err != nil {
+		return err
+	}
+	if !hasDraft {
+		return nil
+	}
+	draft, err := backup.Client.Charts.RetrieveDraftVersion(context, chartKey)
+	if err != nil {
+		return fmt.Errorf("draft version of chart %s: %w", chartKey, err)
+	}
+	return archive.put(draftDrawingFile(chartKey), draft)
+}
@@ -0,0 +1,63 @@
+package backup
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/migration"
+)
+
+// Restore rebuilds a workspace from an archive, with a company admin key. The API cannot create a chart from a
+// drawing, so the charts are copied from a workspace that still has them, usually the workspace that was backed up.
+// The copies get new keys, which Result.Charts maps. Everything else (tags, events, seasons, partial seasons and
+// object statuses) comes from the archive, as it was when the backup was made.
+//
+// Charts that are no longer in the source workspace are reported as failures, together with their events; their
+// drawings remain available through Archive.PublishedDrawing. So are charts whose published version changed after the
+// backup: they are restored in their current version. Draft versions are not restored either; they remain available
+// through Archive.DraftDrawing.
+func Restore(ctx context.Context, baseUrl string, companyAdminKey string, sourceWorkspaceKey string, targetWorkspaceKey string, archive *Archive) (*migration.Result, error) {
+	migrator := migration.NewMigrator(baseUrl, companyAdminKey, sourceWorkspaceKey, targetWorkspaceKey)
+	migrator.Source = archive
+	result, err := migrator.Migrate(ctx)
+	if err != nil {
+		return result, err
+	}
+	source := seatsio.NewSeatsioClient(baseUrl, companyAdminKey, seatsio.ClientSupport.WorkspaceKey(sourceWorkspaceKey))
+	for chartKey := range result.Charts {
+		if err := checkPublishedDrawing(ctx, source, archive, chartKey); err != nil {
+			result.Failures = append(result.Failures, migration.Failure{Resource: "chart", Key: chartKey, Err: err})
+		}
+		if archive.has(draftDrawingFile(chartKey)) {
+			result.Failures = append(result.Failures, migration.Failure{
+				Resource: "chart",
+				Key:      chartKey,
+				Err:      errors.New("the draft version cannot be restored, it is kept in the archive"),
+			})
+		}
+	}
+	return result, nil
+}
+
+// checkPublishedDrawing checks that the chart a restored chart was copied from still has the published version in
+// the archive. Charts that were left out of an incremental archive are not checked.
+func checkPublishedDrawing(ctx context.Context, source *seatsio.SeatsioClient, archive *Archive, chartKey string) error {
+	if !archive.has(publishedDrawingFile(chartKey)) {
+		return nil
+	}
+	archived, err := archive.PublishedDrawing(chartKey)
+	if err != nil {
+		return err
+	}
+	current, err := source.Charts.RetrievePublishedVersion(ctx, chartKey)
+	if err != nil {
+		return fmt.Errorf("reading the published version: %w", err)
+	}
+	if !reflect.DeepEqual(archived, current) {
+		return errors.New("the published version changed after the backup; the current version was restored, the archived version is kept in the archive")
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/backup"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteAndReadArchive(t *testing.T) {
+	t.Parallel()
+	archive := &backup.Archive{
+		Manifest: backup.Manifest{Version: backup.FormatVersion, LastEventLogId: 12},
+		Files: map[string][]byte{
+			"charts/chart1.json":             []byte(`{"key": "chart1", "name": "Arena", "tags": ["concerts"]}`),
+			"drawings/chart1/published.json": []byte(`{"name": "Arena"}`),
+			"events.json":                    []byte(`[{"key": "event1", "chartKey": "chart1"}, {"key": "season1", "chartKey": "chart1", "isSeason": true, "isTopLevelSeason": true}]`),
+			"objects/event1.json":            []byte(`{"A-1": [{"label": "A-1", "status": "booked"}]}`),
+		},
+	}
+	var buffer bytes.Buffer
+	require.NoError(t, archive.Write(&buffer))
+
+	read, err := backup.Read(&buffer)
+
+	require.NoError(t, err)
+	require.Equal(t, int64(12), read.Manifest.LastEventLogId)
+	require.Len(t, read.Manifest.Checksums, 4)
+	charts, err := read.Charts(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "Arena", charts[0].Name)
+	require.Equal(t, []string{"concerts"}, charts[0].Tags)
+	allEvents, err := read.Events(context.Background())
+	require.NoError(t, err)
+	require.Len(t, allEvents, 2)
+	require.True(t, allEvents[1].IsTopLevelSeason)
+	objects, err := read.Objects(context.Background(), "event1")
+	require.NoError(t, err)
+	require.Equal(t, events.BOOKED, objects["A-1"][0].Status)
+	drawing, err := read.PublishedDrawing("chart1")
+	require.NoError(t, err)
+	require.Equal(t, "Arena", drawing["name"])
+	draft, err := read.DraftDrawing("chart1")
+	require.NoError(t, err)
+	require.Nil(t, draft)
+}
+
+func TestReadArchiveVerifiesChecksums(t *testing.T) {
+	t.Parallel()
+	var buffer bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buffer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	files := []struct{ name, content string }{
+		{"manifest.json", `{"version": 1, "checksums": {"events.json": "0000", "objects/gone.json": "0000"}}`},
+		{"events.json", `[]`},
+		{"extra.json", `{}`},
+	}
+	for _, file := range files {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.content))}))
+		_, err := tarWriter.Write([]byte(file.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	_, err := backup.Read(&buffer)
+
+	require.EqualError(t, err, "corrupt archive: events.json has a wrong checksum, extra.json is not in the manifest, objects/gone.json is missing")
+}
+
+func TestMergeIncrementalArchive(t *testing.T) {
+	t.Parallel()
+	base := &backup.Archive{Files: map[string][]byte{
+		"drawings/chart1/published.json": []byte(`{"name": "v1"}`),
+		"drawings/chart1/draft.json":     []byte(`{"name": "v2"}`),
+		"drawings/chart2/published.json": []byte(`{"name": "old"}`),
+	}}
+	increment := &backup.Archive{
+		Manifest: backup.Manifest{Version: backup.FormatVersion, UnchangedCharts: []string{"chart1"}},
+		Files:    map[string][]byte{"drawings/chart2/published.json": []byte(`{"name": "new"}`)},
+	}
+	require.True(t, increment.Manifest.IsIncremental())
+
+	merged, err := backup.Merge(base, increment)
+
+	require.NoError(t, err)
+	require.False(t, merged.Manifest.IsIncremental())
+	require.Equal(t, map[string][]byte{
+		"drawings/chart1/published.json": []byte(`{"name": "v1"}`),
+		"drawings/chart1/draft.json":     []byte(`{"name": "v2"}`),
+		"drawings/chart2/published.json": []byte(`{"name": "new"}`),
+	}, merged.Files)
+
+	_, err = backup.Merge(&backup.Archive{Files: map[string][]byte{}}, increment)
+	require.EqualError(t, err, "the drawing of chart chart1 is not in the base archive")
+}
+
+func TestMergeChainOfIncrementalArchives(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	base := &backup.Archive{
+		Manifest: backup.Manifest{Version: backup.FormatVersion, CreatedAt: start},
+		Files: map[string][]byte{
+			"drawings/chart1/published.json": []byte(`{"name": "v1"}`),
+			"drawings/chart2/published.json": []byte(`{"name": "v1"}`),
+		},
+	}
+	first := &backup.Archive{
+		Manifest: backup.Manifest{Version: backup.FormatVersion, CreatedAt: start.Add(24 * time.Hour), UnchangedCharts: []string{"chart1"}},
+		Files:    map[string][]byte{"drawings/chart2/published.json": []byte(`{"name": "v2"}`)},
+	}
+	second := &backup.Archive{
+		Manifest: backup.Manifest{Version: backup.FormatVersion, CreatedAt: start.Add(48 * time.Hour), UnchangedCharts: []string{"chart1", "chart2"}},
+		Files:    map[string][]byte{},
+	}
+
+	merged, err := backup.Merge(base, first, second)
+
+	require.NoError(t, err)
+	require.Equal(t, second.Manifest.CreatedAt, merged.Manifest.CreatedAt)
+	require.Equal(t, map[string][]byte{
+		"drawings/chart1/published.json": []byte(`{"name": "v1"}`),
+		"drawings/chart2/published.json": []byte(`{"name": "v2"}`),
+	}, merged.Files)
+	require.Len(t, merged.Manifest.Checksums, 2)
+
+	_, err = backup.Merge(base, second, first)
+	require.EqualError(t, err, "incremental archives must be passed oldest first")
+	_, err = backup.Merge(first, second)
+	require.EqualError(t, err, "the base archive is incremental, pass the full archive it is based on instead")
+}
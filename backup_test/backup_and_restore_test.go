@@ -0,0 +1,108 @@
+package backup_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/backup"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	require.NoError(t, client.Charts.AddTag(test_util.RequestContext(), chartKey, "concerts"))
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	_, err = client.Events.Book(test_util.RequestContext(), event.Key, "A-1")
+	require.NoError(t, err)
+
+	archive, err := (&backup.Backup{Client: client}).Create(test_util.RequestContext())
+	require.NoError(t, err)
+	var buffer bytes.Buffer
+	require.NoError(t, archive.Write(&buffer))
+	read, err := backup.Read(&buffer)
+	require.NoError(t, err)
+
+	workspace, err := client.Workspaces.CreateProductionWorkspace(test_util.RequestContext(), "restored")
+	require.NoError(t, err)
+	result, err := backup.Restore(test_util.RequestContext(), test_util.BaseUrl, company.Admin.SecretKey, company.Workspace.Key, workspace.Key, read)
+
+	require.NoError(t, err)
+	require.Empty(t, result.Failures)
+	workspaceClient := seatsio.NewSeatsioClient(test_util.BaseUrl, workspace.SecretKey)
+	restoredChart, err := workspaceClient.Charts.Retrieve(test_util.RequestContext(), result.Charts[chartKey])
+	require.NoError(t, err)
+	require.Equal(t, []string{"concerts"}, restoredChart.Tags)
+	objectInfos, err := workspaceClient.Events.RetrieveObjectInfo(test_util.RequestContext(), event.Key, "A-1")
+	require.NoError(t, err)
+	require.Equal(t, events.BOOKED, objectInfos["A-1"].Status)
+}
+
+func TestRestoreReportsChartsChangedAfterTheBackup(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	archive, err := (&backup.Backup{Client: client}).Create(test_util.RequestContext())
+	require.NoError(t, err)
+	require.NoError(t, client.Charts.Update(test_util.RequestContext(), chartKey, &charts.UpdateChartParams{Name: "renamed"}))
+
+	workspace, err := client.Workspaces.CreateProductionWorkspace(test_util.RequestContext(), "restored")
+	require.NoError(t, err)
+	result, err := backup.Restore(test_util.RequestContext(), test_util.BaseUrl, company.Admin.SecretKey, company.Workspace.Key, workspace.Key, archive)
+
+	require.NoError(t, err)
+	require.Len(t, result.Failures, 1)
+	require.Equal(t, "chart", result.Failures[0].Resource)
+	require.Equal(t, chartKey, result.Failures[0].Key)
+	require.ErrorContains(t, result.Failures[0].Err, "the published version changed after the backup")
+}
+
+func TestIncrementalBackupLeavesOutUnchangedCharts(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	full, err := (&backup.Backup{Client: client}).Create(test_util.RequestContext())
+	require.NoError(t, err)
+
+	increment, err := (&backup.Backup{Client: client, Previous: &full.Manifest}).Create(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{chartKey}, increment.Manifest.UnchangedCharts)
+	require.Equal(t, full.Manifest.LastEventLogId, increment.Manifest.LastEventLogId)
+	merged, err := backup.Merge(full, increment)
+	require.NoError(t, err)
+	_, err = merged.PublishedDrawing(chartKey)
+	require.NoError(t, err)
+}
+
+func TestIncrementalBackupIncludesChartsChangedSinceThePreviousBackup(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	unchangedChartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	changedChartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	time.Sleep(2 * time.Second)
+	full, err := (&backup.Backup{Client: client}).Create(test_util.RequestContext())
+	require.NoError(t, err)
+	require.NoError(t, client.Charts.Update(test_util.RequestContext(), changedChartKey, &charts.UpdateChartParams{Name: "renamed"}))
+	time.Sleep(2 * time.Second)
+
+	increment, err := (&backup.Backup{Client: client, Previous: &full.Manifest}).Create(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{unchangedChartKey}, increment.Manifest.UnchangedCharts)
+	require.Greater(t, increment.Manifest.LastEventLogId, full.Manifest.LastEventLogId)
+	drawing, err := increment.PublishedDrawing(changedChartKey)
+	require.NoError(t, err)
+	require.Equal(t, "renamed", drawing["name"])
+}
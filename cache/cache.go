@@ -0,0 +1,236 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// Cache is a read-through cache for charts, chart categories and events. Its Charts and Events can be used instead
+// of the client's: Charts.Retrieve, Charts.ListCategories and Events.Retrieve are cached, the other methods go
+// straight to the API.
+//
+// Entries are invalidated whenever the client sends a request that changes a chart, event or season, whether it goes
+// through the cache or not. Changes made by others are picked up by an Invalidator, or when the entries expire.
+type Cache struct {
+	store     Store
+	ttl       time.Duration
+	namespace string
+	// generation changes on every invalidation, so that a value that was loaded while it was invalidated is not
+	// stored.
+	generation atomic.Int64
+	attachment *attachment
+
+	Charts *Charts
+	Events *Events
+}
+
+type Option func(cache *Cache)
+
+type cacheNS struct{}
+
+var CacheSupport cacheNS
+
+// TTL sets how long entries are kept. Defaults to 5 minutes.
+func (cacheNS) TTL(ttl time.Duration) Option {
+	return func(cache *Cache) {
+		cache.ttl = ttl
+	}
+}
+
+// Store replaces the in-memory LRU, which holds up to 1000 entries.
+func (cacheNS) Store(store Store) Option {
+	return func(cache *Cache) {
+		cache.store = store
+	}
+}
+
+// Namespace prefixes the keys of the entries, which is needed when caches of different workspaces share a Store.
+func (cacheNS) Namespace(namespace string) Option {
+	return func(cache *Cache) {
+		cache.namespace = namespace
+	}
+}
+
+// New creates a cache for the client, and makes the client invalidate it until Detach is called. Call Detach when
+// the cache is no longer used: until then, the client and the cache are kept alive.
+func New(client *seatsio.SeatsioClient, opts ...Option) *Cache {
+	cache := &Cache{store: NewLRU(1000), ttl: 5 * time.Minute}
+	for _, opt := range opts {
+		opt(cache)
+	}
+	cache.Charts = &Charts{Charts: client.Charts, cache: cache}
+	cache.Events = &Events{Events: client.Events, cache: cache}
+	cache.attachment = attach(client.Charts.Client, cache)
+	return cache
+}
+
+// Detach stops the client from invalidating the cache. The cache can still be used, but it only loses entries when
+// they expire or are invalidated explicitly.
+func (cache *Cache) Detach() {
+	cache.attachment.detach(cache)
+}
+
+// attachment holds the caches a client invalidates. A req.Client hook cannot be removed, so every client gets a
+// single hook, however many caches are created for it, and detaching a cache removes it from the set. When the last
+// cache is detached, the attachment is forgotten, so that it no longer keeps the client alive.
+type attachment struct {
+	mutex   sync.Mutex
+	client  *req.Client
+	caches  map[*Cache]bool
+	removed bool
+}
+
+var attachments sync.Map
+
+func attach(client *req.Client, cache *Cache) *attachment {
+	for {
+		value, loaded := attachments.LoadOrStore(client, &attachment{client: client, caches: map[*Cache]bool{}})
+		attachment := value.(*attachment)
+		attachment.mutex.Lock()
+		// the last cache of the attachment was detached after it was loaded, so a new one is needed
+		if attachment.removed {
+			attachment.mutex.Unlock()
+			continue
+		}
+		if !loaded {
+			client.OnAfterResponse(attachment.invalidate)
+		}
+		attachment.caches[cache] = true
+		attachment.mutex.Unlock()
+		return attachment
+	}
+}
+
+func (attachment *attachment) detach(cache *Cache) {
+	attachment.mutex.Lock()
+	defer attachment.mutex.Unlock()
+	delete(attachment.caches, cache)
+	if len(attachment.caches) == 0 && !attachment.removed {
+		attachment.removed = true
+		attachments.CompareAndDelete(attachment.client, attachment)
+	}
+}
+
+func (attachment *attachment) invalidate(client *req.Client, response *req.Response) error {
+	if response.Request == nil || response.Request.RawRequest == nil || response.Request.RawRequest.Method == http.MethodGet {
+		return nil
+	}
+	attachment.mutex.Lock()
+	caches := make([]*Cache, 0, len(attachment.caches))
+	for cache := range attachment.caches {
+		caches = append(caches, cache)
+	}
+	attachment.mutex.Unlock()
+	for _, cache := range caches {
+		cache.invalidatePath(response.Request.RawRequest.URL)
+	}
+	return nil
+}
+
+func (cache *Cache) chartKey(chartKey string) string {
+	return cache.namespace + "chart:" + chartKey
+}
+
+func (cache *Cache) categoriesKey(chartKey string) string {
+	return cache.namespace + "categories:" + chartKey
+}
+
+func (cache *Cache) eventKey(eventKey string) string {
+	return cache.namespace + "event:" + eventKey
+}
+
+// InvalidateChart removes a chart and its categories from the cache.
+func (cache *Cache) InvalidateChart(chartKey string) {
+	cache.generation.Add(1)
+	cache.store.Delete(cache.chartKey(chartKey), cache.categoriesKey(chartKey))
+}
+
+// InvalidateEvent removes an event, season or partial season from the cache.
+func (cache *Cache) InvalidateEvent(eventKey string) {
+	cache.generation.Add(1)
+	cache.store.Delete(cache.eventKey(eventKey))
+}
+
+// invalidatePath invalidates what a request to path may have changed: /charts/{key}/..., /events/{key}/... and
+// /seasons/{key}/partial-seasons/{key}/...
+func (cache *Cache) invalidatePath(requestUrl *url.URL) {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(requestUrl.EscapedPath(), "/"), "/") {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			unescaped = segment
+		}
+		segments = append(segments, unescaped)
+	}
+	if len(segments) < 2 {
+		return
+	}
+	switch segments[0] {
+	case "charts":
+		cache.InvalidateChart(segments[1])
+	case "events":
+		cache.InvalidateEvent(segments[1])
+	case "seasons":
+		cache.InvalidateEvent(segments[1])
+		if len(segments) >= 4 && segments[2] == "partial-seasons" {
+			cache.InvalidateEvent(segments[3])
+		}
+	}
+}
+
+func readThrough[T any](cache *Cache, key string, load func() (T, error)) (T, error) {
+	if data, ok := cache.store.Get(key); ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	}
+	generation := cache.generation.Load()
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	if data, err := json.Marshal(value); err == nil && cache.generation.Load() == generation {
+		cache.store.Set(key, data, cache.ttl)
+	}
+	return value, nil
+}
+
+type Charts struct {
+	*charts.Charts
+	cache *Cache
+}
+
+func (cached *Charts) Retrieve(context context.Context, chartKey string) (*charts.Chart, error) {
+	return readThrough(cached.cache, cached.cache.chartKey(chartKey), func() (*charts.Chart, error) {
+		return cached.Charts.Retrieve(context, chartKey)
+	})
+}
+
+func (cached *Charts) ListCategories(context context.Context, chartKey string) ([]events.Category, error) {
+	return readThrough(cached.cache, cached.cache.categoriesKey(chartKey), func() ([]events.Category, error) {
+		return cached.Charts.ListCategories(context, chartKey)
+	})
+}
+
+type Events struct {
+	*events.Events
+	cache *Cache
+}
+
+func (cached *Events) Retrieve(context context.Context, eventKey string) (*events.Event, error) {
+	return readThrough(cached.cache, cached.cache.eventKey(eventKey), func() (*events.Event, error) {
+		return cached.Events.Retrieve(context, eventKey)
+	})
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/eventlog"
+)
+
+// Invalidator follows the event log of a workspace, and invalidates the charts, events and seasons that were changed,
+// including by other clients and in the designer.
+type Invalidator struct {
+	Cache    *Cache
+	EventLog *eventlog.EventLog
+	// LastId is the id of the last event log item that was processed. Poll starts after it, so when it is 0 the
+	// whole event log is read first.
+	LastId int64
+}
+
+// Poll processes the event log items since the last poll.
+func (invalidator *Invalidator) Poll(context context.Context) error {
+	startAfter := invalidator.LastId
+	for {
+		page, err := invalidator.EventLog.ListPageAfter(context, startAfter)
+		if err != nil {
+			return err
+		}
+		for _, item := range page.Items {
+			invalidator.invalidate(item)
+			invalidator.LastId = max(invalidator.LastId, item.Id)
+		}
+		if len(page.Items) == 0 || page.NextPageStartsAfter == 0 {
+			return nil
+		}
+		startAfter = page.NextPageStartsAfter
+	}
+}
+
+// Run polls the event log every interval, until the context is done or a poll fails.
+func (invalidator *Invalidator) Run(context context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := invalidator.Poll(context); err != nil && context.Err() == nil {
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-context.Done():
+			return context.Err()
+		}
+	}
+}
+
+func (invalidator *Invalidator) invalidate(item eventlog.EventLogItem) {
+	key, ok := item.Data["key"].(string)
+	if !ok {
+		return
+	}
+	switch {
+	case strings.HasPrefix(item.Type, "chart."):
+		invalidator.Cache.InvalidateChart(key)
+	case strings.HasPrefix(item.Type, "event."), strings.HasPrefix(item.Type, "season."), strings.HasPrefix(item.Type, "partialSeason."):
+		invalidator.Cache.InvalidateEvent(key)
+	}
+}
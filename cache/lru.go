@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Store holds encoded cache entries. Implementations must be safe for concurrent use; a shared store, such as Redis,
+// can be plugged in instead of the in-memory LRU.
+type Store interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte, ttl time.Duration)
+	Delete(keys ...string)
+}
+
+// LRU is an in-memory Store that holds up to a maximum number of entries, and evicts the least recently used one
+// when it is full.
+type LRU struct {
+	maxEntries int
+	now        func() time.Time
+	mutex      sync.Mutex
+	entries    map[string]*list.Element
+	order      *list.List
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+func NewLRU(maxEntries int) *LRU {
+	return &LRU{maxEntries: max(maxEntries, 1), now: time.Now, entries: map[string]*list.Element{}, order: list.New()}
+}
+
+func (lru *LRU) Get(key string) ([]byte, bool) {
+	lru.mutex.Lock()
+	defer lru.mutex.Unlock()
+	element, ok := lru.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry := element.Value.(*lruEntry)
+	if !lru.now().Before(entry.expires) {
+		lru.remove(element)
+		return nil, false
+	}
+	lru.order.MoveToFront(element)
+	return entry.value, true
+}
+
+func (lru *LRU) Set(key string, value []byte, ttl time.Duration) {
+	lru.mutex.Lock()
+	defer lru.mutex.Unlock()
+	expires := lru.now().Add(ttl)
+	if element, ok := lru.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		lru.order.MoveToFront(element)
+		return
+	}
+	lru.entries[key] = lru.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	for lru.order.Len() > lru.maxEntries {
+		lru.remove(lru.order.Back())
+	}
+}
+
+func (lru *LRU) Delete(keys ...string) {
+	lru.mutex.Lock()
+	defer lru.mutex.Unlock()
+	for _, key := range keys {
+		if element, ok := lru.entries[key]; ok {
+			lru.remove(element)
+		}
+	}
+}
+
+func (lru *LRU) Len() int {
+	lru.mutex.Lock()
+	defer lru.mutex.Unlock()
+	return lru.order.Len()
+}
+
+func (lru *LRU) remove(element *list.Element) {
+	lru.order.Remove(element)
+	delete(lru.entries, element.Value.(*lruEntry).key)
+}
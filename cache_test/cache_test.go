@@ -0,0 +1,183 @@
+package cache_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/cache"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+// requestCounter counts the GET requests a client sends, per path.
+type requestCounter struct {
+	mutex    sync.Mutex
+	requests map[string]int
+}
+
+func (counter *requestCounter) count(path string) int {
+	counter.mutex.Lock()
+	defer counter.mutex.Unlock()
+	return counter.requests[path]
+}
+
+type testWorkspace struct {
+	client   *seatsio.SeatsioClient
+	requests *requestCounter
+	chartKey string
+	eventKey string
+}
+
+func newTestWorkspace(t *testing.T) *testWorkspace {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	counter := &requestCounter{requests: map[string]int{}}
+	client.Charts.Client.OnAfterResponse(func(_ *req.Client, response *req.Response) error {
+		if response.Request != nil && response.Request.RawRequest != nil && response.Request.RawRequest.Method == http.MethodGet {
+			counter.mutex.Lock()
+			counter.requests[response.Request.RawRequest.URL.Path]++
+			counter.mutex.Unlock()
+		}
+		return nil
+	})
+	return &testWorkspace{client: client, requests: counter, chartKey: chartKey, eventKey: event.Key}
+}
+
+// retrieve reads the chart, its categories and the event through the cache.
+func (workspace *testWorkspace) retrieve(t *testing.T, cached *cache.Cache) (*charts.Chart, []events.Category, *events.Event) {
+	chart, err := cached.Charts.Retrieve(test_util.RequestContext(), workspace.chartKey)
+	require.NoError(t, err)
+	categories, err := cached.Charts.ListCategories(test_util.RequestContext(), workspace.chartKey)
+	require.NoError(t, err)
+	event, err := cached.Events.Retrieve(test_util.RequestContext(), workspace.eventKey)
+	require.NoError(t, err)
+	return chart, categories, event
+}
+
+func (workspace *testWorkspace) chartRetrievals() int {
+	return workspace.requests.count("/charts/" + workspace.chartKey)
+}
+
+func TestRetrievalsAreCached(t *testing.T) {
+	t.Parallel()
+	workspace := newTestWorkspace(t)
+	cached := cache.New(workspace.client)
+
+	for i := 0; i < 3; i++ {
+		chart, categories, event := workspace.retrieve(t, cached)
+		require.Equal(t, workspace.chartKey, chart.Key)
+		require.NotEmpty(t, categories)
+		require.Equal(t, workspace.chartKey, event.ChartKey)
+	}
+
+	require.Equal(t, 1, workspace.chartRetrievals())
+	require.Equal(t, 1, workspace.requests.count("/charts/"+workspace.chartKey+"/categories"))
+	require.Equal(t, 1, workspace.requests.count("/events/"+workspace.eventKey))
+}
+
+func TestMutationsThroughTheClientInvalidate(t *testing.T) {
+	t.Parallel()
+	workspace := newTestWorkspace(t)
+	cached := cache.New(workspace.client)
+	_, categories, _ := workspace.retrieve(t, cached)
+
+	require.NoError(t, workspace.client.Charts.Update(test_util.RequestContext(), workspace.chartKey, &charts.UpdateChartParams{Name: "renamed"}))
+	require.NoError(t, workspace.client.Charts.AddCategory(test_util.RequestContext(), workspace.chartKey, events.Category{Key: events.CategoryKey{Key: "new"}, Label: "New", Color: "#aaaaaa"}))
+	require.NoError(t, workspace.client.Events.Update(test_util.RequestContext(), workspace.eventKey, &events.UpdateEventParams{EventParams: &events.EventParams{Name: "renamed"}}))
+
+	chart, updatedCategories, event := workspace.retrieve(t, cached)
+	require.Equal(t, "renamed", chart.Name)
+	require.Len(t, updatedCategories, len(categories)+1)
+	require.Equal(t, "renamed", event.Name)
+}
+
+func TestDetachedCachesAreNotInvalidated(t *testing.T) {
+	t.Parallel()
+	workspace := newTestWorkspace(t)
+	detached := cache.New(workspace.client)
+	attached := cache.New(workspace.client)
+	workspace.retrieve(t, detached)
+	workspace.retrieve(t, attached)
+	detached.Detach()
+
+	require.NoError(t, workspace.client.Charts.AddTag(test_util.RequestContext(), workspace.chartKey, "tag1"))
+
+	chart, _, _ := workspace.retrieve(t, detached)
+	require.Empty(t, chart.Tags)
+	chart, _, _ = workspace.retrieve(t, attached)
+	require.Equal(t, []string{"tag1"}, chart.Tags)
+}
+
+func TestCachesAreInvalidatedAfterAllCachesOfTheClientWereDetached(t *testing.T) {
+	t.Parallel()
+	workspace := newTestWorkspace(t)
+	cache.New(workspace.client).Detach()
+	cached := cache.New(workspace.client)
+	workspace.retrieve(t, cached)
+
+	require.NoError(t, workspace.client.Charts.AddTag(test_util.RequestContext(), workspace.chartKey, "tag1"))
+
+	chart, _, _ := workspace.retrieve(t, cached)
+	require.Equal(t, []string{"tag1"}, chart.Tags)
+	require.Equal(t, 2, workspace.chartRetrievals())
+}
+
+func TestInvalidatorFollowsTheEventLog(t *testing.T) {
+	t.Parallel()
+	workspace := newTestWorkspace(t)
+	cached := cache.New(workspace.client)
+	cached.Detach()
+	invalidator := &cache.Invalidator{Cache: cached, EventLog: workspace.client.EventLog}
+	time.Sleep(2 * time.Second)
+	require.NoError(t, invalidator.Poll(test_util.RequestContext()))
+	workspace.retrieve(t, cached)
+
+	require.NoError(t, workspace.client.Charts.Update(test_util.RequestContext(), workspace.chartKey, &charts.UpdateChartParams{Name: "renamed"}))
+	require.NoError(t, workspace.client.Events.Update(test_util.RequestContext(), workspace.eventKey, &events.UpdateEventParams{EventParams: &events.EventParams{Name: "renamed"}}))
+	time.Sleep(2 * time.Second)
+	lastId := invalidator.LastId
+	require.NoError(t, invalidator.Poll(test_util.RequestContext()))
+
+	require.Greater(t, invalidator.LastId, lastId)
+	chart, _, event := workspace.retrieve(t, cached)
+	require.Equal(t, "renamed", chart.Name)
+	require.Equal(t, "renamed", event.Name)
+}
+
+func TestLRUEvictsTheLeastRecentlyUsedEntry(t *testing.T) {
+	t.Parallel()
+	lru := cache.NewLRU(2)
+	lru.Set("a", []byte("1"), time.Minute)
+	lru.Set("b", []byte("2"), time.Minute)
+	_, _ = lru.Get("a")
+
+	lru.Set("c", []byte("3"), time.Minute)
+
+	require.Equal(t, 2, lru.Len())
+	_, ok := lru.Get("b")
+	require.False(t, ok)
+	value, ok := lru.Get("a")
+	require.True(t, ok)
+	require.Equal(t, []byte("1"), value)
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	t.Parallel()
+	lru := cache.NewLRU(10)
+	lru.Set("a", []byte("1"), time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := lru.Get("a")
+	require.False(t, ok)
+	require.Equal(t, 0, lru.Len())
+}
@@ -0,0 +1,288 @@
+package channelsync
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/reports"
+)
+
+type ChannelShare struct {
+	Key   string
+	Name  string
+	Color string
+	Share float64
+}
+
+type Allocation struct {
+	Channels []events.CreateChannelParams
+	// TotalCapacity is the number of places that were allocated, seats and general admission places together.
+	TotalCapacity int
+	// Achieved holds the fraction of TotalCapacity that ended up in every channel, by channel key.
+	Achieved map[string]float64
+	// AchievedByCategory holds the achieved fractions per category label, by channel key.
+	AchievedByCategory map[string]map[string]float64
+}
+
+// allocationUnit is a group of objects that must end up in the same channel, such as a row or a table.
+type allocationUnit struct {
+	objects  []string
+	size     int
+	category string
+}
+
+type gaArea struct {
+	label    string
+	capacity int
+	category string
+}
+
+// Allocate divides the objects of a chart report (preferably a ByLabel report) over channels according to the
+// given shares. Rows and tables are never split, and every category is divided separately so that each channel
+// gets its share of every category. General admission areas are divided using area places.
+func Allocate(report *reports.ChartReport, shares []ChannelShare) (*Allocation, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("at least one channel share is required")
+	}
+	totalShare := 0.0
+	for _, share := range shares {
+		if share.Share < 0 {
+			return nil, errors.New("channel shares must not be negative")
+		}
+		totalShare += share.Share
+	}
+	if totalShare <= 0 {
+		return nil, errors.New("channel shares must add up to more than zero")
+	}
+	normalized := make([]float64, len(shares))
+	for i, share := range shares {
+		normalized[i] = share.Share / totalShare
+	}
+
+	units, areas := allocationUnits(report)
+	unitsByCategory := map[string][]allocationUnit{}
+	areasByCategory := map[string][]gaArea{}
+	for _, unit := range units {
+		unitsByCategory[unit.category] = append(unitsByCategory[unit.category], unit)
+	}
+	for _, area := range areas {
+		areasByCategory[area.category] = append(areasByCategory[area.category], area)
+	}
+	categories := map[string]bool{}
+	for category := range unitsByCategory {
+		categories[category] = true
+	}
+	for category := range areasByCategory {
+		categories[category] = true
+	}
+
+	channels := make([]events.CreateChannelParams, len(shares))
+	for i, share := range shares {
+		channels[i] = events.CreateChannelParams{Key: share.Key, Name: share.Name, Color: share.Color, Index: i + 1}
+	}
+	allocatedPerCategory := map[string][]int{}
+	for _, category := range slices.Sorted(maps.Keys(categories)) {
+		allocated := allocateCategory(unitsByCategory[category], areasByCategory[category], normalized, channels)
+		allocatedPerCategory[category] = allocated
+	}
+	for i := range channels {
+		sort.Strings(channels[i].Objects)
+	}
+	return achievedShares(channels, shares, allocatedPerCategory), nil
+}
+
+func allocateCategory(units []allocationUnit, areas []gaArea, shares []float64, channels []events.CreateChannelParams) []int {
+	capacity := 0
+	for _, unit := range units {
+		capacity += unit.size
+	}
+	for _, area := range areas {
+		capacity += area.capacity
+	}
+	targets := make([]float64, len(shares))
+	for i, share := range shares {
+		targets[i] = share * float64(capacity)
+	}
+	allocated := make([]int, len(shares))
+
+	sort.SliceStable(units, func(i, j int) bool {
+		if units[i].size != units[j].size {
+			return units[i].size > units[j].size
+		}
+		return units[i].objects[0] < units[j].objects[0]
+	})
+	for _, unit := range units {
+		best := 0
+		for i := range shares {
+			if targets[i]-float64(allocated[i]) > targets[best]-float64(allocated[best]) {
+				best = i
+			}
+		}
+		channels[best].Objects = append(channels[best].Objects, unit.objects...)
+		allocated[best] += unit.size
+	}
+
+	sort.Slice(areas, func(i, j int) bool { return areas[i].label < areas[j].label })
+	for _, area := range areas {
+		deficits := make([]float64, len(shares))
+		totalDeficit := 0.0
+		for i := range shares {
+			deficits[i] = max(0, targets[i]-float64(allocated[i]))
+			totalDeficit += deficits[i]
+		}
+		if totalDeficit == 0 {
+			copy(deficits, shares)
+			totalDeficit = 1
+		}
+		places := splitByLargestRemainder(area.capacity, deficits, totalDeficit)
+		for i, numPlaces := range places {
+			if numPlaces == 0 {
+				continue
+			}
+			if channels[i].AreaPlaces == nil {
+				channels[i].AreaPlaces = map[string]int{}
+			}
+			channels[i].AreaPlaces[area.label] = numPlaces
+			allocated[i] += numPlaces
+		}
+	}
+	return allocated
+}
+
+func splitByLargestRemainder(total int, weights []float64, totalWeight float64) []int {
+	result := make([]int, len(weights))
+	remainders := make([]float64, len(weights))
+	assigned := 0
+	for i, weight := range weights {
+		exact := float64(total) * weight / totalWeight
+		result[i] = int(exact)
+		remainders[i] = exact - float64(result[i])
+		assigned += result[i]
+	}
+	order := make([]int, len(weights))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool { return remainders[order[i]] > remainders[order[j]] })
+	for i := 0; assigned < total; i++ {
+		result[order[i%len(order)]]++
+		assigned++
+	}
+	return result
+}
+
+func achievedShares(channels []events.CreateChannelParams, shares []ChannelShare, allocatedPerCategory map[string][]int) *Allocation {
+	allocation := &Allocation{
+		Channels:           channels,
+		Achieved:           map[string]float64{},
+		AchievedByCategory: map[string]map[string]float64{},
+	}
+	totals := make([]int, len(shares))
+	for category, allocated := range allocatedPerCategory {
+		categoryTotal := 0
+		for i, count := range allocated {
+			totals[i] += count
+			categoryTotal += count
+		}
+		allocation.TotalCapacity += categoryTotal
+		allocation.AchievedByCategory[category] = map[string]float64{}
+		for i, count := range allocated {
+			allocation.AchievedByCategory[category][shares[i].Key] = fraction(count, categoryTotal)
+		}
+	}
+	for i, share := range shares {
+		allocation.Achieved[share.Key] = fraction(totals[i], allocation.TotalCapacity)
+	}
+	return allocation
+}
+
+func fraction(count int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(count) / float64(total)
+}
+
+// allocationUnits groups seats by row or table, and additionally joins seats that are each other's neighbours.
+func allocationUnits(report *reports.ChartReport) ([]allocationUnit, []gaArea) {
+	items := map[string]reports.ChartReportItem{}
+	for _, reportItems := range report.Items {
+		for _, item := range reportItems {
+			items[item.Label] = item
+		}
+	}
+
+	var areas []gaArea
+	parents := map[string]string{}
+	var find func(label string) string
+	find = func(label string) string {
+		if parents[label] == label {
+			return label
+		}
+		parents[label] = find(parents[label])
+		return parents[label]
+	}
+	union := func(a string, b string) {
+		parents[find(a)] = find(b)
+	}
+	groupRoots := map[string]string{}
+	for _, label := range slices.Sorted(maps.Keys(items)) {
+		item := items[label]
+		if item.ObjectType == "generalAdmission" {
+			areas = append(areas, gaArea{label: label, capacity: item.Capacity, category: item.CategoryLabel})
+			continue
+		}
+		parents[label] = label
+	}
+	for _, label := range slices.Sorted(maps.Keys(parents)) {
+		item := items[label]
+		if item.Labels.Parent.Label != "" {
+			group := item.Labels.Section + "\x00" + item.Labels.Parent.Type + "\x00" + item.Labels.Parent.Label
+			if root, ok := groupRoots[group]; ok {
+				union(label, root)
+			} else {
+				groupRoots[group] = label
+			}
+		}
+		for _, neighbour := range []string{item.LeftNeighbour, item.RightNeighbour} {
+			if _, ok := parents[neighbour]; ok && neighbour != "" {
+				union(label, neighbour)
+			}
+		}
+	}
+
+	members := map[string][]string{}
+	for _, label := range slices.Sorted(maps.Keys(parents)) {
+		root := find(label)
+		members[root] = append(members[root], label)
+	}
+	var units []allocationUnit
+	for _, root := range slices.Sorted(maps.Keys(members)) {
+		unit := allocationUnit{objects: members[root]}
+		categoryCounts := map[string]int{}
+		for _, label := range unit.objects {
+			size := objectSize(items[label])
+			unit.size += size
+			categoryCounts[items[label].CategoryLabel] += size
+		}
+		bestCount := 0
+		for _, category := range slices.Sorted(maps.Keys(categoryCounts)) {
+			if categoryCounts[category] > bestCount {
+				unit.category = category
+				bestCount = categoryCounts[category]
+			}
+		}
+		units = append(units, unit)
+	}
+	return units, areas
+}
+
+func objectSize(item reports.ChartReportItem) int {
+	if item.ObjectType == "table" && item.NumSeats > 0 {
+		return item.NumSeats
+	}
+	return 1
+}
@@ -0,0 +1,65 @@
+package channelsync
+
+import (
+	"encoding/json"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"gopkg.in/yaml.v3"
+)
+
+// DesiredChannel is a channel as it should be on an event. A channel with Index 0 keeps the index it has.
+type DesiredChannel struct {
+	Key        string         `json:"key" yaml:"key"`
+	Name       string         `json:"name" yaml:"name"`
+	Color      string         `json:"color" yaml:"color"`
+	Index      int            `json:"index,omitempty" yaml:"index,omitempty"`
+	Objects    []string       `json:"objects,omitempty" yaml:"objects,omitempty"`
+	AreaPlaces map[string]int `json:"areaPlaces,omitempty" yaml:"areaPlaces,omitempty"`
+}
+
+type desiredChannels struct {
+	Channels []DesiredChannel `json:"channels" yaml:"channels"`
+}
+
+// ParseYAML reads a document of the form `channels: [{key, name, color, objects, areaPlaces}]`.
+func ParseYAML(data []byte) ([]DesiredChannel, error) {
+	var document desiredChannels
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document.Channels, nil
+}
+
+func ParseJSON(data []byte) ([]DesiredChannel, error) {
+	var document desiredChannels
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, err
+	}
+	return document.Channels, nil
+}
+
+func FromChannels(channels []events.Channel) []DesiredChannel {
+	result := make([]DesiredChannel, len(channels))
+	for i, channel := range channels {
+		result[i] = DesiredChannel{
+			Key:        channel.Key,
+			Name:       channel.Name,
+			Color:      channel.Color,
+			Index:      channel.Index,
+			Objects:    channel.Objects,
+			AreaPlaces: channel.AreaPlaces,
+		}
+	}
+	return result
+}
+
+func (channel DesiredChannel) toCreateChannelParams() *events.CreateChannelParams {
+	return &events.CreateChannelParams{
+		Key:        channel.Key,
+		Name:       channel.Name,
+		Color:      channel.Color,
+		Index:      channel.Index,
+		Objects:    channel.Objects,
+		AreaPlaces: channel.AreaPlaces,
+	}
+}
@@ -0,0 +1,178 @@
+package channelsync
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+type ActionType string
+
+const (
+	CreateChannel   ActionType = "CREATE_CHANNEL"
+	UpdateChannel   ActionType = "UPDATE_CHANNEL"
+	DeleteChannel   ActionType = "DELETE_CHANNEL"
+	AddObjects      ActionType = "ADD_OBJECTS"
+	RemoveObjects   ActionType = "REMOVE_OBJECTS"
+	ReplaceChannels ActionType = "REPLACE_CHANNELS"
+)
+
+type Action struct {
+	Type       ActionType
+	ChannelKey string
+	Create     *events.CreateChannelParams
+	Update     *events.UpdateChannelParams
+	Objects    []string
+	Replace    []events.CreateChannelParams
+}
+
+func (action Action) String() string {
+	switch action.Type {
+	case CreateChannel:
+		return fmt.Sprintf("create channel %s (%d objects)", action.ChannelKey, len(action.Create.Objects))
+	case UpdateChannel:
+		return fmt.Sprintf("update channel %s", action.ChannelKey)
+	case DeleteChannel:
+		return fmt.Sprintf("delete channel %s", action.ChannelKey)
+	case AddObjects:
+		return fmt.Sprintf("add %d objects to channel %s", len(action.Objects), action.ChannelKey)
+	case RemoveObjects:
+		return fmt.Sprintf("remove %d objects from channel %s", len(action.Objects), action.ChannelKey)
+	case ReplaceChannels:
+		return fmt.Sprintf("replace all channels with %d channels, to change their order", len(action.Replace))
+	}
+	return string(action.Type)
+}
+
+type Plan struct {
+	EventKey string
+	Actions  []Action
+}
+
+func (plan Plan) IsEmpty() bool {
+	return len(plan.Actions) == 0
+}
+
+// Validate checks that every desired channel has a key, and that no key is used twice.
+func Validate(desired []DesiredChannel) error {
+	seen := map[string]bool{}
+	for _, channel := range desired {
+		if channel.Key == "" {
+			return errors.New("desired channel without a key")
+		}
+		if seen[channel.Key] {
+			return fmt.Errorf("desired channel %s is listed more than once", channel.Key)
+		}
+		seen[channel.Key] = true
+	}
+	return nil
+}
+
+// PlanFor computes the changes needed to turn the channels of the event into the desired channels. Objects are
+// removed from channels before they are added to others, since an object can only be in one channel at a time.
+// The index of a channel cannot be updated, so when it differs all channels are replaced at once instead. Desired
+// channels without an index keep the index they have.
+func PlanFor(event *events.Event, desired []DesiredChannel) (Plan, error) {
+	if err := Validate(desired); err != nil {
+		return Plan{}, err
+	}
+	current := map[string]events.Channel{}
+	for _, channel := range event.Channels {
+		current[channel.Key] = channel
+	}
+	wanted := map[string]DesiredChannel{}
+	for _, channel := range desired {
+		wanted[channel.Key] = channel
+	}
+	if indexChanged(current, wanted) {
+		replace := make([]events.CreateChannelParams, len(desired))
+		for i, channel := range desired {
+			replace[i] = *channel.toCreateChannelParams()
+		}
+		return Plan{EventKey: event.Key, Actions: []Action{{Type: ReplaceChannels, Replace: replace}}}, nil
+	}
+
+	var deletes, removals, updates, creates, additions []Action
+	for _, key := range slices.Sorted(maps.Keys(current)) {
+		channel := current[key]
+		desiredChannel, ok := wanted[key]
+		if !ok {
+			deletes = append(deletes, Action{Type: DeleteChannel, ChannelKey: key})
+			continue
+		}
+		if toRemove := difference(channel.Objects, desiredChannel.Objects); len(toRemove) > 0 {
+			removals = append(removals, Action{Type: RemoveObjects, ChannelKey: key, Objects: toRemove})
+		}
+		if update := updateParams(channel, desiredChannel); update != nil {
+			updates = append(updates, Action{Type: UpdateChannel, ChannelKey: key, Update: update})
+		}
+		if toAdd := difference(desiredChannel.Objects, channel.Objects); len(toAdd) > 0 {
+			additions = append(additions, Action{Type: AddObjects, ChannelKey: key, Objects: toAdd})
+		}
+	}
+	for _, channel := range desired {
+		if _, ok := current[channel.Key]; !ok {
+			creates = append(creates, Action{Type: CreateChannel, ChannelKey: channel.Key, Create: channel.toCreateChannelParams()})
+		}
+	}
+
+	var actions []Action
+	actions = append(actions, deletes...)
+	actions = append(actions, removals...)
+	actions = append(actions, updates...)
+	actions = append(actions, creates...)
+	actions = append(actions, additions...)
+	return Plan{EventKey: event.Key, Actions: actions}, nil
+}
+
+func indexChanged(current map[string]events.Channel, wanted map[string]DesiredChannel) bool {
+	for key, channel := range current {
+		// an index of 0 leaves the index of the channel as it is
+		if desiredChannel, ok := wanted[key]; ok && desiredChannel.Index != 0 && desiredChannel.Index != channel.Index {
+			return true
+		}
+	}
+	return false
+}
+
+func updateParams(current events.Channel, desired DesiredChannel) *events.UpdateChannelParams {
+	params := events.UpdateChannelParams{}
+	changed := false
+	if current.Name != desired.Name {
+		params.Name = desired.Name
+		changed = true
+	}
+	if current.Color != desired.Color {
+		params.Color = desired.Color
+		changed = true
+	}
+	if !maps.Equal(current.AreaPlaces, desired.AreaPlaces) {
+		// an empty, non-nil map is sent to remove all area places
+		params.AreaPlaces = map[string]int{}
+		maps.Copy(params.AreaPlaces, desired.AreaPlaces)
+		changed = true
+	}
+	if !changed {
+		return nil
+	}
+	return &params
+}
+
+func difference(objects []string, toSubtract []string) []string {
+	subtract := map[string]bool{}
+	for _, object := range toSubtract {
+		subtract[object] = true
+	}
+	var result []string
+	for _, object := range objects {
+		if !subtract[object] {
+			result = append(result, object)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
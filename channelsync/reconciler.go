@@ -0,0 +1,137 @@
+package channelsync
+
+import (
+	"context"
+	"sync"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+type Reconciler struct {
+	Events      *events.Events
+	Channels    *events.Channels
+	Concurrency int
+	DryRun      bool
+}
+
+type EventResult struct {
+	EventKey string
+	Applied  []Action
+	Err      error
+}
+
+type Summary struct {
+	DryRun  bool
+	Results []EventResult
+}
+
+func (summary *Summary) Count(actionType ActionType) int {
+	count := 0
+	for _, result := range summary.Results {
+		for _, action := range result.Applied {
+			if action.Type == actionType {
+				count++
+			}
+		}
+	}
+	return count
+}
+
+func (summary *Summary) Failed() []EventResult {
+	var failed []EventResult
+	for _, result := range summary.Results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Plan retrieves every event (or season) and computes the changes needed to reach the desired channels.
+func (reconciler *Reconciler) Plan(context context.Context, eventKeys []string, desired []DesiredChannel) ([]Plan, error) {
+	plans := make([]Plan, len(eventKeys))
+	errs := make([]error, len(eventKeys))
+	reconciler.forEach(len(eventKeys), func(i int) {
+		event, err := reconciler.Events.Retrieve(context, eventKeys[i])
+		if err != nil {
+			errs[i] = err
+			return
+		}
+		plans[i], errs[i] = PlanFor(event, desired)
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plans, nil
+}
+
+// Apply executes the plans. In dry-run mode nothing is sent, and the summary lists what would have been done.
+// Applying stops at the first failing action of an event; other events are not affected.
+func (reconciler *Reconciler) Apply(context context.Context, plans []Plan) *Summary {
+	results := make([]EventResult, len(plans))
+	reconciler.forEach(len(plans), func(i int) {
+		results[i] = reconciler.applyPlan(context, plans[i])
+	})
+	return &Summary{DryRun: reconciler.DryRun, Results: results}
+}
+
+func (reconciler *Reconciler) Reconcile(context context.Context, eventKeys []string, desired []DesiredChannel) (*Summary, error) {
+	plans, err := reconciler.Plan(context, eventKeys, desired)
+	if err != nil {
+		return nil, err
+	}
+	return reconciler.Apply(context, plans), nil
+}
+
+func (reconciler *Reconciler) applyPlan(context context.Context, plan Plan) EventResult {
+	result := EventResult{EventKey: plan.EventKey}
+	for _, action := range plan.Actions {
+		if !reconciler.DryRun {
+			if err := reconciler.applyAction(context, plan.EventKey, action); err != nil {
+				result.Err = err
+				return result
+			}
+		}
+		result.Applied = append(result.Applied, action)
+	}
+	return result
+}
+
+func (reconciler *Reconciler) applyAction(context context.Context, eventKey string, action Action) error {
+	switch action.Type {
+	case CreateChannel:
+		return reconciler.Channels.Create(context, eventKey, action.Create)
+	case UpdateChannel:
+		return reconciler.Channels.Update(context, eventKey, action.ChannelKey, *action.Update)
+	case DeleteChannel:
+		return reconciler.Channels.Delete(context, eventKey, action.ChannelKey)
+	case AddObjects:
+		return reconciler.Channels.AddObjects(context, eventKey, action.ChannelKey, action.Objects)
+	case RemoveObjects:
+		return reconciler.Channels.RemoveObjects(context, eventKey, action.ChannelKey, action.Objects)
+	case ReplaceChannels:
+		return reconciler.Channels.Replace(context, eventKey, action.Replace...)
+	}
+	return nil
+}
+
+func (reconciler *Reconciler) forEach(count int, fn func(i int)) {
+	concurrency := reconciler.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,121 @@
+package channelsync_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/reports"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func rowOfSeats(row string, numSeats int, category string) []reports.ChartReportItem {
+	var items []reports.ChartReportItem
+	for i := 1; i <= numSeats; i++ {
+		item := reports.ChartReportItem{
+			Label:         fmt.Sprintf("%s-%d", row, i),
+			Labels:        events.Labels{Own: events.LabelAndType{Label: fmt.Sprint(i), Type: "seat"}, Parent: events.LabelAndType{Label: row, Type: "row"}},
+			CategoryLabel: category,
+			ObjectType:    "seat",
+		}
+		if i > 1 {
+			item.LeftNeighbour = fmt.Sprintf("%s-%d", row, i-1)
+		}
+		if i < numSeats {
+			item.RightNeighbour = fmt.Sprintf("%s-%d", row, i+1)
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+func chartReportOf(items ...[]reports.ChartReportItem) *reports.ChartReport {
+	report := &reports.ChartReport{Items: map[string][]reports.ChartReportItem{}}
+	for _, group := range items {
+		for _, item := range group {
+			report.Items[item.Label] = []reports.ChartReportItem{item}
+		}
+	}
+	return report
+}
+
+func channelWithKey(allocation *channelsync.Allocation, key string) events.CreateChannelParams {
+	for _, channel := range allocation.Channels {
+		if channel.Key == key {
+			return channel
+		}
+	}
+	return events.CreateChannelParams{}
+}
+
+func TestAllocateKeepsRowsTogether(t *testing.T) {
+	t.Parallel()
+	report := chartReportOf(
+		rowOfSeats("A", 6, "Cat1"),
+		rowOfSeats("B", 2, "Cat1"),
+		rowOfSeats("C", 2, "Cat1"),
+	)
+
+	allocation, err := channelsync.Allocate(report, []channelsync.ChannelShare{{Key: "boxOffice", Share: 60}, {Key: "reseller", Share: 40}})
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"A-1", "A-2", "A-3", "A-4", "A-5", "A-6"}, channelWithKey(allocation, "boxOffice").Objects)
+	require.Equal(t, []string{"B-1", "B-2", "C-1", "C-2"}, channelWithKey(allocation, "reseller").Objects)
+	require.Equal(t, 10, allocation.TotalCapacity)
+	require.InDelta(t, 0.6, allocation.Achieved["boxOffice"], 0.001)
+	require.InDelta(t, 0.4, allocation.Achieved["reseller"], 0.001)
+}
+
+func TestAllocateBalancesCategories(t *testing.T) {
+	t.Parallel()
+	report := chartReportOf(
+		rowOfSeats("A", 2, "Cat1"),
+		rowOfSeats("B", 2, "Cat1"),
+		rowOfSeats("C", 2, "Cat2"),
+		rowOfSeats("D", 2, "Cat2"),
+	)
+
+	allocation, err := channelsync.Allocate(report, []channelsync.ChannelShare{{Key: "c1", Share: 1}, {Key: "c2", Share: 1}})
+	require.NoError(t, err)
+
+	require.InDelta(t, 0.5, allocation.AchievedByCategory["Cat1"]["c1"], 0.001)
+	require.InDelta(t, 0.5, allocation.AchievedByCategory["Cat2"]["c1"], 0.001)
+}
+
+func TestAllocateSplitsGeneralAdmissionAreas(t *testing.T) {
+	t.Parallel()
+	report := chartReportOf([]reports.ChartReportItem{{Label: "GA1", ObjectType: "generalAdmission", Capacity: 100, CategoryLabel: "Cat1"}})
+
+	allocation, err := channelsync.Allocate(report, []channelsync.ChannelShare{{Key: "boxOffice", Share: 60}, {Key: "resellerA", Share: 25}, {Key: "resellerB", Share: 15}})
+	require.NoError(t, err)
+
+	require.Equal(t, map[string]int{"GA1": 60}, channelWithKey(allocation, "boxOffice").AreaPlaces)
+	require.Equal(t, map[string]int{"GA1": 25}, channelWithKey(allocation, "resellerA").AreaPlaces)
+	require.Equal(t, map[string]int{"GA1": 15}, channelWithKey(allocation, "resellerB").AreaPlaces)
+}
+
+func TestAllocateRejectsInvalidShares(t *testing.T) {
+	t.Parallel()
+	_, err := channelsync.Allocate(chartReportOf(), []channelsync.ChannelShare{{Key: "c1", Share: 0}})
+
+	require.EqualError(t, err, "channel shares must add up to more than zero")
+}
+
+func TestAllocateChartReport(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	report, err := client.ChartReports.ByLabel(test_util.RequestContext(), chartKey)
+	require.NoError(t, err)
+
+	allocation, err := channelsync.Allocate(report, []channelsync.ChannelShare{{Key: "c1", Name: "Channel 1", Color: "#FF0000", Share: 1}, {Key: "c2", Name: "Channel 2", Color: "#00FF00", Share: 1}})
+	require.NoError(t, err)
+
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey, EventParams: &events.EventParams{Channels: &allocation.Channels}})
+	require.NoError(t, err)
+	require.Len(t, event.Channels, 2)
+}
@@ -0,0 +1,136 @@
+package channelsync_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYAML(t *testing.T) {
+	t.Parallel()
+	desired, err := channelsync.ParseYAML([]byte(`
+channels:
+  - key: boxOffice
+    name: Box office
+    color: "#FF0000"
+    objects: [A-1, A-2]
+  - key: reseller
+    name: Reseller
+    color: "#00FF00"
+    areaPlaces:
+      GA1: 10
+`))
+	require.NoError(t, err)
+
+	require.Len(t, desired, 2)
+	require.Equal(t, []string{"A-1", "A-2"}, desired[0].Objects)
+	require.Equal(t, map[string]int{"GA1": 10}, desired[1].AreaPlaces)
+}
+
+func TestPlanIsEmptyWhenChannelsMatch(t *testing.T) {
+	t.Parallel()
+	event := &events.Event{Key: "event1", Channels: []events.Channel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Objects: []string{"A-1", "A-2"}},
+	}}
+
+	plan, err := channelsync.PlanFor(event, channelsync.FromChannels(event.Channels))
+
+	require.NoError(t, err)
+	require.True(t, plan.IsEmpty())
+}
+
+func TestPlanComputesMinimalChanges(t *testing.T) {
+	t.Parallel()
+	event := &events.Event{Key: "event1", Channels: []events.Channel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Objects: []string{"A-1", "A-2"}},
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Objects: []string{"B-1"}},
+		{Key: "obsolete", Name: "Obsolete", Color: "#000000"},
+	}}
+	desired := []channelsync.DesiredChannel{
+		{Key: "c1", Name: "Renamed", Color: "#FFFFFF", Objects: []string{"A-1", "B-1"}},
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Objects: []string{"A-2"}},
+		{Key: "c3", Name: "Channel 3", Color: "#123456", Objects: []string{"C-1"}},
+	}
+
+	plan, err := channelsync.PlanFor(event, desired)
+
+	require.NoError(t, err)
+	require.Equal(t, "event1", plan.EventKey)
+	require.Equal(t, []channelsync.Action{
+		{Type: channelsync.DeleteChannel, ChannelKey: "obsolete"},
+		{Type: channelsync.RemoveObjects, ChannelKey: "c1", Objects: []string{"A-2"}},
+		{Type: channelsync.RemoveObjects, ChannelKey: "c2", Objects: []string{"B-1"}},
+		{Type: channelsync.UpdateChannel, ChannelKey: "c1", Update: &events.UpdateChannelParams{Name: "Renamed"}},
+		{Type: channelsync.CreateChannel, ChannelKey: "c3", Create: &events.CreateChannelParams{Key: "c3", Name: "Channel 3", Color: "#123456", Objects: []string{"C-1"}}},
+		{Type: channelsync.AddObjects, ChannelKey: "c1", Objects: []string{"B-1"}},
+		{Type: channelsync.AddObjects, ChannelKey: "c2", Objects: []string{"A-2"}},
+	}, plan.Actions)
+}
+
+func TestPlanRemovesAreaPlaces(t *testing.T) {
+	t.Parallel()
+	event := &events.Event{Key: "event1", Channels: []events.Channel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", AreaPlaces: map[string]int{"GA1": 10}},
+	}}
+	desired := []channelsync.DesiredChannel{{Key: "c1", Name: "Channel 1", Color: "#FFFFFF"}}
+
+	plan, err := channelsync.PlanFor(event, desired)
+
+	require.NoError(t, err)
+	require.Equal(t, []channelsync.Action{
+		{Type: channelsync.UpdateChannel, ChannelKey: "c1", Update: &events.UpdateChannelParams{AreaPlaces: map[string]int{}}},
+	}, plan.Actions)
+}
+
+func TestPlanReplacesChannelsWhenTheirIndexChanges(t *testing.T) {
+	t.Parallel()
+	event := &events.Event{Key: "event1", Channels: []events.Channel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Index: 1, Objects: []string{"A-1"}},
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Index: 2},
+	}}
+	desired := []channelsync.DesiredChannel{
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Index: 1},
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Index: 2, Objects: []string{"A-1"}},
+	}
+
+	plan, err := channelsync.PlanFor(event, desired)
+
+	require.NoError(t, err)
+	require.Equal(t, []channelsync.Action{{Type: channelsync.ReplaceChannels, Replace: []events.CreateChannelParams{
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Index: 1},
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Index: 2, Objects: []string{"A-1"}},
+	}}}, plan.Actions)
+}
+
+func TestPlanKeepsTheIndexOfChannelsWithoutOne(t *testing.T) {
+	t.Parallel()
+	event := &events.Event{Key: "event1", Channels: []events.Channel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Index: 1, Objects: []string{"A-1"}},
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Index: 2},
+	}}
+	desired := []channelsync.DesiredChannel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Objects: []string{"A-1", "A-2"}},
+		{Key: "c2", Name: "Channel 2", Color: "#000000", Index: 2},
+	}
+
+	plan, err := channelsync.PlanFor(event, desired)
+
+	require.NoError(t, err)
+	require.Equal(t, []channelsync.Action{
+		{Type: channelsync.AddObjects, ChannelKey: "c1", Objects: []string{"A-2"}},
+	}, plan.Actions)
+}
+
+func TestPlanRejectsDuplicateKeys(t *testing.T) {
+	t.Parallel()
+	desired := []channelsync.DesiredChannel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF"},
+		{Key: "c1", Name: "Channel 1 again", Color: "#000000"},
+	}
+
+	_, err := channelsync.PlanFor(&events.Event{Key: "event1"}, desired)
+
+	require.ErrorContains(t, err, "c1 is listed more than once")
+}
@@ -0,0 +1,62 @@
+package channelsync_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event1, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	event2, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	err = client.Channels.Create(test_util.RequestContext(), event1.Key, &events.CreateChannelParams{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Objects: []string{"A-1", "A-2"}})
+	require.NoError(t, err)
+	desired := []channelsync.DesiredChannel{
+		{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Objects: []string{"A-1", "A-3"}},
+	}
+	reconciler := &channelsync.Reconciler{Events: client.Events, Channels: client.Channels, Concurrency: 2}
+
+	summary, err := reconciler.Reconcile(test_util.RequestContext(), []string{event1.Key, event2.Key}, desired)
+	require.NoError(t, err)
+
+	require.Empty(t, summary.Failed())
+	require.Equal(t, 1, summary.Count(channelsync.CreateChannel))
+	require.Equal(t, 1, summary.Count(channelsync.AddObjects))
+	require.Equal(t, 1, summary.Count(channelsync.RemoveObjects))
+	for _, eventKey := range []string{event1.Key, event2.Key} {
+		retrievedEvent, err := client.Events.Retrieve(test_util.RequestContext(), eventKey)
+		require.NoError(t, err)
+		require.Len(t, retrievedEvent.Channels, 1)
+		require.ElementsMatch(t, []string{"A-1", "A-3"}, retrievedEvent.Channels[0].Objects)
+	}
+}
+
+func TestReconcileDryRun(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	desired := []channelsync.DesiredChannel{{Key: "c1", Name: "Channel 1", Color: "#FFFFFF", Objects: []string{"A-1"}}}
+	reconciler := &channelsync.Reconciler{Events: client.Events, Channels: client.Channels, DryRun: true}
+
+	summary, err := reconciler.Reconcile(test_util.RequestContext(), []string{event.Key}, desired)
+	require.NoError(t, err)
+
+	require.True(t, summary.DryRun)
+	require.Equal(t, 1, summary.Count(channelsync.CreateChannel))
+	retrievedEvent, err := client.Events.Retrieve(test_util.RequestContext(), event.Key)
+	require.NoError(t, err)
+	require.Empty(t, retrievedEvent.Channels)
+}
@@ -0,0 +1,235 @@
+package charts
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+type ThumbnailVersion string
+
+const (
+	PublishedVersion ThumbnailVersion = "published"
+	DraftVersion     ThumbnailVersion = "draft"
+)
+
+type ThumbnailInfo struct {
+	ContentType string `json:"contentType"`
+	// Size is -1 when the API did not send a Content-Length.
+	Size         int64  `json:"size"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Thumbnail streams a thumbnail. It must be closed. When the thumbnail was requested conditionally and did not
+// change, NotModified is set and there is nothing to read.
+type Thumbnail struct {
+	ThumbnailInfo
+	io.ReadCloser
+	NotModified bool
+}
+
+// ThumbnailConditions make a thumbnail request conditional, with the ETag and Last-Modified of a copy the caller
+// already has.
+type ThumbnailConditions struct {
+	ETag         string
+	LastModified string
+}
+
+func (charts *Charts) StreamPublishedVersionThumbnail(context context.Context, chartKey string) (*Thumbnail, error) {
+	return charts.StreamThumbnail(context, chartKey, PublishedVersion, ThumbnailConditions{})
+}
+
+func (charts *Charts) StreamDraftVersionThumbnail(context context.Context, chartKey string) (*Thumbnail, error) {
+	return charts.StreamThumbnail(context, chartKey, DraftVersion, ThumbnailConditions{})
+}
+
+func (charts *Charts) WritePublishedVersionThumbnail(context context.Context, chartKey string, writer io.Writer) (*ThumbnailInfo, error) {
+	return charts.writeThumbnail(context, chartKey, PublishedVersion, writer)
+}
+
+func (charts *Charts) WriteDraftVersionThumbnail(context context.Context, chartKey string, writer io.Writer) (*ThumbnailInfo, error) {
+	return charts.writeThumbnail(context, chartKey, DraftVersion, writer)
+}
+
+func (charts *Charts) writeThumbnail(context context.Context, chartKey string, version ThumbnailVersion, writer io.Writer) (*ThumbnailInfo, error) {
+	thumbnail, err := charts.StreamThumbnail(context, chartKey, version, ThumbnailConditions{})
+	if err != nil {
+		return nil, err
+	}
+	defer thumbnail.Close()
+	if _, err := io.Copy(writer, thumbnail); err != nil {
+		return nil, err
+	}
+	return &thumbnail.ThumbnailInfo, nil
+}
+
+// StreamThumbnail requests a thumbnail without reading it into memory. With conditions, the API answers 304 Not
+// Modified when the thumbnail did not change, and the result has NotModified set.
+func (charts *Charts) StreamThumbnail(context context.Context, chartKey string, version ThumbnailVersion, conditions ThumbnailConditions) (*Thumbnail, error) {
+	request := charts.Client.R().
+		SetContext(context).
+		DisableAutoReadResponse().
+		SetPathParam("key", chartKey).
+		SetPathParam("imageType", string(version))
+	if conditions.ETag != "" {
+		request.SetHeader("If-None-Match", conditions.ETag)
+	}
+	if conditions.LastModified != "" {
+		request.SetHeader("If-Modified-Since", conditions.LastModified)
+	}
+	result, err := request.Get("/charts/{key}/version/{imageType}/thumbnail")
+	if err != nil {
+		return nil, err
+	}
+	info := ThumbnailInfo{
+		ContentType:  result.GetHeader("Content-Type"),
+		Size:         result.ContentLength,
+		ETag:         result.GetHeader("ETag"),
+		LastModified: result.GetHeader("Last-Modified"),
+	}
+	if result.StatusCode == http.StatusNotModified {
+		_ = result.Body.Close()
+		return &Thumbnail{ThumbnailInfo: info, ReadCloser: http.NoBody, NotModified: true}, nil
+	}
+	if !result.IsSuccessState() {
+		_, _ = result.ToBytes()
+		return nil, shared.AssertOkWithoutResult(result, nil)
+	}
+	return &Thumbnail{ThumbnailInfo: info, ReadCloser: result.Body}, nil
+}
+
+// ThumbnailCache keeps thumbnails on disk, by chart key and version. A cached thumbnail is revalidated with a
+// conditional request, so it is only downloaded again when it changed.
+type ThumbnailCache struct {
+	Charts *Charts
+	Dir    string
+	// MaxAge is how long a cached thumbnail is used without revalidating it. Defaults to 0: always revalidate.
+	MaxAge time.Duration
+}
+
+type cachedThumbnail struct {
+	ThumbnailInfo
+	ValidatedAt time.Time `json:"validatedAt"`
+}
+
+func (cache *ThumbnailCache) paths(chartKey string, version ThumbnailVersion) (string, string) {
+	base := filepath.Join(cache.Dir, url.PathEscape(chartKey), string(version))
+	return base + ".img", base + ".json"
+}
+
+// Open returns the thumbnail, from the cache when it did not change.
+func (cache *ThumbnailCache) Open(context context.Context, chartKey string, version ThumbnailVersion) (*Thumbnail, error) {
+	imagePath, metadataPath := cache.paths(chartKey, version)
+	cached, cachedErr := readCachedThumbnail(metadataPath)
+	if cachedErr == nil {
+		_, cachedErr = os.Stat(imagePath)
+	}
+	if cachedErr == nil && cache.MaxAge > 0 && time.Since(cached.ValidatedAt) < cache.MaxAge {
+		if thumbnail, err := openCachedThumbnail(imagePath, cached.ThumbnailInfo); err == nil {
+			return thumbnail, nil
+		}
+	}
+
+	var conditions ThumbnailConditions
+	if cachedErr == nil {
+		conditions = ThumbnailConditions{ETag: cached.ETag, LastModified: cached.LastModified}
+	}
+	thumbnail, err := cache.Charts.StreamThumbnail(context, chartKey, version, conditions)
+	if err != nil {
+		return nil, err
+	}
+	if thumbnail.NotModified {
+		cached.ValidatedAt = time.Now()
+		if err := writeCachedThumbnail(metadataPath, cached); err != nil {
+			return nil, err
+		}
+		return openCachedThumbnail(imagePath, cached.ThumbnailInfo)
+	}
+	defer thumbnail.Close()
+	if err := cache.store(imagePath, metadataPath, thumbnail); err != nil {
+		return nil, err
+	}
+	return openCachedThumbnail(imagePath, thumbnail.ThumbnailInfo)
+}
+
+// Remove drops a thumbnail from the cache.
+func (cache *ThumbnailCache) Remove(chartKey string, version ThumbnailVersion) error {
+	imagePath, metadataPath := cache.paths(chartKey, version)
+	return errors.Join(removeIfExists(metadataPath), removeIfExists(imagePath))
+}
+
+func (cache *ThumbnailCache) store(imagePath string, metadataPath string, thumbnail *Thumbnail) error {
+	if err := os.MkdirAll(filepath.Dir(imagePath), 0755); err != nil {
+		return err
+	}
+	tempFile, err := os.CreateTemp(filepath.Dir(imagePath), filepath.Base(imagePath)+".")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tempFile.Name())
+	size, err := io.Copy(tempFile, thumbnail)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	// The image is replaced before the metadata, so that a crash in between leaves metadata that fails revalidation
+	// rather than metadata that matches the wrong image.
+	if err := removeIfExists(metadataPath); err != nil {
+		return err
+	}
+	if err := os.Rename(tempFile.Name(), imagePath); err != nil {
+		return err
+	}
+	thumbnail.Size = size
+	return writeCachedThumbnail(metadataPath, cachedThumbnail{ThumbnailInfo: thumbnail.ThumbnailInfo, ValidatedAt: time.Now()})
+}
+
+func readCachedThumbnail(metadataPath string) (cachedThumbnail, error) {
+	var cached cachedThumbnail
+	data, err := os.ReadFile(metadataPath)
+	if err != nil {
+		return cached, err
+	}
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return cached, err
+	}
+	return cached, nil
+}
+
+func writeCachedThumbnail(metadataPath string, cached cachedThumbnail) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	tempFile := metadataPath + ".tmp"
+	if err := os.WriteFile(tempFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tempFile, metadataPath)
+}
+
+func openCachedThumbnail(imagePath string, info ThumbnailInfo) (*Thumbnail, error) {
+	file, err := os.Open(imagePath)
+	if err != nil {
+		return nil, err
+	}
+	return &Thumbnail{ThumbnailInfo: info, ReadCloser: file}, nil
+}
+
+func removeIfExists(path string) error {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/seatsio/seatsio-go/v12/events"
 	"github.com/seatsio/seatsio-go/v12/test_util"
 	"github.com/stretchr/testify/require"
+	"io"
 	"os"
 	"testing"
 )
@@ -39,3 +40,20 @@ func TestRetrieveDraftVersionThumbnail(t *testing.T) {
 	require.Contains(t, file.Name(), chart.Key)
 	_ = os.Remove(file.Name())
 }
+
+func TestStreamPublishedVersionThumbnail(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+
+	chart, err := client.Charts.Create(test_util.RequestContext(), &charts.CreateChartParams{VenueType: "SIMPLE"})
+	require.NoError(t, err)
+
+	thumbnail, err := client.Charts.StreamPublishedVersionThumbnail(test_util.RequestContext(), chart.Key)
+	require.NoError(t, err)
+	defer thumbnail.Close()
+	data, err := io.ReadAll(thumbnail)
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+	require.NotEmpty(t, thumbnail.ContentType)
+}
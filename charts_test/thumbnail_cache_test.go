@@ -0,0 +1,98 @@
+package charts
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func thumbnailServer(t *testing.T, image *atomic.Value) (*seatsio.SeatsioClient, *atomic.Int32) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		if request.URL.Path != "/charts/chart1/version/published/thumbnail" {
+			writer.Header().Set("Content-Type", "application/json")
+			writer.WriteHeader(http.StatusNotFound)
+			_, _ = writer.Write([]byte(`{"errors": [{"code": "CHART_NOT_FOUND", "message": "chart not found"}]}`))
+			return
+		}
+		data := image.Load().([]byte)
+		etag := `"` + string(data) + `"`
+		if request.Header.Get("If-None-Match") == etag {
+			writer.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		writer.Header().Set("Content-Type", "image/png")
+		writer.Header().Set("ETag", etag)
+		_, _ = writer.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return seatsio.NewSeatsioClient(server.URL, "secretKey"), &downloads
+}
+
+func readThumbnail(t *testing.T, thumbnail *charts.Thumbnail, err error) string {
+	require.NoError(t, err)
+	defer thumbnail.Close()
+	data, err := io.ReadAll(thumbnail)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestStreamThumbnailReturnsContentTypeAndSize(t *testing.T) {
+	t.Parallel()
+	image := &atomic.Value{}
+	image.Store([]byte("png1"))
+	client, _ := thumbnailServer(t, image)
+
+	var buffer bytes.Buffer
+	info, err := client.Charts.WritePublishedVersionThumbnail(test_util.RequestContext(), "chart1", &buffer)
+
+	require.NoError(t, err)
+	require.Equal(t, "png1", buffer.String())
+	require.Equal(t, "image/png", info.ContentType)
+	require.Equal(t, int64(4), info.Size)
+}
+
+func TestStreamThumbnailOfUnknownChart(t *testing.T) {
+	t.Parallel()
+	image := &atomic.Value{}
+	image.Store([]byte("png1"))
+	client, _ := thumbnailServer(t, image)
+
+	_, err := client.Charts.StreamDraftVersionThumbnail(test_util.RequestContext(), "unknown")
+
+	require.EqualError(t, err, "chart not found")
+}
+
+func TestThumbnailCacheOnlyDownloadsChangedThumbnails(t *testing.T) {
+	t.Parallel()
+	image := &atomic.Value{}
+	image.Store([]byte("png1"))
+	client, downloads := thumbnailServer(t, image)
+	cache := &charts.ThumbnailCache{Charts: client.Charts, Dir: t.TempDir()}
+
+	thumbnail, err := cache.Open(test_util.RequestContext(), "chart1", charts.PublishedVersion)
+	require.Equal(t, "png1", readThumbnail(t, thumbnail, err))
+	thumbnail, err = cache.Open(test_util.RequestContext(), "chart1", charts.PublishedVersion)
+	require.Equal(t, "image/png", thumbnail.ContentType)
+	require.Equal(t, "png1", readThumbnail(t, thumbnail, err))
+	require.Equal(t, int32(1), downloads.Load())
+
+	image.Store([]byte("png2"))
+	thumbnail, err = cache.Open(test_util.RequestContext(), "chart1", charts.PublishedVersion)
+	require.Equal(t, "png2", readThumbnail(t, thumbnail, err))
+	require.Equal(t, int32(2), downloads.Load())
+
+	require.NoError(t, cache.Remove("chart1", charts.PublishedVersion))
+	thumbnail, err = cache.Open(test_util.RequestContext(), "chart1", charts.PublishedVersion)
+	require.Equal(t, "png2", readThumbnail(t, thumbnail, err))
+	require.Equal(t, int32(3), downloads.Load())
+}
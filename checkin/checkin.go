@@ -0,0 +1,162 @@
+package checkin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/shared"
+	"github.com/seatsio/seatsio-go/v12/tickets"
+)
+
+// DefaultCheckedInStatus is the custom status that checked-in objects get, unless Scanner.CheckedInStatus is set.
+const DefaultCheckedInStatus = "checked-in"
+
+type Outcome string
+
+const (
+	// CheckedIn means the ticket is valid, and its object is now checked in.
+	CheckedIn Outcome = "checkedIn"
+	// AlreadyCheckedIn means the object was checked in before: the ticket is scanned twice, or copied.
+	AlreadyCheckedIn Outcome = "alreadyCheckedIn"
+	// WrongEvent means the ticket is for another event.
+	WrongEvent Outcome = "wrongEvent"
+	// InvalidTicket means the payload is malformed or its signature is wrong.
+	InvalidTicket Outcome = "invalidTicket"
+	// UnknownObject means the event has no object with the label of the ticket.
+	UnknownObject Outcome = "unknownObject"
+	// NotBooked means the object is not booked, for instance because the booking was cancelled.
+	NotBooked Outcome = "notBooked"
+	// OrderMismatch means the object is booked, but for another order, for instance after a resale.
+	OrderMismatch Outcome = "orderMismatch"
+)
+
+type Result struct {
+	Outcome Outcome
+	Payload tickets.Payload
+	// Object is the state of the object when the ticket was scanned.
+	Object    events.EventObjectInfo
+	ScannedAt time.Time
+}
+
+func (result Result) Admitted() bool {
+	return result.Outcome == CheckedIn
+}
+
+// Scanner checks tickets in at the entrance of an event. A ticket is admitted when its object is booked with the
+// order id of the ticket; the object then moves to the checked-in status, so that a second scan is detected.
+type Scanner struct {
+	Client   *seatsio.SeatsioClient
+	EventKey string
+	// Secret verifies the signature of the ticket payloads.
+	Secret []byte
+	// CheckedInStatus defaults to DefaultCheckedInStatus.
+	CheckedInStatus string
+	// Parse replaces tickets.ParsePayload, for tickets with another payload.
+	Parse func(scanned string) (tickets.Payload, error)
+}
+
+func (scanner *Scanner) checkedInStatus() string {
+	if scanner.CheckedInStatus == "" {
+		return DefaultCheckedInStatus
+	}
+	return scanner.CheckedInStatus
+}
+
+func (scanner *Scanner) parse(scanned string) (tickets.Payload, error) {
+	if scanner.Parse != nil {
+		return scanner.Parse(scanned)
+	}
+	return tickets.ParsePayload(scanner.Secret, scanned)
+}
+
+// check returns the outcome for a ticket without changing anything, or "" if the ticket can be checked in.
+func (scanner *Scanner) check(payload tickets.Payload, object events.EventObjectInfo, found bool) Outcome {
+	switch {
+	case payload.EventKey != scanner.EventKey:
+		return WrongEvent
+	case !found:
+		return UnknownObject
+	case object.Status == scanner.checkedInStatus():
+		return AlreadyCheckedIn
+	case object.Status != events.BOOKED:
+		return NotBooked
+	case object.OrderId != payload.OrderId:
+		return OrderMismatch
+	}
+	return ""
+}
+
+// Scan checks a scanned ticket in. Tickets that are not admitted are reported in the Result; an error means the
+// ticket could not be checked, for instance because the API could not be reached.
+func (scanner *Scanner) Scan(context context.Context, scanned string) (Result, error) {
+	result := Result{ScannedAt: time.Now()}
+	payload, err := scanner.parse(scanned)
+	if err != nil {
+		result.Outcome = InvalidTicket
+		return result, nil
+	}
+	result.Payload = payload
+	if payload.EventKey != scanner.EventKey {
+		result.Outcome = WrongEvent
+		return result, nil
+	}
+	for attempt := 0; ; attempt++ {
+		object, found, err := scanner.retrieveObject(context, payload.ObjectLabel)
+		if err != nil {
+			return result, err
+		}
+		result.Object = object
+		if outcome := scanner.check(payload, object, found); outcome != "" {
+			result.Outcome = outcome
+			return result, nil
+		}
+		err = scanner.changeStatus(context, payload)
+		if err == nil {
+			result.Outcome = CheckedIn
+			return result, nil
+		}
+		// Another entrance changed the object between retrieving and checking in: look at it again.
+		if !isIllegalStatusChange(err) || attempt == 1 {
+			return result, err
+		}
+	}
+}
+
+func (scanner *Scanner) retrieveObject(context context.Context, objectLabel string) (events.EventObjectInfo, bool, error) {
+	objects, err := scanner.Client.Events.RetrieveObjectInfo(context, scanner.EventKey, objectLabel)
+	if shared.IsNotFound(err) {
+		return events.EventObjectInfo{}, false, nil
+	}
+	if err != nil {
+		return events.EventObjectInfo{}, false, err
+	}
+	object, found := objects[objectLabel]
+	return object, found, nil
+}
+
+func (scanner *Scanner) changeStatus(context context.Context, payload tickets.Payload) error {
+	_, err := scanner.Client.Events.ChangeObjectStatusWithOptions(context, &events.StatusChangeParams{
+		Events: []string{scanner.EventKey},
+		StatusChanges: events.StatusChanges{
+			Status:                  scanner.checkedInStatus(),
+			Objects:                 []events.ObjectProperties{{ObjectId: payload.ObjectLabel}},
+			OrderId:                 payload.OrderId,
+			KeepExtraData:           true,
+			IgnoreChannels:          true,
+			AllowedPreviousStatuses: []string{events.BOOKED},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("checking in %s: %w", payload.ObjectLabel, err)
+	}
+	return nil
+}
+
+func isIllegalStatusChange(err error) bool {
+	var seatsioError *shared.SeatsioError
+	return errors.As(err, &seatsioError) && seatsioError.Code == "ILLEGAL_STATUS_CHANGE"
+}
@@ -0,0 +1,131 @@
+package checkin
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// OfflineScanner checks tickets in without a connection, against a report of the event by label that was downloaded
+// beforehand. Duplicate scans are detected locally. The check-ins are sent to the API by Sync, once there is a
+// connection again.
+type OfflineScanner struct {
+	scanner *Scanner
+	mutex   sync.Mutex
+	objects map[string]events.EventObjectInfo
+	pending []Result
+}
+
+// Conflict is an offline check-in that the API rejected, because the object changed in the meantime, for instance
+// because it was checked in at another entrance, or its booking was cancelled.
+type Conflict struct {
+	Result Result
+	// Outcome is what the scan would have been with the current state of the object.
+	Outcome Outcome
+	Current events.EventObjectInfo
+}
+
+type SyncReport struct {
+	Synced    []Result
+	Conflicts []Conflict
+}
+
+// Offline downloads the event report by label, and returns an OfflineScanner that uses it.
+func (scanner *Scanner) Offline(context context.Context) (*OfflineScanner, error) {
+	report, err := scanner.Client.EventReports.ByLabel(context, scanner.EventKey)
+	if err != nil {
+		return nil, err
+	}
+	return scanner.OfflineFromReport(report.Items), nil
+}
+
+// OfflineFromReport returns an OfflineScanner that uses an event report by label that was downloaded before.
+func (scanner *Scanner) OfflineFromReport(report map[string][]events.EventObjectInfo) *OfflineScanner {
+	objects := make(map[string]events.EventObjectInfo, len(report))
+	for label, infos := range report {
+		if len(infos) > 0 {
+			objects[label] = infos[0]
+		}
+	}
+	return &OfflineScanner{scanner: scanner, objects: objects}
+}
+
+// Scan checks a ticket in locally.
+func (offline *OfflineScanner) Scan(scanned string) Result {
+	result := Result{ScannedAt: time.Now()}
+	payload, err := offline.scanner.parse(scanned)
+	if err != nil {
+		result.Outcome = InvalidTicket
+		return result
+	}
+	result.Payload = payload
+
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	object, found := offline.objects[payload.ObjectLabel]
+	result.Object = object
+	if outcome := offline.scanner.check(payload, object, found); outcome != "" {
+		result.Outcome = outcome
+		return result
+	}
+	result.Outcome = CheckedIn
+	object.Status = offline.scanner.checkedInStatus()
+	offline.objects[payload.ObjectLabel] = object
+	offline.pending = append(offline.pending, result)
+	return result
+}
+
+// Pending returns the check-ins that were not synced yet.
+func (offline *OfflineScanner) Pending() []Result {
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	return append([]Result(nil), offline.pending...)
+}
+
+// Sync sends the pending check-ins to the API, in the order they were scanned. Check-ins that the API rejects
+// because the object changed are reported as conflicts. When Sync fails, the check-ins that were not sent stay
+// pending.
+func (offline *OfflineScanner) Sync(context context.Context) (*SyncReport, error) {
+	offline.mutex.Lock()
+	pending := offline.pending
+	offline.pending = nil
+	offline.mutex.Unlock()
+
+	report := &SyncReport{}
+	for i, result := range pending {
+		err := offline.scanner.changeStatus(context, result.Payload)
+		if err == nil {
+			report.Synced = append(report.Synced, result)
+			continue
+		}
+		if isIllegalStatusChange(err) {
+			current, found, retrieveErr := offline.scanner.retrieveObject(context, result.Payload.ObjectLabel)
+			if retrieveErr == nil {
+				outcome := offline.scanner.check(result.Payload, current, found)
+				report.Conflicts = append(report.Conflicts, Conflict{Result: result, Outcome: outcome, Current: current})
+				offline.updateObject(result.Payload.ObjectLabel, current, found)
+				continue
+			}
+			err = retrieveErr
+		}
+		offline.requeue(pending[i:])
+		return report, err
+	}
+	return report, nil
+}
+
+func (offline *OfflineScanner) updateObject(objectLabel string, current events.EventObjectInfo, found bool) {
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	if found {
+		offline.objects[objectLabel] = current
+	}
+}
+
+func (offline *OfflineScanner) requeue(results []Result) {
+	offline.mutex.Lock()
+	defer offline.mutex.Unlock()
+	offline.pending = append(append([]Result(nil), results...), offline.pending...)
+}
@@ -0,0 +1,121 @@
+package checkin_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/checkin"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/seatsio/seatsio-go/v12/tickets"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("secret")
+
+type testEvent struct {
+	client   *seatsio.SeatsioClient
+	eventKey string
+}
+
+func (event *testEvent) setStatus(t *testing.T, label string, status string) {
+	_, err := event.client.Events.ChangeObjectStatus(test_util.RequestContext(), []string{event.eventKey}, []string{label}, status)
+	require.NoError(t, err)
+}
+
+func (event *testEvent) status(t *testing.T, label string) string {
+	objects, err := event.client.Events.RetrieveObjectInfo(test_util.RequestContext(), event.eventKey, label)
+	require.NoError(t, err)
+	return objects[label].Status
+}
+
+// newScanner creates an event on which A-1 and A-2 are booked with order1, and a scanner for it.
+func newScanner(t *testing.T) (*checkin.Scanner, *testEvent) {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey, EventParams: &events.EventParams{EventKey: "event1"}})
+	require.NoError(t, err)
+	_, err = client.Events.BookWithOptions(test_util.RequestContext(), &events.StatusChangeParams{
+		Events: []string{event.Key},
+		StatusChanges: events.StatusChanges{
+			Objects: []events.ObjectProperties{{ObjectId: "A-1"}, {ObjectId: "A-2"}},
+			OrderId: "order1",
+		},
+	})
+	require.NoError(t, err)
+	return &checkin.Scanner{Client: client, EventKey: event.Key, Secret: secret}, &testEvent{client: client, eventKey: event.Key}
+}
+
+func ticket(eventKey string, objectLabel string, orderId string) string {
+	return tickets.Payload{EventKey: eventKey, ObjectLabel: objectLabel, OrderId: orderId}.Sign(secret)
+}
+
+func scan(t *testing.T, scanner *checkin.Scanner, scanned string) checkin.Outcome {
+	result, err := scanner.Scan(test_util.RequestContext(), scanned)
+	require.NoError(t, err)
+	return result.Outcome
+}
+
+func TestScanChecksInAndDetectsDuplicates(t *testing.T) {
+	t.Parallel()
+	scanner, event := newScanner(t)
+
+	result, err := scanner.Scan(test_util.RequestContext(), ticket("event1", "A-1", "order1"))
+	require.NoError(t, err)
+	require.True(t, result.Admitted())
+	require.Equal(t, "A-1", result.Payload.ObjectLabel)
+	require.Equal(t, checkin.DefaultCheckedInStatus, event.status(t, "A-1"))
+
+	require.Equal(t, checkin.AlreadyCheckedIn, scan(t, scanner, ticket("event1", "A-1", "order1")))
+}
+
+func TestScanRejectsInvalidTickets(t *testing.T) {
+	t.Parallel()
+	scanner, event := newScanner(t)
+
+	require.Equal(t, checkin.InvalidTicket, scan(t, scanner, "not a ticket"))
+	require.Equal(t, checkin.InvalidTicket, scan(t, scanner, tickets.Payload{EventKey: "event1", ObjectLabel: "A-1", OrderId: "order1"}.Sign([]byte("forged"))))
+	require.Equal(t, checkin.WrongEvent, scan(t, scanner, ticket("event2", "A-1", "order1")))
+	require.Equal(t, checkin.UnknownObject, scan(t, scanner, ticket("event1", "Z-9", "order1")))
+	require.Equal(t, checkin.NotBooked, scan(t, scanner, ticket("event1", "A-3", "order1")))
+	require.Equal(t, checkin.OrderMismatch, scan(t, scanner, ticket("event1", "A-2", "order2")))
+	require.Equal(t, events.BOOKED, event.status(t, "A-1"))
+	require.Equal(t, events.BOOKED, event.status(t, "A-2"))
+}
+
+func TestScanWithCustomStatus(t *testing.T) {
+	t.Parallel()
+	scanner, event := newScanner(t)
+	scanner.CheckedInStatus = "at-the-venue"
+
+	require.Equal(t, checkin.CheckedIn, scan(t, scanner, ticket("event1", "A-1", "order1")))
+	require.Equal(t, "at-the-venue", event.status(t, "A-1"))
+}
+
+func TestOfflineScanAndSync(t *testing.T) {
+	t.Parallel()
+	scanner, event := newScanner(t)
+	offline, err := scanner.Offline(test_util.RequestContext())
+	require.NoError(t, err)
+
+	require.Equal(t, checkin.CheckedIn, offline.Scan(ticket("event1", "A-1", "order1")).Outcome)
+	require.Equal(t, checkin.AlreadyCheckedIn, offline.Scan(ticket("event1", "A-1", "order1")).Outcome)
+	require.Equal(t, checkin.CheckedIn, offline.Scan(ticket("event1", "A-2", "order1")).Outcome)
+	require.Equal(t, checkin.NotBooked, offline.Scan(ticket("event1", "A-3", "order1")).Outcome)
+	require.Len(t, offline.Pending(), 2)
+	require.Equal(t, events.BOOKED, event.status(t, "A-1"))
+
+	// A-2 is checked in at another entrance before the sync
+	event.setStatus(t, "A-2", checkin.DefaultCheckedInStatus)
+	report, err := offline.Sync(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Len(t, report.Synced, 1)
+	require.Equal(t, "A-1", report.Synced[0].Payload.ObjectLabel)
+	require.Len(t, report.Conflicts, 1)
+	require.Equal(t, "A-2", report.Conflicts[0].Result.Payload.ObjectLabel)
+	require.Equal(t, checkin.AlreadyCheckedIn, report.Conflicts[0].Outcome)
+	require.Equal(t, checkin.DefaultCheckedInStatus, event.status(t, "A-1"))
+	require.Empty(t, offline.Pending())
+}
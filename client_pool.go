@@ -0,0 +1,305 @@
+package seatsio
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+// ClientPool hands out workspace-scoped clients that share one connection pool and one rate limiter, keyed by
+// workspace. Clients are created on first use and evicted after they have been idle for a while; an evicted client
+// keeps working, but the next call to Client creates a new one with fresh metrics.
+type ClientPool struct {
+	baseUrl     string
+	secretKey   string
+	transport   http.RoundTripper
+	limiter     *keyedRateLimiter
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mutex   sync.Mutex
+	clients map[string]*pooledClient
+	stop    chan struct{}
+}
+
+type pooledClient struct {
+	client   *SeatsioClient
+	metrics  *workspaceMetrics
+	lastUsed atomic.Int64
+}
+
+type ClientPoolOption func(pool *ClientPool)
+
+type clientPoolNS struct{}
+
+var ClientPoolSupport clientPoolNS
+
+// IdleTimeout sets how long a client can go unused before it is evicted. Defaults to 10 minutes; 0 disables
+// eviction.
+func (clientPoolNS) IdleTimeout(idleTimeout time.Duration) ClientPoolOption {
+	return func(pool *ClientPool) {
+		pool.idleTimeout = idleTimeout
+	}
+}
+
+// RateLimit limits the requests of every workspace to requestsPerSecond, with bursts of up to burst requests.
+func (clientPoolNS) RateLimit(requestsPerSecond float64, burst int) ClientPoolOption {
+	return func(pool *ClientPool) {
+		pool.limiter = newKeyedRateLimiter(requestsPerSecond, burst, pool.now)
+	}
+}
+
+// Transport replaces the shared transport, for instance to tune its connection limits.
+func (clientPoolNS) Transport(transport http.RoundTripper) ClientPoolOption {
+	return func(pool *ClientPool) {
+		pool.transport = transport
+	}
+}
+
+// NewClientPool creates a pool for a company admin key.
+func NewClientPool(baseUrl string, companyAdminKey string, opts ...ClientPoolOption) *ClientPool {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = 100
+	pool := &ClientPool{
+		baseUrl:     baseUrl,
+		secretKey:   companyAdminKey,
+		transport:   transport,
+		idleTimeout: 10 * time.Minute,
+		now:         time.Now,
+		clients:     map[string]*pooledClient{},
+		stop:        make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(pool)
+	}
+	if pool.idleTimeout > 0 {
+		go pool.evictPeriodically()
+	}
+	return pool
+}
+
+// Client returns the client for a workspace, creating it if needed.
+func (pool *ClientPool) Client(workspaceKey string) *SeatsioClient {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	pooled, ok := pool.clients[workspaceKey]
+	if !ok {
+		pooled = pool.newClient(workspaceKey)
+		pool.clients[workspaceKey] = pooled
+	}
+	pooled.lastUsed.Store(pool.now().UnixNano())
+	return pooled.client
+}
+
+func (pool *ClientPool) newClient(workspaceKey string) *pooledClient {
+	pooled := &pooledClient{metrics: &workspaceMetrics{}}
+	transport := &workspaceTransport{pool: pool, pooled: pooled, workspaceKey: workspaceKey}
+	apiClient := shared.ApiClientWithRoundTripper(pool.secretKey, pool.baseUrl, transport, ClientSupport.WorkspaceKey(workspaceKey))
+	pooled.client = newSeatsioClient(pool.baseUrl, pool.secretKey, apiClient)
+	pooled.client.workspaceKey = workspaceKey
+	return pooled
+}
+
+// Len returns the number of clients in the pool.
+func (pool *ClientPool) Len() int {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	return len(pool.clients)
+}
+
+// EvictIdle removes the clients that have been idle for longer than the idle timeout, and returns how many were
+// removed.
+func (pool *ClientPool) EvictIdle() int {
+	if pool.idleTimeout <= 0 {
+		return 0
+	}
+	cutoff := pool.now().Add(-pool.idleTimeout).UnixNano()
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	evicted := 0
+	for workspaceKey, pooled := range pool.clients {
+		if pooled.lastUsed.Load() < cutoff {
+			delete(pool.clients, workspaceKey)
+			if pool.limiter != nil {
+				pool.limiter.remove(workspaceKey)
+			}
+			evicted++
+		}
+	}
+	return evicted
+}
+
+func (pool *ClientPool) evictPeriodically() {
+	ticker := time.NewTicker(pool.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			pool.EvictIdle()
+		case <-pool.stop:
+			return
+		}
+	}
+}
+
+// Close stops evicting clients and closes the idle connections of the shared transport.
+func (pool *ClientPool) Close() {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	select {
+	case <-pool.stop:
+	default:
+		close(pool.stop)
+	}
+	if transport, ok := pool.transport.(interface{ CloseIdleConnections() }); ok {
+		transport.CloseIdleConnections()
+	}
+}
+
+// WorkspaceMetrics counts the requests a pooled client sent. Retried requests count once per attempt.
+type WorkspaceMetrics struct {
+	WorkspaceKey string
+	Requests     int64
+	// Errors counts the requests that failed without a response, or with a 5xx response.
+	Errors int64
+	// RateLimited counts the 429 responses.
+	RateLimited int64
+	// Throttled counts the requests that were delayed by the pool's rate limiter.
+	Throttled    int64
+	TotalLatency time.Duration
+	LastUsed     time.Time
+}
+
+func (metrics WorkspaceMetrics) AverageLatency() time.Duration {
+	if metrics.Requests == 0 {
+		return 0
+	}
+	return metrics.TotalLatency / time.Duration(metrics.Requests)
+}
+
+type workspaceMetrics struct {
+	requests     atomic.Int64
+	errors       atomic.Int64
+	rateLimited  atomic.Int64
+	throttled    atomic.Int64
+	totalLatency atomic.Int64
+}
+
+// Metrics returns the metrics of the clients in the pool, sorted by workspace key.
+func (pool *ClientPool) Metrics() []WorkspaceMetrics {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	result := make([]WorkspaceMetrics, 0, len(pool.clients))
+	for workspaceKey, pooled := range pool.clients {
+		result = append(result, WorkspaceMetrics{
+			WorkspaceKey: workspaceKey,
+			Requests:     pooled.metrics.requests.Load(),
+			Errors:       pooled.metrics.errors.Load(),
+			RateLimited:  pooled.metrics.rateLimited.Load(),
+			Throttled:    pooled.metrics.throttled.Load(),
+			TotalLatency: time.Duration(pooled.metrics.totalLatency.Load()),
+			LastUsed:     time.Unix(0, pooled.lastUsed.Load()),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].WorkspaceKey < result[j].WorkspaceKey
+	})
+	return result
+}
+
+// workspaceTransport rate limits and measures the requests of one workspace, and sends them through the shared
+// transport.
+type workspaceTransport struct {
+	pool         *ClientPool
+	pooled       *pooledClient
+	workspaceKey string
+}
+
+func (transport *workspaceTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	pool := transport.pool
+	metrics := transport.pooled.metrics
+	if pool.limiter != nil {
+		waited, err := pool.limiter.wait(request.Context(), transport.workspaceKey)
+		if err != nil {
+			return nil, err
+		}
+		if waited {
+			metrics.throttled.Add(1)
+		}
+	}
+	start := pool.now()
+	transport.pooled.lastUsed.Store(start.UnixNano())
+	response, err := pool.transport.RoundTrip(request)
+	metrics.requests.Add(1)
+	metrics.totalLatency.Add(int64(pool.now().Sub(start)))
+	switch {
+	case err != nil || response.StatusCode >= 500:
+		metrics.errors.Add(1)
+	case response.StatusCode == http.StatusTooManyRequests:
+		metrics.rateLimited.Add(1)
+	}
+	return response, err
+}
+
+// keyedRateLimiter is a token bucket per key.
+type keyedRateLimiter struct {
+	rate    float64
+	burst   float64
+	now     func() time.Time
+	mutex   sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func newKeyedRateLimiter(requestsPerSecond float64, burst int, now func() time.Time) *keyedRateLimiter {
+	return &keyedRateLimiter{rate: requestsPerSecond, burst: float64(max(burst, 1)), now: now, buckets: map[string]*tokenBucket{}}
+}
+
+// reserve takes a token for key, and returns how long the caller has to wait before using it.
+func (limiter *keyedRateLimiter) reserve(key string) time.Duration {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	now := limiter.now()
+	bucket, ok := limiter.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: limiter.burst, updated: now}
+		limiter.buckets[key] = bucket
+	}
+	bucket.tokens = min(limiter.burst, bucket.tokens+now.Sub(bucket.updated).Seconds()*limiter.rate)
+	bucket.updated = now
+	bucket.tokens--
+	if bucket.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-bucket.tokens / limiter.rate * float64(time.Second))
+}
+
+func (limiter *keyedRateLimiter) wait(context context.Context, key string) (bool, error) {
+	delay := limiter.reserve(key)
+	if delay == 0 {
+		return false, nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-context.Done():
+		return true, context.Err()
+	}
+}
+
+func (limiter *keyedRateLimiter) remove(key string) {
+	limiter.mutex.Lock()
+	defer limiter.mutex.Unlock()
+	delete(limiter.buckets, key)
+}
@@ -0,0 +1,103 @@
+package seatsio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func workspaceEchoServer(t *testing.T) (*httptest.Server, *sync.Map) {
+	seen := &sync.Map{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		seen.Store(request.Header.Get("X-Workspace-Key"), true)
+		if request.Header.Get("X-Workspace-Key") == "broken" {
+			writer.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"holdToken": "token", "expiresInSeconds": 900}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, seen
+}
+
+func TestClientPoolHandsOutWorkspaceScopedClients(t *testing.T) {
+	t.Parallel()
+	server, seen := workspaceEchoServer(t)
+	pool := NewClientPool(server.URL, "adminKey")
+	defer pool.Close()
+
+	client1 := pool.Client("ws1")
+	client2 := pool.Client("ws2")
+	_, err := client1.HoldTokens.Create(test_util.RequestContext())
+	require.NoError(t, err)
+	_, err = client2.HoldTokens.Create(test_util.RequestContext())
+	require.NoError(t, err)
+
+	require.Same(t, client1, pool.Client("ws1"))
+	require.Equal(t, 2, pool.Len())
+	_, ok := seen.Load("ws1")
+	require.True(t, ok)
+	_, ok = seen.Load("ws2")
+	require.True(t, ok)
+}
+
+func TestClientPoolMetrics(t *testing.T) {
+	t.Parallel()
+	server, _ := workspaceEchoServer(t)
+	pool := NewClientPool(server.URL, "adminKey")
+	defer pool.Close()
+	require.NoError(t, pool.Client("broken").SetMaxRetries(0))
+
+	for i := 0; i < 3; i++ {
+		_, err := pool.Client("ws1").HoldTokens.Create(test_util.RequestContext())
+		require.NoError(t, err)
+	}
+	_, err := pool.Client("broken").HoldTokens.Create(test_util.RequestContext())
+	require.Error(t, err)
+
+	metrics := pool.Metrics()
+	require.Len(t, metrics, 2)
+	require.Equal(t, "broken", metrics[0].WorkspaceKey)
+	require.Equal(t, int64(1), metrics[0].Requests)
+	require.Equal(t, int64(1), metrics[0].Errors)
+	require.Equal(t, "ws1", metrics[1].WorkspaceKey)
+	require.Equal(t, int64(3), metrics[1].Requests)
+	require.Equal(t, int64(0), metrics[1].Errors)
+}
+
+func TestClientPoolEvictsIdleClients(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	pool := NewClientPool("http://localhost", "adminKey", ClientPoolSupport.IdleTimeout(time.Hour))
+	defer pool.Close()
+	pool.now = func() time.Time { return now }
+
+	evicted := pool.Client("ws1")
+	now = now.Add(40 * time.Minute)
+	pool.Client("ws2")
+	now = now.Add(40 * time.Minute)
+
+	require.Equal(t, 1, pool.EvictIdle())
+	require.Equal(t, 1, pool.Len())
+	require.NotSame(t, evicted, pool.Client("ws1"))
+}
+
+func TestKeyedRateLimiter(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := newKeyedRateLimiter(10, 2, func() time.Time { return now })
+
+	require.Equal(t, time.Duration(0), limiter.reserve("ws1"))
+	require.Equal(t, time.Duration(0), limiter.reserve("ws1"))
+	require.Equal(t, 100*time.Millisecond, limiter.reserve("ws1"))
+	require.Equal(t, time.Duration(0), limiter.reserve("ws2"))
+
+	now = now.Add(time.Second)
+	require.Equal(t, time.Duration(0), limiter.reserve("ws1"))
+}
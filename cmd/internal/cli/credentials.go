@@ -0,0 +1,136 @@
+// Package cli resolves credentials and regions for the seatsio commands.
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/shared"
+	"gopkg.in/yaml.v3"
+)
+
+type Profile struct {
+	SecretKey    string `yaml:"secretKey"`
+	WorkspaceKey string `yaml:"workspaceKey,omitempty"`
+	Region       string `yaml:"region,omitempty"`
+}
+
+// Config is the content of the configuration file, by default ~/.config/seatsio/config.yaml:
+//
+//	defaultProfile: staging
+//	profiles:
+//	  staging:
+//	    secretKey: ...
+//	    region: eu
+type Config struct {
+	DefaultProfile string             `yaml:"defaultProfile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles"`
+}
+
+type Credentials struct {
+	SecretKey    string
+	WorkspaceKey string
+	Region       string
+}
+
+func DefaultConfigPath() string {
+	if path := os.Getenv("SEATSIO_CONFIG"); path != "" {
+		return path
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "seatsio", "config.yaml")
+}
+
+// LoadConfig reads the configuration file. A missing file results in an empty configuration.
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{Profiles: map[string]Profile{}}
+	if path == "" {
+		return config, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return config, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Resolve combines the credentials passed as flags with the SEATSIO_SECRET_KEY, SEATSIO_WORKSPACE_KEY and
+// SEATSIO_REGION environment variables and the selected profile, in that order of precedence.
+func Resolve(fromFlags Credentials, profileName string, configPath string) (Credentials, error) {
+	config, err := LoadConfig(configPath)
+	if err != nil {
+		return Credentials{}, err
+	}
+	if profileName == "" {
+		profileName = firstNonEmpty(os.Getenv("SEATSIO_PROFILE"), config.DefaultProfile)
+	}
+	var profile Profile
+	if profileName != "" {
+		var exists bool
+		profile, exists = config.Profiles[profileName]
+		if !exists {
+			return Credentials{}, fmt.Errorf("profile %s not found in %s", profileName, configPath)
+		}
+	}
+	credentials := Credentials{
+		SecretKey:    firstNonEmpty(fromFlags.SecretKey, os.Getenv("SEATSIO_SECRET_KEY"), profile.SecretKey),
+		WorkspaceKey: firstNonEmpty(fromFlags.WorkspaceKey, os.Getenv("SEATSIO_WORKSPACE_KEY"), profile.WorkspaceKey),
+		Region:       firstNonEmpty(fromFlags.Region, os.Getenv("SEATSIO_REGION"), profile.Region, "eu"),
+	}
+	if credentials.SecretKey == "" {
+		return Credentials{}, errors.New("no secret key: pass --secret-key, set SEATSIO_SECRET_KEY or configure a profile")
+	}
+	return credentials, nil
+}
+
+func (credentials Credentials) Client() (*seatsio.SeatsioClient, error) {
+	baseUrl, err := RegionUrl(credentials.Region)
+	if err != nil {
+		return nil, err
+	}
+	var headers []shared.AdditionalHeader
+	if credentials.WorkspaceKey != "" {
+		headers = append(headers, seatsio.ClientSupport.WorkspaceKey(credentials.WorkspaceKey))
+	}
+	return seatsio.NewSeatsioClient(baseUrl, credentials.SecretKey, headers...), nil
+}
+
+// RegionUrl maps eu, na, sa and oc to the API url of that region. Urls are passed through, which is useful for testing.
+func RegionUrl(region string) (string, error) {
+	switch strings.ToLower(region) {
+	case "eu":
+		return seatsio.EU, nil
+	case "na":
+		return seatsio.NA, nil
+	case "sa":
+		return seatsio.SA, nil
+	case "oc":
+		return seatsio.OC, nil
+	}
+	if strings.HasPrefix(region, "http://") || strings.HasPrefix(region, "https://") {
+		return region, nil
+	}
+	return "", fmt.Errorf("unknown region %s, expected eu, na, sa or oc", region)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
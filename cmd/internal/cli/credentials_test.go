@@ -0,0 +1,119 @@
+package cli_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/cmd/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+const config = `defaultProfile: staging
+profiles:
+  staging:
+    secretKey: stagingKey
+    workspaceKey: stagingWorkspace
+    region: na
+  production:
+    secretKey: productionKey
+`
+
+func TestResolve(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(config), 0o600))
+	tests := []struct {
+		name        string
+		flags       cli.Credentials
+		profile     string
+		env         map[string]string
+		configPath  string
+		credentials cli.Credentials
+		err         string
+	}{
+		{
+			name:        "default profile",
+			configPath:  configPath,
+			credentials: cli.Credentials{SecretKey: "stagingKey", WorkspaceKey: "stagingWorkspace", Region: "na"},
+		},
+		{
+			name:        "profile flag",
+			profile:     "production",
+			configPath:  configPath,
+			credentials: cli.Credentials{SecretKey: "productionKey", Region: "eu"},
+		},
+		{
+			name:        "profile from the environment",
+			env:         map[string]string{"SEATSIO_PROFILE": "production"},
+			configPath:  configPath,
+			credentials: cli.Credentials{SecretKey: "productionKey", Region: "eu"},
+		},
+		{
+			name:        "profile flag over the environment",
+			profile:     "staging",
+			env:         map[string]string{"SEATSIO_PROFILE": "production"},
+			configPath:  configPath,
+			credentials: cli.Credentials{SecretKey: "stagingKey", WorkspaceKey: "stagingWorkspace", Region: "na"},
+		},
+		{
+			name:        "environment over the profile",
+			env:         map[string]string{"SEATSIO_SECRET_KEY": "envKey", "SEATSIO_REGION": "oc"},
+			configPath:  configPath,
+			credentials: cli.Credentials{SecretKey: "envKey", WorkspaceKey: "stagingWorkspace", Region: "oc"},
+		},
+		{
+			name:        "flags over the environment and the profile",
+			flags:       cli.Credentials{SecretKey: "flagKey", WorkspaceKey: "flagWorkspace"},
+			env:         map[string]string{"SEATSIO_SECRET_KEY": "envKey", "SEATSIO_WORKSPACE_KEY": "envWorkspace"},
+			configPath:  configPath,
+			credentials: cli.Credentials{SecretKey: "flagKey", WorkspaceKey: "flagWorkspace", Region: "na"},
+		},
+		{
+			name:        "missing configuration file",
+			flags:       cli.Credentials{SecretKey: "flagKey"},
+			configPath:  filepath.Join(t.TempDir(), "missing.yaml"),
+			credentials: cli.Credentials{SecretKey: "flagKey", Region: "eu"},
+		},
+		{
+			name:       "unknown profile",
+			profile:    "unknown",
+			configPath: configPath,
+			err:        "profile unknown not found in " + configPath,
+		},
+		{
+			name: "no secret key",
+			err:  "no secret key: pass --secret-key, set SEATSIO_SECRET_KEY or configure a profile",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, name := range []string{"SEATSIO_SECRET_KEY", "SEATSIO_WORKSPACE_KEY", "SEATSIO_REGION", "SEATSIO_PROFILE"} {
+				t.Setenv(name, test.env[name])
+			}
+
+			credentials, err := cli.Resolve(test.flags, test.profile, test.configPath)
+
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.credentials, credentials)
+		})
+	}
+}
+
+func TestRegionUrl(t *testing.T) {
+	t.Parallel()
+	url, err := cli.RegionUrl("NA")
+	require.NoError(t, err)
+	require.Equal(t, seatsio.NA, url)
+
+	url, err = cli.RegionUrl("http://localhost:9001")
+	require.NoError(t, err)
+	require.Equal(t, "http://localhost:9001", url)
+
+	_, err = cli.RegionUrl("mars")
+	require.EqualError(t, err, "unknown region mars, expected eu, na, sa or oc")
+}
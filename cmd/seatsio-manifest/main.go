@@ -0,0 +1,69 @@
+// Command seatsio-manifest shows and applies the difference between a manifest and the live state of a workspace.
+//
+//	seatsio-manifest plan -f manifest.yaml
+//	seatsio-manifest apply -f manifest.yaml
+//
+// The secret key, workspace key and region are read from the --secret-key, --workspace-key and --region flags,
+// from the SEATSIO_SECRET_KEY, SEATSIO_WORKSPACE_KEY and SEATSIO_REGION environment variables, or from a profile
+// in the seatsio configuration file.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seatsio/seatsio-go/v12/cmd/internal/cli"
+	"github.com/seatsio/seatsio-go/v12/manifest"
+)
+
+func main() {
+	if len(os.Args) < 2 || (os.Args[1] != "plan" && os.Args[1] != "apply") {
+		fmt.Fprintln(os.Stderr, "usage: seatsio-manifest plan|apply -f <manifest> [--profile <name>] [--secret-key <key>] [--workspace-key <key>] [--region eu|na|sa|oc]")
+		os.Exit(2)
+	}
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	file := flags.String("f", "seatsio.yaml", "the manifest file, in YAML or JSON")
+	var fromFlags cli.Credentials
+	flags.StringVar(&fromFlags.SecretKey, "secret-key", "", "the secret key")
+	flags.StringVar(&fromFlags.WorkspaceKey, "workspace-key", "", "the workspace key, when using a company admin key")
+	flags.StringVar(&fromFlags.Region, "region", "", "the region: eu, na, sa or oc")
+	profile := flags.String("profile", "", "the profile in the configuration file")
+	configPath := flags.String("config", cli.DefaultConfigPath(), "the configuration file")
+	_ = flags.Parse(os.Args[2:])
+
+	credentials, err := cli.Resolve(fromFlags, *profile, *configPath)
+	if err != nil {
+		exitWithError(err)
+	}
+	client, err := credentials.Client()
+	if err != nil {
+		exitWithError(err)
+	}
+	desired, err := manifest.Load(*file)
+	if err != nil {
+		exitWithError(err)
+	}
+	engine := manifest.NewEngine(client)
+
+	if command == "plan" {
+		plan, err := engine.Plan(context.Background(), desired)
+		if err != nil {
+			exitWithError(err)
+		}
+		fmt.Print(plan.String())
+		return
+	}
+	applied, err := engine.Apply(context.Background(), desired)
+	fmt.Print(applied.String())
+	if err != nil {
+		exitWithError(err)
+	}
+}
+
+func exitWithError(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+	os.Exit(1)
+}
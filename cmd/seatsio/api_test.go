@@ -0,0 +1,114 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/eventlog"
+	"github.com/seatsio/seatsio-go/v12/holdtokens"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type syncBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (buffer *syncBuffer) Write(p []byte) (int, error) {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	return buffer.buffer.Write(p)
+}
+
+func (buffer *syncBuffer) String() string {
+	buffer.mutex.Lock()
+	defer buffer.mutex.Unlock()
+	return buffer.buffer.String()
+}
+
+func credentialArgs(company *test_util.TestCompany, args ...string) []string {
+	return append([]string{"--secret-key", company.Admin.SecretKey, "--region", test_util.BaseUrl}, args...)
+}
+
+func TestMutationWritesOnlyJSONToOut(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+
+	out, errOut, err := runCommand(t, credentialArgs(company, "hold-tokens", "create", "--format", "json")...)
+	require.NoError(t, err)
+
+	var holdToken holdtokens.HoldToken
+	require.NoError(t, json.Unmarshal([]byte(out), &holdToken))
+	require.NotEmpty(t, holdToken.HoldToken)
+	require.Equal(t, "done: create a hold token\n", errOut)
+}
+
+func TestMutationWritesOnlyCSVToOut(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+
+	out, _, err := runCommand(t, credentialArgs(company, "hold-tokens", "create", "--format", "csv")...)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.Len(t, lines, 2)
+	require.Equal(t, "holdToken,expiresAt,expiresInSeconds", lines[0])
+}
+
+func TestEventLogTailShowsTheMostRecentItems(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	var chartKeys []string
+	for range 3 {
+		chart, err := client.Charts.Create(test_util.RequestContext(), &charts.CreateChartParams{})
+		require.NoError(t, err)
+		chartKeys = append(chartKeys, chart.Key)
+	}
+	time.Sleep(2 * time.Second)
+
+	out, _, err := runCommand(t, credentialArgs(company, "event-log", "tail", "--limit", "2", "--format", "json")...)
+	require.NoError(t, err)
+
+	var items []eventlog.EventLogItem
+	require.NoError(t, json.Unmarshal([]byte(out), &items))
+	require.Len(t, items, 2)
+	require.Equal(t, chartKeys[1], items[0].Data["key"])
+	require.Equal(t, chartKeys[2], items[1].Data["key"])
+}
+
+func TestEventLogTailFollowsNewItems(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	for range 3 {
+		_, err := client.Charts.Create(test_util.RequestContext(), &charts.CreateChartParams{})
+		require.NoError(t, err)
+	}
+	time.Sleep(2 * time.Second)
+	ctx, cancel := context.WithCancel(test_util.RequestContext())
+	defer cancel()
+	out := &syncBuffer{}
+	app := &app{out: out, errOut: &bytes.Buffer{}, context: ctx, format: "csv"}
+	done := make(chan error, 1)
+	go func() {
+		done <- run(app, credentialArgs(company, "event-log", "tail", "--follow", "--limit", "1", "--interval", "200ms"))
+	}()
+	require.Eventually(t, func() bool { return strings.Count(out.String(), "chart.created") == 1 }, 10*time.Second, 50*time.Millisecond)
+
+	chart, err := client.Charts.Create(test_util.RequestContext(), &charts.CreateChartParams{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), chart.Key) }, 10*time.Second, 50*time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+	require.Equal(t, 2, strings.Count(out.String(), "chart.created"))
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/cmd/internal/cli"
+)
+
+type app struct {
+	out io.Writer
+	// errOut receives status messages, so that they do not mix with the JSON or CSV written to out
+	errOut      io.Writer
+	context     context.Context
+	credentials cli.Credentials
+	profile     string
+	configPath  string
+	dryRun      bool
+	format      string
+	client      *seatsio.SeatsioClient
+}
+
+func (app *app) registerGlobalFlags(flags *flag.FlagSet) {
+	flags.StringVar(&app.credentials.SecretKey, "secret-key", app.credentials.SecretKey, "the secret key (or SEATSIO_SECRET_KEY)")
+	flags.StringVar(&app.credentials.WorkspaceKey, "workspace-key", app.credentials.WorkspaceKey, "the workspace key, when using a company admin key (or SEATSIO_WORKSPACE_KEY)")
+	flags.StringVar(&app.credentials.Region, "region", app.credentials.Region, "the region: eu, na, sa or oc (or SEATSIO_REGION)")
+	flags.StringVar(&app.profile, "profile", app.profile, "the profile in the configuration file (or SEATSIO_PROFILE)")
+	flags.StringVar(&app.configPath, "config", app.configPath, "the configuration file (or SEATSIO_CONFIG)")
+	flags.BoolVar(&app.dryRun, "dry-run", app.dryRun, "print what would change instead of changing it")
+	flags.StringVar(&app.format, "format", app.format, "the output format: table, json or csv")
+}
+
+func (app *app) seatsioClient() (*seatsio.SeatsioClient, error) {
+	if app.client != nil {
+		return app.client, nil
+	}
+	credentials, err := app.resolveCredentials()
+	if err != nil {
+		return nil, err
+	}
+	app.client, err = credentials.Client()
+	return app.client, err
+}
+
+func (app *app) resolveCredentials() (cli.Credentials, error) {
+	return cli.Resolve(app.credentials, app.profile, app.configPath)
+}
+
+// mutate runs the change, unless --dry-run is passed, in which case it only prints the description.
+func (app *app) mutate(description string, change func(client *seatsio.SeatsioClient) error) error {
+	if app.dryRun {
+		fmt.Fprintf(app.out, "dry run: would %s\n", description)
+		return nil
+	}
+	client, err := app.seatsioClient()
+	if err != nil {
+		return err
+	}
+	if err := change(client); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.errOut, "done: %s\n", description)
+	return nil
+}
+
+type table struct {
+	headers []string
+	rows    [][]string
+}
+
+func (table *table) add(values ...any) {
+	row := make([]string, len(values))
+	for i, value := range values {
+		row[i] = fmt.Sprint(value)
+	}
+	table.rows = append(table.rows, row)
+}
+
+// print writes value as indented JSON, or the table as CSV or as aligned columns, depending on --format.
+func (app *app) print(value any, table *table) error {
+	switch app.format {
+	case "json":
+		encoder := json.NewEncoder(app.out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(value)
+	case "csv":
+		writer := csv.NewWriter(app.out)
+		if err := writer.Write(table.headers); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(table.rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	case "table", "":
+		writer := tabwriter.NewWriter(app.out, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(table.headers, "\t")))
+		for _, row := range table.rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return writer.Flush()
+	}
+	return fmt.Errorf("unknown format %s, expected table, json or csv", app.format)
+}
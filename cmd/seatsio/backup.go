@@ -0,0 +1,117 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/backup"
+	"github.com/seatsio/seatsio-go/v12/cmd/internal/cli"
+)
+
+func backupCommand() *command {
+	return &command{
+		name:        "backup",
+		description: "Back up a workspace to an archive, and restore it",
+		subcommands: []*command{backupCreateCommand(), backupRestoreCommand()},
+	}
+}
+
+func backupCreateCommand() *command {
+	var previous string
+	return &command{
+		name:        "create",
+		args:        "<archive file>",
+		description: "Write a backup of the workspace to a .tar.gz archive",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&previous, "previous", "", "a previous archive; only charts that changed since are backed up")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "an archive file"); err != nil {
+				return err
+			}
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			workspaceBackup := &backup.Backup{Client: client}
+			if previous != "" {
+				previousArchive, err := readArchive(previous)
+				if err != nil {
+					return err
+				}
+				workspaceBackup.Previous = &previousArchive.Manifest
+			}
+			archive, err := workspaceBackup.Create(app.context)
+			if err != nil {
+				return err
+			}
+			file, err := os.Create(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			if err := archive.Write(file); err != nil {
+				return err
+			}
+			fmt.Fprintf(app.out, "wrote %d files to %s (%d unchanged charts left out)\n", len(archive.Files), args[0], len(archive.Manifest.UnchangedCharts))
+			return file.Close()
+		},
+	}
+}
+
+func backupRestoreCommand() *command {
+	var fromWorkspace string
+	return &command{
+		name:        "restore",
+		args:        "<archive file>",
+		description: "Rebuild a workspace from an archive, with a company admin key; --workspace-key is the workspace to restore into",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&fromWorkspace, "from-workspace", "", "the key of the workspace to copy the charts from, usually the one that was backed up (required)")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "an archive file"); err != nil {
+				return err
+			}
+			if fromWorkspace == "" {
+				return errors.New("--from-workspace is required")
+			}
+			archive, err := readArchive(args[0])
+			if err != nil {
+				return err
+			}
+			credentials, err := app.resolveCredentials()
+			if err != nil {
+				return err
+			}
+			if credentials.WorkspaceKey == "" {
+				return errors.New("--workspace-key is required, it is the workspace to restore into")
+			}
+			baseUrl, err := cli.RegionUrl(credentials.Region)
+			if err != nil {
+				return err
+			}
+			return app.mutate(fmt.Sprintf("restore %s into workspace %s", args[0], credentials.WorkspaceKey), func(*seatsio.SeatsioClient) error {
+				result, err := backup.Restore(app.context, baseUrl, credentials.SecretKey, fromWorkspace, credentials.WorkspaceKey, archive)
+				if err != nil {
+					return err
+				}
+				for _, failure := range result.Failures {
+					fmt.Fprintln(app.out, "not restored:", failure)
+				}
+				return nil
+			})
+		},
+	}
+}
+
+func readArchive(path string) (*backup.Archive, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return backup.Read(file)
+}
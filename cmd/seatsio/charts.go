@@ -0,0 +1,164 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+func chartsCommand() *command {
+	return &command{
+		name:        "charts",
+		description: "List, inspect, publish and archive charts",
+		subcommands: []*command{
+			chartsListCommand(),
+			{
+				name:        "retrieve",
+				args:        "<chart key>",
+				description: "Show a chart",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 1, "a chart key"); err != nil {
+						return err
+					}
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					chart, err := client.Charts.Retrieve(app.context, args[0])
+					if err != nil {
+						return err
+					}
+					return app.print(chart, chartsTable([]charts.Chart{*chart}))
+				},
+			},
+			chartActionCommand("publish", "Publish the draft version of a chart", "publish the draft version of chart", func(client *seatsio.SeatsioClient, app *app, chartKey string) error {
+				return client.Charts.PublishDraftVersion(app.context, chartKey)
+			}),
+			chartActionCommand("archive", "Move a chart to the archive", "move to the archive chart", func(client *seatsio.SeatsioClient, app *app, chartKey string) error {
+				return client.Charts.MoveToArchive(app.context, chartKey)
+			}),
+			chartActionCommand("unarchive", "Move a chart out of the archive", "move out of the archive chart", func(client *seatsio.SeatsioClient, app *app, chartKey string) error {
+				return client.Charts.MoveOutOfArchive(app.context, chartKey)
+			}),
+			chartTagsCommand(),
+		},
+	}
+}
+
+func chartsListCommand() *command {
+	var tag, filter string
+	var archived bool
+	return &command{
+		name:        "list",
+		description: "List charts",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&tag, "tag", "", "only list charts with this tag")
+			flags.StringVar(&filter, "filter", "", "only list charts whose name contains this text")
+			flags.BoolVar(&archived, "archived", false, "list the charts in the archive")
+		},
+		run: func(app *app, args []string) error {
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			var opts []shared.PaginationParamsOption
+			if tag != "" {
+				opts = append(opts, charts.ChartSupport.WithTag(tag))
+			}
+			if filter != "" {
+				opts = append(opts, charts.ChartSupport.WithFilter(filter))
+			}
+			var result []charts.Chart
+			if archived {
+				result, err = client.Charts.Archive.All(app.context, opts...)
+			} else {
+				result, err = client.Charts.List(app.context).All(opts...)
+			}
+			if err != nil {
+				return err
+			}
+			return app.print(result, chartsTable(result))
+		},
+	}
+}
+
+func chartsTable(result []charts.Chart) *table {
+	chartsTable := &table{headers: []string{"key", "name", "status", "tags", "archived"}}
+	for _, chart := range result {
+		chartsTable.add(chart.Key, chart.Name, chart.Status, strings.Join(chart.Tags, ","), chart.Archived)
+	}
+	return chartsTable
+}
+
+func chartActionCommand(name string, description string, action string, change func(client *seatsio.SeatsioClient, app *app, chartKey string) error) *command {
+	return &command{
+		name:        name,
+		args:        "<chart key>",
+		description: description,
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "a chart key"); err != nil {
+				return err
+			}
+			return app.mutate(action+" "+args[0], func(client *seatsio.SeatsioClient) error {
+				return change(client, app, args[0])
+			})
+		},
+	}
+}
+
+func chartTagsCommand() *command {
+	return &command{
+		name:        "tags",
+		description: "List, add and remove chart tags",
+		subcommands: []*command{
+			{
+				name:        "list",
+				description: "List all tags in the workspace",
+				run: func(app *app, args []string) error {
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					tags, err := client.Charts.ListAllTags(app.context)
+					if err != nil {
+						return err
+					}
+					tagsTable := &table{headers: []string{"tag"}}
+					for _, tag := range tags {
+						tagsTable.add(tag)
+					}
+					return app.print(tags, tagsTable)
+				},
+			},
+			{
+				name:        "add",
+				args:        "<chart key> <tag>",
+				description: "Add a tag to a chart",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 2, "a chart key and a tag"); err != nil {
+						return err
+					}
+					return app.mutate("add tag "+args[1]+" to chart "+args[0], func(client *seatsio.SeatsioClient) error {
+						return client.Charts.AddTag(app.context, args[0], args[1])
+					})
+				},
+			},
+			{
+				name:        "remove",
+				args:        "<chart key> <tag>",
+				description: "Remove a tag from a chart",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 2, "a chart key and a tag"); err != nil {
+						return err
+					}
+					return app.mutate("remove tag "+args[1]+" from chart "+args[0], func(client *seatsio.SeatsioClient) error {
+						return client.Charts.RemoveTag(app.context, args[0], args[1])
+					})
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+type command struct {
+	name        string
+	args        string
+	description string
+	subcommands []*command
+	flags       func(flags *flag.FlagSet)
+	run         func(app *app, args []string) error
+	hidden      bool
+}
+
+func (cmd *command) find(name string) *command {
+	for _, subcommand := range cmd.subcommands {
+		if subcommand.name == name {
+			return subcommand
+		}
+	}
+	return nil
+}
+
+// resolve walks down the command tree as long as the arguments name subcommands.
+func (cmd *command) resolve(args []string) (*command, []string, []string) {
+	node := cmd
+	path := []string{cmd.name}
+	for len(args) > 0 {
+		next := node.find(args[0])
+		if next == nil {
+			break
+		}
+		node = next
+		path = append(path, next.name)
+		args = args[1:]
+	}
+	return node, path, args
+}
+
+func (cmd *command) flagSet(app *app, path []string) *flag.FlagSet {
+	flags := flag.NewFlagSet(strings.Join(path, " "), flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	app.registerGlobalFlags(flags)
+	if cmd.flags != nil {
+		cmd.flags(flags)
+	}
+	return flags
+}
+
+func (cmd *command) printUsage(out io.Writer, app *app, path []string) {
+	if cmd.run != nil {
+		fmt.Fprintf(out, "Usage: %s %s\n", strings.Join(path, " "), cmd.args)
+	} else {
+		fmt.Fprintf(out, "Usage: %s <command>\n", strings.Join(path, " "))
+	}
+	if cmd.description != "" {
+		fmt.Fprintf(out, "\n%s\n", cmd.description)
+	}
+	if len(cmd.subcommands) > 0 {
+		fmt.Fprintln(out, "\nCommands:")
+		for _, subcommand := range cmd.subcommands {
+			if !subcommand.hidden {
+				fmt.Fprintf(out, "  %-24s %s\n", subcommand.name, subcommand.description)
+			}
+		}
+	}
+	if cmd.run != nil {
+		fmt.Fprintln(out, "\nFlags:")
+		flags := cmd.flagSet(app, path)
+		flags.SetOutput(out)
+		flags.PrintDefaults()
+	}
+}
+
+// parseInterspersed allows flags to come after positional arguments, which the flag package does not support.
+func parseInterspersed(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		if args[0] == "--" {
+			return append(positional, args[1:]...), nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// completions returns the subcommands and flags that can follow the given words.
+func (cmd *command) completions(app *app, words []string) []string {
+	node, path, rest := cmd.resolve(words)
+	var candidates []string
+	if len(rest) == 0 {
+		for _, subcommand := range node.subcommands {
+			if !subcommand.hidden {
+				candidates = append(candidates, subcommand.name)
+			}
+		}
+	}
+	if node.run != nil {
+		node.flagSet(app, path).VisitAll(func(f *flag.Flag) {
+			candidates = append(candidates, "--"+f.Name)
+		})
+	}
+	sort.Strings(candidates)
+	return candidates
+}
+
+func exactArgs(args []string, count int, usage string) error {
+	if len(args) != count {
+		return fmt.Errorf("expected %s", usage)
+	}
+	return nil
+}
+
+func minArgs(args []string, count int, usage string) error {
+	if len(args) < count {
+		return fmt.Errorf("expected %s", usage)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return ""
+	}
+	return value.Format(time.RFC3339)
+}
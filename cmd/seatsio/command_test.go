@@ -0,0 +1,145 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func runCommand(t *testing.T, args ...string) (string, string, error) {
+	var out, errOut bytes.Buffer
+	app := &app{out: &out, errOut: &errOut, context: test_util.RequestContext(), format: "table"}
+	err := run(app, args)
+	return out.String(), errOut.String(), err
+}
+
+func TestParseInterspersed(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		args       []string
+		positional []string
+		orderId    string
+		dryRun     bool
+		err        string
+	}{
+		{name: "flags before arguments", args: []string{"--order-id", "order1", "event1", "A-1"}, positional: []string{"event1", "A-1"}, orderId: "order1"},
+		{name: "flags after arguments", args: []string{"event1", "A-1", "--order-id", "order1"}, positional: []string{"event1", "A-1"}, orderId: "order1"},
+		{name: "flags between arguments", args: []string{"event1", "--dry-run", "A-1", "--order-id=order1", "A-2"}, positional: []string{"event1", "A-1", "A-2"}, orderId: "order1", dryRun: true},
+		{name: "double dash ends the flags", args: []string{"event1", "--", "--order-id", "A-1"}, positional: []string{"event1", "--order-id", "A-1"}},
+		{name: "no arguments", args: []string{}},
+		{name: "unknown flag", args: []string{"event1", "--unknown"}, err: "flag provided but not defined: -unknown"},
+		{name: "missing flag value", args: []string{"event1", "--order-id"}, err: "flag needs an argument: -order-id"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			flags := flag.NewFlagSet("test", flag.ContinueOnError)
+			flags.SetOutput(io.Discard)
+			orderId := flags.String("order-id", "", "")
+			dryRun := flags.Bool("dry-run", false, "")
+
+			positional, err := parseInterspersed(flags, test.args)
+
+			if test.err != "" {
+				require.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.positional, positional)
+			require.Equal(t, test.orderId, *orderId)
+			require.Equal(t, test.dryRun, *dryRun)
+		})
+	}
+}
+
+func TestCompletions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		words    []string
+		contains []string
+		excludes []string
+	}{
+		{words: nil, contains: []string{"charts", "events", "event-log", "completion"}, excludes: []string{"__complete"}},
+		{words: []string{"events"}, contains: []string{"book", "hold", "release", "status-changes"}, excludes: []string{"--order-id"}},
+		{words: []string{"events", "book"}, contains: []string{"--order-id", "--hold-token", "--dry-run", "--format"}, excludes: []string{"book"}},
+		{words: []string{"event-log", "tail"}, contains: []string{"--follow", "--interval", "--limit"}},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.words, " "), func(t *testing.T) {
+			t.Parallel()
+			out, _, err := runCommand(t, append([]string{"__complete"}, test.words...)...)
+			require.NoError(t, err)
+
+			candidates := strings.Split(strings.TrimSpace(out), "\n")
+			require.Subset(t, candidates, test.contains)
+			for _, excluded := range test.excludes {
+				require.NotContains(t, candidates, excluded)
+			}
+		})
+	}
+}
+
+func TestCompletionScripts(t *testing.T) {
+	t.Parallel()
+	out, _, err := runCommand(t, "completion", "bash")
+	require.NoError(t, err)
+	require.Equal(t, bashCompletion, out)
+
+	out, _, err = runCommand(t, "completion", "fish")
+	require.NoError(t, err)
+	require.Equal(t, fishCompletion, out)
+
+	_, _, err = runCommand(t, "completion", "powershell")
+	require.EqualError(t, err, "unsupported shell powershell, expected bash, zsh or fish")
+}
+
+func TestDryRunDoesNotNeedCredentials(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args []string
+		out  string
+	}{
+		{args: []string{"--dry-run", "events", "book", "event1", "A-1", "A-2", "--order-id", "order1"}, out: "dry run: would change the status of A-1, A-2 in event event1 to booked\n"},
+		{args: []string{"events", "release", "event1", "A-1", "--dry-run"}, out: "dry run: would release A-1 in event event1\n"},
+		{args: []string{"events", "create", "--chart", "chart1", "--dry-run"}, out: "dry run: would create an event on chart chart1\n"},
+		{args: []string{"hold-tokens", "create", "--dry-run", "--format", "json"}, out: "dry run: would create a hold token\n"},
+	}
+	for _, test := range tests {
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			t.Parallel()
+			out, errOut, err := runCommand(t, test.args...)
+
+			require.NoError(t, err)
+			require.Equal(t, test.out, out)
+			require.Empty(t, errOut)
+		})
+	}
+}
+
+func TestDryRunStillValidatesArguments(t *testing.T) {
+	t.Parallel()
+	_, _, err := runCommand(t, "--dry-run", "events", "hold", "event1", "A-1")
+	require.EqualError(t, err, "--hold-token is required")
+
+	_, _, err = runCommand(t, "--dry-run", "events", "book", "event1")
+	require.EqualError(t, err, "expected an event key and at least one object")
+}
+
+func TestUnknownCommand(t *testing.T) {
+	t.Parallel()
+	out, _, err := runCommand(t, "events", "explode")
+
+	require.EqualError(t, err, "unknown command events explode")
+	require.Contains(t, out, "Usage: seatsio events <command>")
+}
+
+func TestFormatTime(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, "", formatTime(nil))
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"flag"
+	"math"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+func eventLogCommand() *command {
+	return &command{
+		name:        "event-log",
+		description: "Show the event log of a workspace",
+		subcommands: []*command{eventLogTailCommand()},
+	}
+}
+
+func eventLogTailCommand() *command {
+	var follow bool
+	var interval time.Duration
+	var limit int
+	return &command{
+		name:        "tail",
+		description: "Show the most recent event log items, oldest first",
+		flags: func(flags *flag.FlagSet) {
+			flags.BoolVar(&follow, "follow", false, "keep polling for new event log items")
+			flags.DurationVar(&interval, "interval", 5*time.Second, "the polling interval when following")
+			flags.IntVar(&limit, "limit", 20, "the number of items to show initially")
+		},
+		run: func(app *app, args []string) error {
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			// the event log is ordered oldest first, so the page before the highest possible id holds the most recent items
+			page, err := client.EventLog.ListPageBefore(app.context, math.MaxInt64, shared.Pagination.PageSize(limit))
+			var lastSeen int64
+			for {
+				if err != nil {
+					return err
+				}
+				if len(page.Items) > 0 {
+					lastSeen = page.Items[len(page.Items)-1].Id
+					itemsTable := &table{headers: []string{"id", "timestamp", "type", "data"}}
+					for _, item := range page.Items {
+						itemsTable.add(item.Id, formatTime(item.Timestamp), item.Type, item.Data)
+					}
+					if err := app.print(page.Items, itemsTable); err != nil {
+						return err
+					}
+				}
+				if page.NextPageStartsAfter == 0 {
+					if !follow {
+						return nil
+					}
+					select {
+					case <-app.context.Done():
+						return nil
+					case <-time.After(interval):
+					}
+				}
+				if lastSeen == 0 {
+					page, err = client.EventLog.ListFirstPage(app.context)
+				} else {
+					page, err = client.EventLog.ListPageAfter(app.context, lastSeen)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+func eventsCommand() *command {
+	return &command{
+		name:        "events",
+		description: "Create events and change the status of their objects",
+		subcommands: []*command{
+			{
+				name:        "list",
+				description: "List events",
+				run: func(app *app, args []string) error {
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					result, err := client.Events.ListAll(app.context)
+					if err != nil {
+						return err
+					}
+					return app.print(result, eventsTable(result))
+				},
+			},
+			{
+				name:        "retrieve",
+				args:        "<event key>",
+				description: "Show an event",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 1, "an event key"); err != nil {
+						return err
+					}
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					event, err := client.Events.Retrieve(app.context, args[0])
+					if err != nil {
+						return err
+					}
+					return app.print(event, eventsTable([]events.Event{*event}))
+				},
+			},
+			eventsCreateCommand(),
+			eventsStatusChangeCommand("book", "Book objects", events.BOOKED),
+			eventsStatusChangeCommand("hold", "Hold objects with a hold token", events.HELD),
+			eventsReleaseCommand(),
+			eventsStatusChangesCommand(),
+		},
+	}
+}
+
+func eventsTable(result []events.Event) *table {
+	eventsTable := &table{headers: []string{"key", "chart", "name", "date", "channels"}}
+	for _, event := range result {
+		eventsTable.add(event.Key, event.ChartKey, event.Name, event.Date, len(event.Channels))
+	}
+	return eventsTable
+}
+
+func eventsCreateCommand() *command {
+	var chartKey, eventKey, name, date string
+	return &command{
+		name:        "create",
+		description: "Create an event",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&chartKey, "chart", "", "the chart key (required)")
+			flags.StringVar(&eventKey, "key", "", "the event key; generated when empty")
+			flags.StringVar(&name, "name", "", "the event name")
+			flags.StringVar(&date, "date", "", "the event date, as yyyy-mm-dd")
+		},
+		run: func(app *app, args []string) error {
+			if chartKey == "" {
+				return fmt.Errorf("--chart is required")
+			}
+			params := &events.CreateEventParams{ChartKey: chartKey, EventParams: &events.EventParams{EventKey: eventKey, Name: name, Date: date}}
+			return app.mutate("create an event on chart "+chartKey, func(client *seatsio.SeatsioClient) error {
+				event, err := client.Events.Create(app.context, params)
+				if err != nil {
+					return err
+				}
+				return app.print(event, eventsTable([]events.Event{*event}))
+			})
+		},
+	}
+}
+
+func eventsStatusChangeCommand(name string, description string, status string) *command {
+	var holdToken, orderId string
+	return &command{
+		name:        name,
+		args:        "<event key> <object>...",
+		description: description,
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&holdToken, "hold-token", "", "the hold token")
+			flags.StringVar(&orderId, "order-id", "", "the order id")
+		},
+		run: func(app *app, args []string) error {
+			if err := minArgs(args, 2, "an event key and at least one object"); err != nil {
+				return err
+			}
+			if status == events.HELD && holdToken == "" {
+				return fmt.Errorf("--hold-token is required")
+			}
+			params := statusChangeParams(args[0], args[1:], holdToken, orderId)
+			params.Status = status
+			return app.mutate(fmt.Sprintf("change the status of %s in event %s to %s", strings.Join(args[1:], ", "), args[0], status), func(client *seatsio.SeatsioClient) error {
+				result, err := client.Events.ChangeObjectStatusWithOptions(app.context, params)
+				if err != nil {
+					return err
+				}
+				return app.print(result, objectInfosTable(result.Objects))
+			})
+		},
+	}
+}
+
+func eventsReleaseCommand() *command {
+	var holdToken string
+	return &command{
+		name:        "release",
+		args:        "<event key> <object>...",
+		description: "Release objects",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&holdToken, "hold-token", "", "the hold token, for held objects")
+		},
+		run: func(app *app, args []string) error {
+			if err := minArgs(args, 2, "an event key and at least one object"); err != nil {
+				return err
+			}
+			params := statusChangeParams(args[0], args[1:], holdToken, "")
+			return app.mutate(fmt.Sprintf("release %s in event %s", strings.Join(args[1:], ", "), args[0]), func(client *seatsio.SeatsioClient) error {
+				result, err := client.Events.ReleaseWithOptions(app.context, params)
+				if err != nil {
+					return err
+				}
+				return app.print(result, objectInfosTable(result.Objects))
+			})
+		},
+	}
+}
+
+func statusChangeParams(eventKey string, objects []string, holdToken string, orderId string) *events.StatusChangeParams {
+	objectProperties := make([]events.ObjectProperties, len(objects))
+	for i, object := range objects {
+		objectProperties[i] = events.ObjectProperties{ObjectId: object}
+	}
+	return &events.StatusChangeParams{
+		Events:        []string{eventKey},
+		StatusChanges: events.StatusChanges{Objects: objectProperties, HoldToken: holdToken, OrderId: orderId},
+	}
+}
+
+func objectInfosTable(objects map[string]events.EventObjectInfo) *table {
+	objectsTable := &table{headers: []string{"label", "status", "category", "orderId", "holdToken"}}
+	for label, info := range objects {
+		objectsTable.add(label, info.Status, info.CategoryLabel, info.OrderId, info.HoldToken)
+	}
+	return objectsTable
+}
+
+func eventsStatusChangesCommand() *command {
+	var status, orderId, holdToken, labels, since, until string
+	return &command{
+		name:        "status-changes",
+		args:        "<event key>",
+		description: "List the status changes of an event, most recent first",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&status, "status", "", "only show these statuses (comma-separated)")
+			flags.StringVar(&orderId, "order-id", "", "only show these order ids (comma-separated)")
+			flags.StringVar(&holdToken, "hold-token", "", "only show this hold token")
+			flags.StringVar(&labels, "labels", "", "only show these object labels (comma-separated)")
+			flags.StringVar(&since, "since", "", "only show status changes at or after this RFC 3339 time")
+			flags.StringVar(&until, "until", "", "only show status changes before this RFC 3339 time")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "an event key"); err != nil {
+				return err
+			}
+			support := events.EventSupport
+			var opts []events.ListParamsOption
+			if status != "" {
+				opts = append(opts, support.WithStatus(splitList(status)...))
+			}
+			if orderId != "" {
+				opts = append(opts, support.WithOrderId(splitList(orderId)...))
+			}
+			if holdToken != "" {
+				opts = append(opts, support.WithHoldToken(holdToken))
+			}
+			if labels != "" {
+				opts = append(opts, support.WithObjectLabels(splitList(labels)...))
+			}
+			if since != "" || until != "" {
+				from, err := optionalTime(since)
+				if err != nil {
+					return err
+				}
+				to, err := optionalTime(until)
+				if err != nil {
+					return err
+				}
+				opts = append(opts, support.WithDateRange(from, to))
+			}
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			lister := client.Events.StatusChanges(app.context, args[0], opts...)
+			switch app.format {
+			case "csv":
+				return events.ExportStatusChangesToCSV(lister, app.out)
+			case "json":
+				return events.ExportStatusChangesToJSONLines(lister, app.out)
+			}
+			statusChanges, err := lister.All()
+			if err != nil {
+				return err
+			}
+			statusChangesTable := &table{headers: []string{"id", "date", "label", "status", "orderId", "holdToken", "origin"}}
+			for _, statusChange := range statusChanges {
+				statusChangesTable.add(statusChange.Id, formatTime(statusChange.Date), statusChange.ObjectLabel, statusChange.Status, statusChange.OrderId, statusChange.HoldToken, statusChange.Origin.Type)
+			}
+			return app.print(statusChanges, statusChangesTable)
+		},
+	}
+}
+
+func optionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
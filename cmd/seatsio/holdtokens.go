@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"strconv"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/holdtokens"
+)
+
+func holdTokensCommand() *command {
+	return &command{
+		name:        "hold-tokens",
+		description: "Create, retrieve and expire hold tokens",
+		subcommands: []*command{
+			holdTokensCreateCommand(),
+			{
+				name:        "retrieve",
+				args:        "<hold token>",
+				description: "Show a hold token",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 1, "a hold token"); err != nil {
+						return err
+					}
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					holdToken, err := client.HoldTokens.Retrieve(app.context, args[0])
+					if err != nil {
+						return err
+					}
+					return app.print(holdToken, holdTokenTable(holdToken))
+				},
+			},
+			{
+				name:        "expire",
+				args:        "<hold token> <minutes>",
+				description: "Change the number of minutes after which a hold token expires",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 2, "a hold token and a number of minutes"); err != nil {
+						return err
+					}
+					minutes, err := strconv.Atoi(args[1])
+					if err != nil {
+						return err
+					}
+					return app.mutate("expire hold token "+args[0]+" in "+args[1]+" minutes", func(client *seatsio.SeatsioClient) error {
+						holdToken, err := client.HoldTokens.ExpireInMinutes(app.context, args[0], minutes)
+						if err != nil {
+							return err
+						}
+						return app.print(holdToken, holdTokenTable(holdToken))
+					})
+				},
+			},
+		},
+	}
+}
+
+func holdTokensCreateCommand() *command {
+	var expiresInMinutes int
+	return &command{
+		name:        "create",
+		description: "Create a hold token",
+		flags: func(flags *flag.FlagSet) {
+			flags.IntVar(&expiresInMinutes, "expires-in-minutes", 0, "the number of minutes after which the hold token expires; the workspace default when 0")
+		},
+		run: func(app *app, args []string) error {
+			return app.mutate("create a hold token", func(client *seatsio.SeatsioClient) error {
+				var holdToken *holdtokens.HoldToken
+				var err error
+				if expiresInMinutes > 0 {
+					holdToken, err = client.HoldTokens.CreateWithExpiration(app.context, expiresInMinutes)
+				} else {
+					holdToken, err = client.HoldTokens.Create(app.context)
+				}
+				if err != nil {
+					return err
+				}
+				return app.print(holdToken, holdTokenTable(holdToken))
+			})
+		},
+	}
+}
+
+func holdTokenTable(holdToken *holdtokens.HoldToken) *table {
+	holdTokenTable := &table{headers: []string{"holdToken", "expiresAt", "expiresInSeconds"}}
+	holdTokenTable.add(holdToken.HoldToken, formatTime(holdToken.ExpiresAt), holdToken.ExpiresInSeconds)
+	return holdTokenTable
+}
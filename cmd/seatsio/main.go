@@ -0,0 +1,122 @@
+// Command seatsio is a command-line interface to the Seats.io API.
+//
+//	seatsio charts list --tag concerts
+//	seatsio events book <event key> A-1 A-2 --order-id order1
+//	seatsio reports event <event key> --by status --format csv
+//	seatsio event-log tail --follow
+//
+// Credentials are read from flags, from the SEATSIO_SECRET_KEY, SEATSIO_WORKSPACE_KEY and SEATSIO_REGION
+// environment variables, or from a profile in the configuration file (~/.config/seatsio/config.yaml by default).
+// Run `seatsio completion bash|zsh|fish` to generate a shell completion script.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/seatsio/seatsio-go/v12/cmd/internal/cli"
+)
+
+func main() {
+	app := &app{out: os.Stdout, errOut: os.Stderr, context: context.Background(), configPath: cli.DefaultConfigPath(), format: "table"}
+	if err := run(app, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func rootCommand() *command {
+	return &command{
+		name:        "seatsio",
+		description: "Manage charts, events, reports, hold tokens and workspaces through the Seats.io API.",
+		subcommands: []*command{
+			chartsCommand(),
+			eventsCommand(),
+			reportsCommand(),
+			holdTokensCommand(),
+			workspacesCommand(),
+			eventLogCommand(),
+			backupCommand(),
+			completionCommand(),
+		},
+	}
+}
+
+func run(app *app, args []string) error {
+	root := rootCommand()
+	globalFlags := flag.NewFlagSet("seatsio", flag.ContinueOnError)
+	globalFlags.SetOutput(io.Discard)
+	app.registerGlobalFlags(globalFlags)
+	if err := globalFlags.Parse(args); errors.Is(err, flag.ErrHelp) {
+		root.printUsage(app.out, app, []string{root.name})
+		return nil
+	} else if err != nil {
+		return err
+	}
+	args = globalFlags.Args()
+
+	if len(args) > 0 && args[0] == "__complete" {
+		for _, candidate := range root.completions(app, args[1:]) {
+			fmt.Fprintln(app.out, candidate)
+		}
+		return nil
+	}
+
+	cmd, path, rest := root.resolve(args)
+	if cmd.run == nil {
+		cmd.printUsage(app.out, app, path)
+		if len(rest) > 0 {
+			return fmt.Errorf("unknown command %s", strings.Join(append(path[1:], rest[0]), " "))
+		}
+		return nil
+	}
+	flags := cmd.flagSet(app, path)
+	positional, err := parseInterspersed(flags, rest)
+	if errors.Is(err, flag.ErrHelp) {
+		cmd.printUsage(app.out, app, path)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return cmd.run(app, positional)
+}
+
+func completionCommand() *command {
+	return &command{
+		name:        "completion",
+		args:        "bash|zsh|fish",
+		description: "Print a shell completion script. For bash: source <(seatsio completion bash)",
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "bash, zsh or fish"); err != nil {
+				return err
+			}
+			switch args[0] {
+			case "bash":
+				fmt.Fprint(app.out, bashCompletion)
+			case "zsh":
+				fmt.Fprint(app.out, "autoload -U +X bashcompinit && bashcompinit\n"+bashCompletion)
+			case "fish":
+				fmt.Fprint(app.out, fishCompletion)
+			default:
+				return fmt.Errorf("unsupported shell %s, expected bash, zsh or fish", args[0])
+			}
+			return nil
+		},
+	}
+}
+
+const bashCompletion = `_seatsio_complete() {
+    local cur="${COMP_WORDS[COMP_CWORD]}"
+    COMPREPLY=( $(compgen -W "$(seatsio __complete "${COMP_WORDS[@]:1:COMP_CWORD-1}" 2>/dev/null)" -- "$cur") )
+}
+complete -o default -F _seatsio_complete seatsio
+`
+
+const fishCompletion = `complete -c seatsio -f -a '(seatsio __complete (commandline -opc)[2..-1] 2>/dev/null)'
+`
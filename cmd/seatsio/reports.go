@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/seatsio/seatsio-go/v12/reports"
+)
+
+func reportsCommand() *command {
+	return &command{
+		name:        "reports",
+		description: "Show event, chart and usage reports",
+		subcommands: []*command{
+			eventReportCommand(),
+			eventSummaryReportCommand(),
+			chartReportCommand(),
+			{
+				name:        "usage",
+				description: "Show the number of used objects per month",
+				run: func(app *app, args []string) error {
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					usage, err := client.UsageReports.SummaryForAllMonths(app.context)
+					if err != nil {
+						return err
+					}
+					usageTable := &table{headers: []string{"month", "usedObjects"}}
+					for _, month := range usage.Usage {
+						usageTable.add(fmt.Sprintf("%d-%02d", month.Month.Year, month.Month.Month), month.NumUsedObjects)
+					}
+					return app.print(usage, usageTable)
+				},
+			},
+		},
+	}
+}
+
+func eventReportCommand() *command {
+	var by string
+	return &command{
+		name:        "event",
+		args:        "<event key>",
+		description: "Show the objects of an event, grouped by label, status, category, section, order id, channel, zone, availability or object type",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&by, "by", "label", "label, status, categoryLabel, categoryKey, section, orderId, channel, zone, availability, availabilityReason or objectType")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "an event key"); err != nil {
+				return err
+			}
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			fetchers := map[string]func(context.Context, string) (*reports.DetailedEventReport, error){
+				"label":              client.EventReports.ByLabel,
+				"status":             client.EventReports.ByStatus,
+				"categoryLabel":      client.EventReports.ByCategoryLabel,
+				"categoryKey":        client.EventReports.ByCategoryKey,
+				"section":            client.EventReports.BySection,
+				"orderId":            client.EventReports.ByOrderId,
+				"channel":            client.EventReports.ByChannel,
+				"zone":               client.EventReports.ByZone,
+				"availability":       client.EventReports.ByAvailability,
+				"availabilityReason": client.EventReports.ByAvailabilityReason,
+				"objectType":         client.EventReports.ByObjectType,
+			}
+			fetch, ok := fetchers[by]
+			if !ok {
+				return fmt.Errorf("unknown report type %s", by)
+			}
+			report, err := fetch(app.context, args[0])
+			if err != nil {
+				return err
+			}
+			reportTable := &table{headers: []string{by, "label", "status", "category", "section", "orderId", "available"}}
+			for _, group := range slices.Sorted(maps.Keys(report.Items)) {
+				for _, info := range report.Items[group] {
+					reportTable.add(group, info.Label, info.Status, info.CategoryLabel, info.Labels.Section, info.OrderId, info.IsAvailable)
+				}
+			}
+			return app.print(report.Items, reportTable)
+		},
+	}
+}
+
+func eventSummaryReportCommand() *command {
+	var by string
+	return &command{
+		name:        "event-summary",
+		args:        "<event key>",
+		description: "Show the number of objects of an event per status, category, section, channel, zone or availability",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&by, "by", "status", "status, categoryLabel, categoryKey, section, channel, zone, availability, availabilityReason or objectType")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "an event key"); err != nil {
+				return err
+			}
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			fetchers := map[string]func(context.Context, string) (*reports.EventSummaryReport, error){
+				"status":             client.EventReports.SummaryByStatus,
+				"categoryLabel":      client.EventReports.SummaryByCategoryLabel,
+				"categoryKey":        client.EventReports.SummaryByCategoryKey,
+				"section":            client.EventReports.SummaryBySection,
+				"channel":            client.EventReports.SummaryByChannel,
+				"zone":               client.EventReports.SummaryByZone,
+				"availability":       client.EventReports.SummaryByAvailability,
+				"availabilityReason": client.EventReports.SummaryByAvailabilityReason,
+				"objectType":         client.EventReports.SummaryByObjectType,
+			}
+			fetch, ok := fetchers[by]
+			if !ok {
+				return fmt.Errorf("unknown report type %s", by)
+			}
+			report, err := fetch(app.context, args[0])
+			if err != nil {
+				return err
+			}
+			summaryTable := &table{headers: []string{by, "count"}}
+			for _, group := range slices.Sorted(maps.Keys(report.Items)) {
+				summaryTable.add(group, report.Items[group].Count)
+			}
+			return app.print(report.Items, summaryTable)
+		},
+	}
+}
+
+func chartReportCommand() *command {
+	var by string
+	var draft bool
+	return &command{
+		name:        "chart",
+		args:        "<chart key>",
+		description: "Show the objects of a chart, grouped by label, object type, category, section or zone",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&by, "by", "label", "label, objectType, categoryLabel, categoryKey, section or zone")
+			flags.BoolVar(&draft, "draft", false, "report on the draft version")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "a chart key"); err != nil {
+				return err
+			}
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			opts := optionsIf(draft, reports.ChartReportOptions.UseDraftVersion())
+			var report *reports.ChartReport
+			switch by {
+			case "label":
+				report, err = client.ChartReports.ByLabel(app.context, args[0], opts...)
+			case "objectType":
+				report, err = client.ChartReports.ByObjectType(app.context, args[0], opts...)
+			case "categoryLabel":
+				report, err = client.ChartReports.ByCategoryLabel(app.context, args[0], opts...)
+			case "categoryKey":
+				report, err = client.ChartReports.ByCategoryKey(app.context, args[0], opts...)
+			case "section":
+				report, err = client.ChartReports.BySection(app.context, args[0], opts...)
+			case "zone":
+				report, err = client.ChartReports.ByZone(app.context, args[0], opts...)
+			default:
+				return fmt.Errorf("unknown report type %s", by)
+			}
+			if err != nil {
+				return err
+			}
+			reportTable := &table{headers: []string{by, "label", "objectType", "category", "section", "capacity"}}
+			for _, group := range slices.Sorted(maps.Keys(report.Items)) {
+				for _, item := range report.Items[group] {
+					reportTable.add(group, item.Label, item.ObjectType, item.CategoryLabel, item.Labels.Section, item.Capacity)
+				}
+			}
+			return app.print(report.Items, reportTable)
+		},
+	}
+}
+
+// optionsIf returns the option when the condition holds. The report option types are unexported, so
+// the slice type has to be inferred.
+func optionsIf[O any](condition bool, option O) []O {
+	if condition {
+		return []O{option}
+	}
+	return nil
+}
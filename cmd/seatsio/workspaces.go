@@ -0,0 +1,148 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/shared"
+	"github.com/seatsio/seatsio-go/v12/workspaces"
+)
+
+// The workspaces commands need a company admin key.
+func workspacesCommand() *command {
+	return &command{
+		name:        "workspaces",
+		description: "Manage the workspaces of a company (needs a company admin key)",
+		subcommands: []*command{
+			workspacesListCommand(),
+			{
+				name:        "retrieve",
+				args:        "<workspace key>",
+				description: "Show a workspace",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 1, "a workspace key"); err != nil {
+						return err
+					}
+					client, err := app.seatsioClient()
+					if err != nil {
+						return err
+					}
+					workspace, err := client.Workspaces.Retrieve(app.context, args[0])
+					if err != nil {
+						return err
+					}
+					return app.print(workspace, workspacesTable([]workspaces.Workspace{*workspace}))
+				},
+			},
+			workspacesCreateCommand(),
+			workspaceKeyCommand("activate", "Activate a workspace", workspaces.Workspaces.Activate),
+			workspaceKeyCommand("deactivate", "Deactivate a workspace", workspaces.Workspaces.Deactivate),
+			{
+				name:        "regenerate-secret-key",
+				args:        "<workspace key>",
+				description: "Replace the secret key of a workspace",
+				run: func(app *app, args []string) error {
+					if err := exactArgs(args, 1, "a workspace key"); err != nil {
+						return err
+					}
+					return app.mutate("regenerate the secret key of workspace "+args[0], func(client *seatsio.SeatsioClient) error {
+						secretKey, err := client.Workspaces.RegenerateSecretKey(app.context, args[0])
+						if err != nil {
+							return err
+						}
+						fmt.Fprintln(app.out, *secretKey)
+						return nil
+					})
+				},
+			},
+		},
+	}
+}
+
+func workspacesListCommand() *command {
+	var status, filter string
+	return &command{
+		name:        "list",
+		description: "List workspaces",
+		flags: func(flags *flag.FlagSet) {
+			flags.StringVar(&status, "status", "all", "all, active or inactive")
+			flags.StringVar(&filter, "filter", "", "only list workspaces whose name or key contains this text")
+		},
+		run: func(app *app, args []string) error {
+			statuses := map[string]workspaces.WorkspaceStatus{"all": workspaces.All, "active": workspaces.Active, "inactive": workspaces.Inactive}
+			workspaceStatus, ok := statuses[status]
+			if !ok {
+				return fmt.Errorf("unknown status %s", status)
+			}
+			var opts []shared.PaginationParamsOption
+			if filter != "" {
+				opts = append(opts, workspaces.WorkspaceSupport.WithFilter(filter))
+			}
+			client, err := app.seatsioClient()
+			if err != nil {
+				return err
+			}
+			result, err := client.Workspaces.ListAll(app.context, workspaceStatus, opts...)
+			if err != nil {
+				return err
+			}
+			return app.print(result, workspacesTable(result))
+		},
+	}
+}
+
+func workspacesCreateCommand() *command {
+	var test bool
+	return &command{
+		name:        "create",
+		args:        "<name>",
+		description: "Create a workspace",
+		flags: func(flags *flag.FlagSet) {
+			flags.BoolVar(&test, "test", false, "create a test workspace")
+		},
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "a workspace name"); err != nil {
+				return err
+			}
+			return app.mutate("create workspace "+args[0], func(client *seatsio.SeatsioClient) error {
+				var workspace *workspaces.Workspace
+				var err error
+				if test {
+					workspace, err = client.Workspaces.CreateTestWorkspace(app.context, args[0])
+				} else {
+					workspace, err = client.Workspaces.CreateProductionWorkspace(app.context, args[0])
+				}
+				if err != nil {
+					return err
+				}
+				return app.print(workspace, workspacesTable([]workspaces.Workspace{*workspace}))
+			})
+		},
+	}
+}
+
+func workspaceKeyCommand(name string, description string, change func(workspaces.Workspaces, context.Context, string) error) *command {
+	return &command{
+		name:        name,
+		args:        "<workspace key>",
+		description: description,
+		run: func(app *app, args []string) error {
+			if err := exactArgs(args, 1, "a workspace key"); err != nil {
+				return err
+			}
+			return app.mutate(name+" workspace "+args[0], func(client *seatsio.SeatsioClient) error {
+				return change(*client.Workspaces, app.context, args[0])
+			})
+		},
+	}
+}
+
+func workspacesTable(result []workspaces.Workspace) *table {
+	workspacesTable := &table{headers: []string{"key", "name", "test", "active", "default"}}
+	for _, workspace := range result {
+		workspacesTable.add(workspace.Key, workspace.Name, workspace.IsTest, workspace.IsActive, workspace.IsDefault)
+	}
+	return workspacesTable
+}
@@ -0,0 +1,145 @@
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type Credentials struct {
+	SecretKey string `json:"secretKey"`
+	// WorkspaceKey is only needed with a company admin key.
+	WorkspaceKey string `json:"workspaceKey,omitempty"`
+}
+
+// Provider returns the credentials to use for a request. It is called before every request, so it should be cheap.
+type Provider interface {
+	Credentials(context context.Context) (Credentials, error)
+}
+
+// Static always returns the same credentials.
+type Static Credentials
+
+func (static Static) Credentials(context context.Context) (Credentials, error) {
+	return Credentials(static), nil
+}
+
+// Func adapts a function, for instance one that reads a secrets manager, to a Provider.
+type Func func(context context.Context) (Credentials, error)
+
+func (f Func) Credentials(context context.Context) (Credentials, error) {
+	return f(context)
+}
+
+// Environment reads the credentials from environment variables on every request. The variables default to
+// SEATSIO_SECRET_KEY and SEATSIO_WORKSPACE_KEY.
+type Environment struct {
+	SecretKeyVariable    string
+	WorkspaceKeyVariable string
+}
+
+func (environment Environment) Credentials(context context.Context) (Credentials, error) {
+	secretKeyVariable := valueOrDefault(environment.SecretKeyVariable, "SEATSIO_SECRET_KEY")
+	workspaceKeyVariable := valueOrDefault(environment.WorkspaceKeyVariable, "SEATSIO_WORKSPACE_KEY")
+	secretKey := os.Getenv(secretKeyVariable)
+	if secretKey == "" {
+		return Credentials{}, fmt.Errorf("%s is not set", secretKeyVariable)
+	}
+	return Credentials{SecretKey: secretKey, WorkspaceKey: os.Getenv(workspaceKeyVariable)}, nil
+}
+
+func valueOrDefault(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
+
+// File reads the credentials from a file, and reads it again whenever its modification time or size changes. The
+// file holds either just the secret key, or a JSON object with secretKey and workspaceKey.
+type File struct {
+	path    string
+	mutex   sync.Mutex
+	modTime time.Time
+	size    int64
+	current Credentials
+}
+
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+func (file *File) Credentials(context context.Context) (Credentials, error) {
+	info, err := os.Stat(file.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	file.mutex.Lock()
+	defer file.mutex.Unlock()
+	if info.ModTime().Equal(file.modTime) && info.Size() == file.size && file.current.SecretKey != "" {
+		return file.current, nil
+	}
+	data, err := os.ReadFile(file.path)
+	if err != nil {
+		return Credentials{}, err
+	}
+	credentials, err := parseFile(data)
+	if err != nil {
+		return Credentials{}, fmt.Errorf("%s: %w", file.path, err)
+	}
+	file.current, file.modTime, file.size = credentials, info.ModTime(), info.Size()
+	return credentials, nil
+}
+
+func parseFile(data []byte) (Credentials, error) {
+	content := strings.TrimSpace(string(data))
+	var credentials Credentials
+	if strings.HasPrefix(content, "{") {
+		if err := json.Unmarshal([]byte(content), &credentials); err != nil {
+			return Credentials{}, err
+		}
+	} else {
+		credentials.SecretKey = content
+	}
+	if credentials.SecretKey == "" {
+		return Credentials{}, errors.New("no secret key")
+	}
+	return credentials, nil
+}
+
+// Rotating holds credentials that can be swapped while requests are in flight.
+type Rotating struct {
+	current atomic.Pointer[Credentials]
+}
+
+func NewRotating(initial Credentials) *Rotating {
+	rotating := &Rotating{}
+	rotating.Set(initial)
+	return rotating
+}
+
+func (rotating *Rotating) Set(credentials Credentials) {
+	rotating.current.Store(&credentials)
+}
+
+// SetSecretKey swaps the secret key, and keeps the workspace key.
+func (rotating *Rotating) SetSecretKey(secretKey string) {
+	for {
+		current := rotating.current.Load()
+		credentials := *current
+		credentials.SecretKey = secretKey
+		if rotating.current.CompareAndSwap(current, &credentials) {
+			return
+		}
+	}
+}
+
+func (rotating *Rotating) Credentials(context context.Context) (Credentials, error) {
+	return *rotating.current.Load(), nil
+}
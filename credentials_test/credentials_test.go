@@ -0,0 +1,68 @@
+package credentials_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/credentials"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvironment(t *testing.T) {
+	t.Setenv("SEATSIO_SECRET_KEY", "secretKey")
+	t.Setenv("SEATSIO_WORKSPACE_KEY", "workspaceKey")
+
+	current, err := credentials.Environment{}.Credentials(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Equal(t, credentials.Credentials{SecretKey: "secretKey", WorkspaceKey: "workspaceKey"}, current)
+}
+
+func TestEnvironmentWithoutSecretKey(t *testing.T) {
+	t.Setenv("MY_SECRET_KEY", "")
+
+	_, err := credentials.Environment{SecretKeyVariable: "MY_SECRET_KEY"}.Credentials(test_util.RequestContext())
+
+	require.EqualError(t, err, "MY_SECRET_KEY is not set")
+}
+
+func TestFileIsReloadedWhenItChanges(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "seatsio")
+	require.NoError(t, os.WriteFile(path, []byte("key1\n"), 0600))
+	file := credentials.NewFile(path)
+
+	current, err := file.Credentials(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Equal(t, credentials.Credentials{SecretKey: "key1"}, current)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"secretKey": "key2", "workspaceKey": "ws1"}`), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+	current, err = file.Credentials(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Equal(t, credentials.Credentials{SecretKey: "key2", WorkspaceKey: "ws1"}, current)
+}
+
+func TestFileWithoutSecretKey(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "seatsio")
+	require.NoError(t, os.WriteFile(path, []byte(`{"workspaceKey": "ws1"}`), 0600))
+
+	_, err := credentials.NewFile(path).Credentials(test_util.RequestContext())
+
+	require.EqualError(t, err, path+": no secret key")
+}
+
+func TestRotatingKeepsTheWorkspaceKey(t *testing.T) {
+	t.Parallel()
+	rotating := credentials.NewRotating(credentials.Credentials{SecretKey: "key1", WorkspaceKey: "ws1"})
+
+	rotating.SetSecretKey("key2")
+
+	current, err := rotating.Credentials(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Equal(t, credentials.Credentials{SecretKey: "key2", WorkspaceKey: "ws1"}, current)
+}
@@ -0,0 +1,525 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+const envelopePrefix = "seatsio-enc:v1:"
+
+// maxDataKeys bounds the cache of unwrapped data keys.
+const maxDataKeys = 1024
+
+// Encryptor encrypts fields of object extra data before they leave the process, with envelope encryption: every
+// request gets a fresh data key, which encrypts the values and is itself wrapped by the KeyProvider. Encrypted values
+// are stored as strings that hold the wrapped data key, the id of the key that wrapped it and the ciphertext.
+//
+// A value is bound to its field and, when the request names them, to its event and object: it cannot be decrypted
+// under another field, and a response that returns it for another event or object fails to decrypt.
+type Encryptor struct {
+	Keys KeyProvider
+	// Fields lists the extra data fields that are encrypted, by their JSON name. TaggedFields lists the fields of a
+	// struct that are tagged for encryption.
+	Fields []string
+	// Cipher defaults to AESGCM.
+	Cipher Cipher
+
+	mutex    sync.Mutex
+	dataKeys map[string][]byte
+}
+
+type envelope struct {
+	KeyId      string `json:"k"`
+	Cipher     string `json:"c"`
+	WrappedKey []byte `json:"w"`
+	Ciphertext []byte `json:"d"`
+	EventKey   string `json:"e,omitempty"`
+	ObjectId   string `json:"o,omitempty"`
+}
+
+// binding is the event and object a value belongs to. Either can be unknown, e.g. the object of a best available
+// request, or the event of a status change for several events.
+type binding struct {
+	eventKey string
+	objectId string
+}
+
+// additionalData authenticates the field, and the event and object if they're known. Values without event and object
+// only authenticate the field, like the values that were encrypted before they were bound.
+func additionalData(field string, binding binding) []byte {
+	if binding.eventKey == "" && binding.objectId == "" {
+		return []byte(field)
+	}
+	return []byte(field + "\x00" + binding.eventKey + "\x00" + binding.objectId)
+}
+
+// check fails if a value that was bound to an event or object is found where another one is expected.
+func (binding binding) check(field string, expected binding) error {
+	if binding.eventKey != "" && expected.eventKey != "" && binding.eventKey != expected.eventKey {
+		return fmt.Errorf("%s was encrypted for event %s, not %s", field, binding.eventKey, expected.eventKey)
+	}
+	if binding.objectId != "" && expected.objectId != "" && binding.objectId != expected.objectId {
+		return fmt.Errorf("%s was encrypted for object %s, not %s", field, binding.objectId, expected.objectId)
+	}
+	return nil
+}
+
+// sealer encrypts the values of one request or extra data with the same data key.
+type sealer struct {
+	encryptor  *Encryptor
+	keyId      string
+	wrappedKey []byte
+	dataKey    []byte
+}
+
+type rawContextKey struct{}
+
+// Raw returns a context for requests whose responses are not decrypted.
+func Raw(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rawContextKey{}, true)
+}
+
+// TaggedFields returns the JSON names of the fields of T that are tagged with `extradata:"encrypt"`.
+func TaggedFields[T any]() []string {
+	var fields []string
+	structType := reflect.TypeFor[T]()
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if field.Tag.Get("extradata") != "encrypt" {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// Install makes the client encrypt the configured extra data fields in the requests it sends, and decrypt encrypted
+// values in the responses it receives. Both happen in a round trip wrapper, so a response body transformer that is
+// set on the client keeps working; it sees the encrypted values.
+func (encryptor *Encryptor) Install(client *seatsio.SeatsioClient) {
+	client.Events.Client.WrapRoundTripFunc(func(roundTripper req.RoundTripper) req.RoundTripFunc {
+		return func(request *req.Request) (*req.Response, error) {
+			if err := encryptor.encryptRequest(request); err != nil {
+				return &req.Response{Request: request, Err: err}, err
+			}
+			response, err := roundTripper.RoundTrip(request)
+			if err != nil || request.Context().Value(rawContextKey{}) != nil || !strings.Contains(response.GetContentType(), "json") {
+				return response, err
+			}
+			if err := encryptor.decryptResponse(request, response); err != nil {
+				response.Err = err
+				return response, err
+			}
+			return response, nil
+		}
+	})
+}
+
+// decryptResponse replaces the body of a response, which has been read by the time the round trip returns, before it
+// is unmarshalled.
+func (encryptor *Encryptor) decryptResponse(request *req.Request, response *req.Response) error {
+	body, err := response.ToBytes()
+	if err != nil {
+		return err
+	}
+	decrypted, err := encryptor.decryptJson(request.Context(), request.URL, body)
+	if err != nil {
+		return err
+	}
+	response.SetBody(decrypted)
+	return nil
+}
+
+func (encryptor *Encryptor) cipher() Cipher {
+	if encryptor.Cipher == nil {
+		return AESGCM{}
+	}
+	return encryptor.Cipher
+}
+
+func (encryptor *Encryptor) newSealer(ctx context.Context) (*sealer, error) {
+	keyId, err := encryptor.Keys.CurrentKeyId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	dataKey := make([]byte, encryptor.cipher().KeySize())
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrappedKey, err := encryptor.Keys.WrapKey(ctx, keyId, dataKey)
+	if err != nil {
+		return nil, fmt.Errorf("wrapping data key with key %s: %w", keyId, err)
+	}
+	return &sealer{encryptor: encryptor, keyId: keyId, wrappedKey: wrappedKey, dataKey: dataKey}, nil
+}
+
+func (sealer *sealer) seal(field string, binding binding, value any) (string, error) {
+	plaintext, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	ciphertext, err := sealer.encryptor.cipher().Seal(sealer.dataKey, plaintext, additionalData(field, binding))
+	if err != nil {
+		return "", err
+	}
+	encoded, err := json.Marshal(envelope{
+		KeyId:      sealer.keyId,
+		Cipher:     sealer.encryptor.cipher().Name(),
+		WrappedKey: sealer.wrappedKey,
+		Ciphertext: ciphertext,
+		EventKey:   binding.eventKey,
+		ObjectId:   binding.objectId,
+	})
+	if err != nil {
+		return "", err
+	}
+	return envelopePrefix + base64.RawURLEncoding.EncodeToString(encoded), nil
+}
+
+// IsEncrypted reports whether an extra data value was encrypted by an Encryptor.
+func IsEncrypted(value any) bool {
+	text, ok := value.(string)
+	return ok && strings.HasPrefix(text, envelopePrefix)
+}
+
+// KeyId returns the id of the key that an encrypted value was encrypted with.
+func KeyId(value any) (string, error) {
+	parsed, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+	return parsed.KeyId, nil
+}
+
+func parseEnvelope(value any) (*envelope, error) {
+	if !IsEncrypted(value) {
+		return nil, fmt.Errorf("not an encrypted value")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(value.(string), envelopePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	var parsed envelope
+	if err := json.Unmarshal(decoded, &parsed); err != nil {
+		return nil, fmt.Errorf("malformed encrypted value: %w", err)
+	}
+	return &parsed, nil
+}
+
+// open decrypts a value that was found where the expected event and object are, as far as they're known.
+func (encryptor *Encryptor) open(ctx context.Context, field string, expected binding, value any) (any, error) {
+	parsed, err := parseEnvelope(value)
+	if err != nil {
+		return nil, err
+	}
+	bound := binding{eventKey: parsed.EventKey, objectId: parsed.ObjectId}
+	if err := bound.check(field, expected); err != nil {
+		return nil, err
+	}
+	if parsed.Cipher != encryptor.cipher().Name() {
+		return nil, fmt.Errorf("%s was encrypted with %s instead of %s", field, parsed.Cipher, encryptor.cipher().Name())
+	}
+	dataKey, err := encryptor.unwrap(ctx, parsed.KeyId, parsed.WrappedKey)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := encryptor.cipher().Open(dataKey, parsed.Ciphertext, additionalData(field, bound))
+	if err != nil {
+		return nil, fmt.Errorf("decrypting %s: %w", field, err)
+	}
+	var decrypted any
+	if err := json.Unmarshal(plaintext, &decrypted); err != nil {
+		return nil, err
+	}
+	return decrypted, nil
+}
+
+func (encryptor *Encryptor) unwrap(ctx context.Context, keyId string, wrappedKey []byte) ([]byte, error) {
+	cacheKey := keyId + "/" + string(wrappedKey)
+	encryptor.mutex.Lock()
+	dataKey, ok := encryptor.dataKeys[cacheKey]
+	encryptor.mutex.Unlock()
+	if ok {
+		return dataKey, nil
+	}
+	dataKey, err := encryptor.Keys.UnwrapKey(ctx, keyId, wrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrapping data key with key %s: %w", keyId, err)
+	}
+	encryptor.mutex.Lock()
+	defer encryptor.mutex.Unlock()
+	if encryptor.dataKeys == nil || len(encryptor.dataKeys) >= maxDataKeys {
+		encryptor.dataKeys = map[string][]byte{}
+	}
+	encryptor.dataKeys[cacheKey] = dataKey
+	return dataKey, nil
+}
+
+// EncryptExtraData returns a copy of the extra data with the configured fields encrypted. Values that are already
+// encrypted are left as they are.
+func (encryptor *Encryptor) EncryptExtraData(ctx context.Context, extraData events.ExtraData) (events.ExtraData, error) {
+	return encryptor.EncryptObjectExtraData(ctx, "", "", extraData)
+}
+
+// EncryptObjectExtraData is EncryptExtraData for the extra data of an object of an event. The encrypted values can
+// only be decrypted as the extra data of that object.
+func (encryptor *Encryptor) EncryptObjectExtraData(ctx context.Context, eventKey string, objectId string, extraData events.ExtraData) (events.ExtraData, error) {
+	sealer, err := encryptor.newSealer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return sealer.encrypt(extraData, binding{eventKey: eventKey, objectId: objectId})
+}
+
+func (sealer *sealer) encrypt(extraData map[string]any, binding binding) (map[string]any, error) {
+	if extraData == nil {
+		return nil, nil
+	}
+	encrypted := make(map[string]any, len(extraData))
+	for field, value := range extraData {
+		encrypted[field] = value
+		if !sealer.encryptor.isEncryptedField(field) || IsEncrypted(value) {
+			continue
+		}
+		sealed, err := sealer.seal(field, binding, value)
+		if err != nil {
+			return nil, err
+		}
+		encrypted[field] = sealed
+	}
+	return encrypted, nil
+}
+
+// DecryptExtraData returns a copy of the extra data with all encrypted values decrypted.
+func (encryptor *Encryptor) DecryptExtraData(ctx context.Context, extraData events.ExtraData) (events.ExtraData, error) {
+	return encryptor.DecryptObjectExtraData(ctx, "", "", extraData)
+}
+
+// DecryptObjectExtraData is DecryptExtraData for the extra data of an object of an event. It fails if a value was
+// encrypted for another event or object.
+func (encryptor *Encryptor) DecryptObjectExtraData(ctx context.Context, eventKey string, objectId string, extraData events.ExtraData) (events.ExtraData, error) {
+	decrypted, err := encryptor.decrypt(ctx, "", binding{eventKey: eventKey, objectId: objectId}, map[string]any(extraData), true)
+	if err != nil {
+		return nil, err
+	}
+	extraData, _ = decrypted.(map[string]any)
+	return extraData, nil
+}
+
+func (encryptor *Encryptor) isEncryptedField(field string) bool {
+	for _, encryptedField := range encryptor.Fields {
+		if encryptedField == field {
+			return true
+		}
+	}
+	return false
+}
+
+// encryptRequest encrypts the extra data in a JSON request body: every extraData object, or array of objects, and the
+// extra data per object of update-extra-data requests. The values are bound to the event in the path, or the single
+// event in the body, and to the object whose id is next to the extra data.
+func (encryptor *Encryptor) encryptRequest(request *req.Request) error {
+	body := bytes.TrimSpace(request.Body)
+	if len(encryptor.Fields) == 0 || len(body) == 0 || (body[0] != '{' && body[0] != '[') || !bytes.Contains(body, []byte(`"extraData"`)) {
+		return nil
+	}
+	document, err := decodeJson(body)
+	if err != nil {
+		return nil
+	}
+	sealer, err := encryptor.newSealer(request.Context())
+	if err != nil {
+		return err
+	}
+	perObject := request.URL != nil && strings.HasSuffix(request.URL.Path, "/actions/update-extra-data")
+	if err := sealer.encryptTree(document, binding{eventKey: eventKeyOf(request.URL)}, perObject); err != nil {
+		return err
+	}
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return err
+	}
+	request.SetBodyBytes(encoded)
+	return nil
+}
+
+func (sealer *sealer) encryptTree(node any, binding binding, perObject bool) error {
+	switch typed := node.(type) {
+	case map[string]any:
+		binding = bindingOf(typed, binding, "objectId")
+		for key, value := range typed {
+			if key != "extraData" {
+				if err := sealer.encryptTree(value, binding, perObject); err != nil {
+					return err
+				}
+				continue
+			}
+			encrypted, err := sealer.encryptExtraDataNode(value, binding, perObject)
+			if err != nil {
+				return err
+			}
+			typed[key] = encrypted
+		}
+	case []any:
+		for _, item := range typed {
+			if err := sealer.encryptTree(item, binding, perObject); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (sealer *sealer) encryptExtraDataNode(node any, binding binding, perObject bool) (any, error) {
+	switch typed := node.(type) {
+	case map[string]any:
+		if !perObject {
+			return sealer.encrypt(typed, binding)
+		}
+		for objectId, extraData := range typed {
+			if objectExtraData, ok := extraData.(map[string]any); ok {
+				binding.objectId = objectId
+				encrypted, err := sealer.encrypt(objectExtraData, binding)
+				if err != nil {
+					return nil, err
+				}
+				typed[objectId] = encrypted
+			}
+		}
+	case []any:
+		for i, item := range typed {
+			if extraData, ok := item.(map[string]any); ok {
+				encrypted, err := sealer.encrypt(extraData, binding)
+				if err != nil {
+					return nil, err
+				}
+				typed[i] = encrypted
+			}
+		}
+	}
+	return node, nil
+}
+
+// eventKeyOf returns the event key in a path like /events/{key}/... or /reports/events/{key}/...
+func eventKeyOf(requestUrl *url.URL) string {
+	if requestUrl == nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(requestUrl.EscapedPath(), "/"), "/")
+	if len(segments) >= 2 && segments[0] == "reports" {
+		segments = segments[1:]
+	}
+	if len(segments) < 2 || segments[0] != "events" || segments[1] == "groups" || segments[1] == "actions" {
+		return ""
+	}
+	eventKey, err := url.PathUnescape(segments[1])
+	if err != nil {
+		return ""
+	}
+	return eventKey
+}
+
+// bindingOf narrows the binding down to the event and object that a JSON object names: its event, its only event or,
+// next to extra data, the object under objectIdKey. Objects that don't name them keep the binding of their parent.
+func bindingOf(node map[string]any, parent binding, objectIdKeys ...string) binding {
+	binding := parent
+	if eventKey, ok := node["event"].(string); ok {
+		binding.eventKey = eventKey
+	}
+	if eventKeys, ok := node["events"].([]any); ok && len(eventKeys) == 1 {
+		if eventKey, ok := eventKeys[0].(string); ok {
+			binding.eventKey = eventKey
+		}
+	}
+	if _, ok := node["extraData"]; ok {
+		for _, key := range objectIdKeys {
+			if objectId, ok := node[key].(string); ok {
+				binding.objectId = objectId
+			}
+		}
+	}
+	return binding
+}
+
+func (encryptor *Encryptor) decryptJson(ctx context.Context, requestUrl *url.URL, body []byte) ([]byte, error) {
+	if !bytes.Contains(body, []byte(envelopePrefix)) {
+		return body, nil
+	}
+	document, err := decodeJson(body)
+	if err != nil {
+		return body, nil
+	}
+	decrypted, err := encryptor.decrypt(ctx, "", binding{eventKey: eventKeyOf(requestUrl)}, document, false)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(decrypted)
+}
+
+// decrypt decrypts every encrypted value in the tree. The field of a value is the key it is stored under, and it is
+// expected to be bound to the object whose label is next to the extra data. Only the API objects that hold extra
+// data narrow the binding down; the keys of the extra data itself belong to the caller and never do.
+func (encryptor *Encryptor) decrypt(ctx context.Context, field string, expected binding, node any, inExtraData bool) (any, error) {
+	switch typed := node.(type) {
+	case map[string]any:
+		if _, ok := typed["extraData"]; ok && !inExtraData {
+			expected = bindingOf(typed, expected, "label", "objectLabel")
+		}
+		decrypted := make(map[string]any, len(typed))
+		for key, value := range typed {
+			decryptedValue, err := encryptor.decrypt(ctx, key, expected, value, inExtraData || key == "extraData")
+			if err != nil {
+				return nil, err
+			}
+			decrypted[key] = decryptedValue
+		}
+		return decrypted, nil
+	case []any:
+		decrypted := make([]any, len(typed))
+		for i, item := range typed {
+			decryptedItem, err := encryptor.decrypt(ctx, field, expected, item, inExtraData)
+			if err != nil {
+				return nil, err
+			}
+			decrypted[i] = decryptedItem
+		}
+		return decrypted, nil
+	case string:
+		if IsEncrypted(typed) {
+			return encryptor.open(ctx, field, expected, typed)
+		}
+	}
+	return node, nil
+}
+
+func decodeJson(body []byte) (any, error) {
+	var document any
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
@@ -0,0 +1,101 @@
+package encryption
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// KeyProvider wraps and unwraps the data keys that extra data is encrypted with, typically with a key management
+// service. Keys are identified by an id, which is stored with the encrypted values, so that keys can be rotated:
+// values are always encrypted with the current key, and can be decrypted with any key the provider still knows.
+type KeyProvider interface {
+	CurrentKeyId(context context.Context) (string, error)
+	WrapKey(context context.Context, keyId string, dataKey []byte) ([]byte, error)
+	UnwrapKey(context context.Context, keyId string, wrappedKey []byte) ([]byte, error)
+}
+
+// Cipher encrypts values with a data key. The additional data, the name of the field and the event and object the
+// value belongs to, is authenticated but not encrypted.
+type Cipher interface {
+	Name() string
+	KeySize() int
+	Seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, error)
+	Open(key []byte, ciphertext []byte, additionalData []byte) ([]byte, error)
+}
+
+// AESGCM is AES-256 in Galois/Counter Mode, with a random nonce that is prepended to the ciphertext.
+type AESGCM struct{}
+
+func (AESGCM) Name() string {
+	return "AES-GCM"
+}
+
+func (AESGCM) KeySize() int {
+	return 32
+}
+
+func (AESGCM) Seal(key []byte, plaintext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+func (AESGCM) Open(key []byte, ciphertext []byte, additionalData []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	return aead.Open(nil, ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():], additionalData)
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// StaticKeys is a KeyProvider that wraps data keys with AES-GCM, using key encryption keys held in memory. To rotate,
+// add a new key and make it the current one; keep the old key until Rotate has re-encrypted all events.
+type StaticKeys struct {
+	Current string
+	// Keys holds the key encryption keys by id. They must be 16, 24 or 32 bytes long.
+	Keys map[string][]byte
+}
+
+func (keys *StaticKeys) CurrentKeyId(context.Context) (string, error) {
+	if _, ok := keys.Keys[keys.Current]; !ok {
+		return "", fmt.Errorf("unknown current key %s", keys.Current)
+	}
+	return keys.Current, nil
+}
+
+func (keys *StaticKeys) WrapKey(_ context.Context, keyId string, dataKey []byte) ([]byte, error) {
+	key, ok := keys.Keys[keyId]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %s", keyId)
+	}
+	return AESGCM{}.Seal(key, dataKey, []byte(keyId))
+}
+
+func (keys *StaticKeys) UnwrapKey(_ context.Context, keyId string, wrappedKey []byte) ([]byte, error) {
+	key, ok := keys.Keys[keyId]
+	if !ok {
+		return nil, fmt.Errorf("unknown key %s", keyId)
+	}
+	return AESGCM{}.Open(key, wrappedKey, []byte(keyId))
+}
@@ -0,0 +1,72 @@
+package encryption
+
+import (
+	"context"
+	"sort"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// rotationBatchSize is the number of objects whose extra data is updated per request.
+const rotationBatchSize = 100
+
+// Rotate re-encrypts the extra data of the objects of an event that was encrypted with another key than the current
+// one, or that is not bound to its event and object yet, and encrypts configured fields that are still stored in
+// plaintext. It returns the number of objects that were
+// updated. Old keys can be removed from the KeyProvider once all events are rotated.
+func (encryptor *Encryptor) Rotate(ctx context.Context, client *seatsio.SeatsioClient, eventKey string) (int, error) {
+	currentKeyId, err := encryptor.Keys.CurrentKeyId(ctx)
+	if err != nil {
+		return 0, err
+	}
+	report, err := client.EventReports.ByLabel(Raw(ctx), eventKey)
+	if err != nil {
+		return 0, err
+	}
+	var labels []string
+	for label, objects := range report.Items {
+		if len(objects) > 0 && encryptor.needsRotation(objects[0].ExtraData, currentKeyId) {
+			labels = append(labels, label)
+		}
+	}
+	sort.Strings(labels)
+
+	for start := 0; start < len(labels); start += rotationBatchSize {
+		batch := labels[start:min(start+rotationBatchSize, len(labels))]
+		sealer, err := encryptor.newSealer(ctx)
+		if err != nil {
+			return start, err
+		}
+		updates := make(map[string]events.ExtraData, len(batch))
+		for _, label := range batch {
+			decrypted, err := encryptor.DecryptObjectExtraData(ctx, eventKey, label, report.Items[label][0].ExtraData)
+			if err != nil {
+				return start, err
+			}
+			if updates[label], err = sealer.encrypt(decrypted, binding{eventKey: eventKey, objectId: label}); err != nil {
+				return start, err
+			}
+		}
+		if err := client.Events.UpdateExtraData(ctx, eventKey, updates); err != nil {
+			return start, err
+		}
+	}
+	return len(labels), nil
+}
+
+func (encryptor *Encryptor) needsRotation(extraData events.ExtraData, currentKeyId string) bool {
+	for field, value := range extraData {
+		if !IsEncrypted(value) {
+			if encryptor.isEncryptedField(field) {
+				return true
+			}
+			continue
+		}
+		parsed, err := parseEnvelope(value)
+		if err != nil || parsed.KeyId != currentKeyId || parsed.EventKey == "" || parsed.ObjectId == "" {
+			return true
+		}
+	}
+	return false
+}
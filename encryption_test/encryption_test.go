@@ -0,0 +1,196 @@
+package encryption_test
+
+import (
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/encryption"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type testEvent struct {
+	client    *seatsio.SeatsioClient
+	secretKey string
+	eventKey  string
+}
+
+func newTestEvent(t *testing.T) *testEvent {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+	return &testEvent{client: client, secretKey: company.Admin.SecretKey, eventKey: event.Key}
+}
+
+// stored returns the extra data of an object as the API stores it.
+func (event *testEvent) stored(t *testing.T, label string) events.ExtraData {
+	infos, err := event.client.Events.RetrieveObjectInfo(encryption.Raw(test_util.RequestContext()), event.eventKey, label)
+	require.NoError(t, err)
+	return infos[label].ExtraData
+}
+
+type customer struct {
+	Name    string `json:"name" extradata:"encrypt"`
+	Email   string `json:"email,omitempty" extradata:"encrypt"`
+	Section string `json:"section"`
+}
+
+func newKeys() *encryption.StaticKeys {
+	return &encryption.StaticKeys{
+		Current: "key1",
+		Keys:    map[string][]byte{"key1": []byte("0123456789abcdef0123456789abcdef")},
+	}
+}
+
+func TestExtraDataIsEncryptedOnWriteAndDecryptedOnRead(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	var transformed atomic.Int32
+	event.client.Events.Client.SetResponseBodyTransformer(func(body []byte, _ *req.Request, _ *req.Response) ([]byte, error) {
+		transformed.Add(1)
+		return body, nil
+	})
+	encryptor := &encryption.Encryptor{Keys: newKeys(), Fields: encryption.TaggedFields[customer]()}
+	encryptor.Install(event.client)
+	require.Equal(t, []string{"name", "email"}, encryptor.Fields)
+
+	booked, err := events.BookWithExtraData(test_util.RequestContext(), event.client.Events, event.eventKey, map[string]customer{
+		"A-1": {Name: "Ada", Email: "ada@example.com", Section: "Floor"},
+	})
+	require.NoError(t, err)
+	err = event.client.Events.UpdateExtraData(test_util.RequestContext(), event.eventKey, map[string]events.ExtraData{"A-2": {"name": "Grace", "vip": true}})
+	require.NoError(t, err)
+
+	stored := event.stored(t, "A-1")
+	require.True(t, encryption.IsEncrypted(stored["name"]))
+	require.True(t, encryption.IsEncrypted(stored["email"]))
+	require.NotContains(t, stored["name"], "Ada")
+	require.Equal(t, "Floor", stored["section"])
+	require.True(t, encryption.IsEncrypted(event.stored(t, "A-2")["name"]))
+	require.Equal(t, true, event.stored(t, "A-2")["vip"])
+
+	decoded, err := events.DecodeExtraData[customer](booked.Objects["A-1"])
+	require.NoError(t, err)
+	require.Equal(t, customer{Name: "Ada", Email: "ada@example.com", Section: "Floor"}, decoded)
+
+	infos, err := event.client.Events.RetrieveObjectInfo(test_util.RequestContext(), event.eventKey, "A-1", "A-2")
+	require.NoError(t, err)
+	require.Equal(t, "ada@example.com", infos["A-1"].ExtraData["email"])
+	require.Equal(t, events.ExtraData{"name": "Grace", "vip": true}, infos["A-2"].ExtraData)
+
+	report, err := event.client.EventReports.ByLabel(test_util.RequestContext(), event.eventKey)
+	require.NoError(t, err)
+	require.Equal(t, "Grace", report.Items["A-2"][0].ExtraData["name"])
+
+	statusChanges, err := event.client.Events.StatusChanges(test_util.RequestContext(), event.eventKey).All()
+	require.NoError(t, err)
+	require.Equal(t, "A-1", statusChanges[0].ObjectLabel)
+	require.Equal(t, "Ada", statusChanges[0].ExtraData["name"])
+
+	require.Greater(t, transformed.Load(), int32(0))
+}
+
+func TestExtraDataKeysDoNotChangeTheBinding(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	encryptor := &encryption.Encryptor{Keys: newKeys(), Fields: []string{"name"}}
+	encryptor.Install(event.client)
+	extraData := events.ExtraData{"name": "Ada", "event": "another event", "label": "B-1"}
+	require.NoError(t, event.client.Events.UpdateExtraData(test_util.RequestContext(), event.eventKey, map[string]events.ExtraData{"A-1": extraData}))
+
+	infos, err := event.client.Events.RetrieveObjectInfo(test_util.RequestContext(), event.eventKey, "A-1")
+	require.NoError(t, err)
+	require.Equal(t, extraData, infos["A-1"].ExtraData)
+}
+
+func TestExtraDataKeysDoNotChangeTheBindingOfDecryptedObjects(t *testing.T) {
+	t.Parallel()
+	encryptor := &encryption.Encryptor{Keys: newKeys(), Fields: []string{"name"}}
+	extraData := events.ExtraData{"name": "Ada", "event": "another event", "events": []any{"another event"}}
+	encrypted, err := encryptor.EncryptObjectExtraData(test_util.RequestContext(), "event1", "A-1", extraData)
+	require.NoError(t, err)
+
+	decrypted, err := encryptor.DecryptObjectExtraData(test_util.RequestContext(), "event1", "A-1", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, extraData, decrypted)
+}
+
+func TestEncryptedValuesCannotBeMovedToAnotherField(t *testing.T) {
+	t.Parallel()
+	encryptor := &encryption.Encryptor{Keys: newKeys(), Fields: []string{"name", "email"}}
+	encrypted, err := encryptor.EncryptExtraData(test_util.RequestContext(), events.ExtraData{"name": "Ada"})
+	require.NoError(t, err)
+
+	_, err = encryptor.DecryptExtraData(test_util.RequestContext(), events.ExtraData{"email": encrypted["name"]})
+	require.Error(t, err)
+}
+
+func TestEncryptedValuesCannotBeMovedToAnotherObject(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	encryptor := &encryption.Encryptor{Keys: newKeys(), Fields: []string{"name"}}
+	encryptor.Install(event.client)
+	require.NoError(t, event.client.Events.UpdateExtraData(test_util.RequestContext(), event.eventKey, map[string]events.ExtraData{"A-1": {"name": "Ada"}}))
+
+	plainClient := seatsio.NewSeatsioClient(test_util.BaseUrl, event.secretKey)
+	require.NoError(t, plainClient.Events.UpdateExtraData(test_util.RequestContext(), event.eventKey, map[string]events.ExtraData{"A-2": event.stored(t, "A-1")}))
+
+	_, err := event.client.Events.RetrieveObjectInfo(test_util.RequestContext(), event.eventKey, "A-2")
+	require.ErrorContains(t, err, "name was encrypted for object A-1, not A-2")
+}
+
+func TestEncryptedValuesCannotBeMovedToAnotherEvent(t *testing.T) {
+	t.Parallel()
+	encryptor := &encryption.Encryptor{Keys: newKeys(), Fields: []string{"name"}}
+	encrypted, err := encryptor.EncryptObjectExtraData(test_util.RequestContext(), "event1", "A-1", events.ExtraData{"name": "Ada"})
+	require.NoError(t, err)
+
+	decrypted, err := encryptor.DecryptObjectExtraData(test_util.RequestContext(), "event1", "A-1", encrypted)
+	require.NoError(t, err)
+	require.Equal(t, events.ExtraData{"name": "Ada"}, decrypted)
+
+	_, err = encryptor.DecryptObjectExtraData(test_util.RequestContext(), "event2", "A-1", encrypted)
+	require.EqualError(t, err, "name was encrypted for event event1, not event2")
+}
+
+func TestRotate(t *testing.T) {
+	t.Parallel()
+	event := newTestEvent(t)
+	keys := newKeys()
+	encryptor := &encryption.Encryptor{Keys: keys, Fields: []string{"name"}}
+	encryptor.Install(event.client)
+	require.NoError(t, event.client.Events.UpdateExtraData(test_util.RequestContext(), event.eventKey, map[string]events.ExtraData{
+		"A-1": {"name": "Ada"},
+		"A-2": {"section": "Floor"},
+	}))
+
+	keys.Keys["key2"] = []byte(strings.Repeat("k", 32))
+	keys.Current = "key2"
+	encryptor.Fields = []string{"name", "section"}
+	rotated, err := encryptor.Rotate(test_util.RequestContext(), event.client, event.eventKey)
+	require.NoError(t, err)
+	require.Equal(t, 2, rotated)
+
+	for _, label := range []string{"A-1", "A-2"} {
+		for _, value := range event.stored(t, label) {
+			keyId, err := encryption.KeyId(value)
+			require.NoError(t, err)
+			require.Equal(t, "key2", keyId)
+		}
+	}
+	delete(keys.Keys, "key1")
+	infos, err := event.client.Events.RetrieveObjectInfo(test_util.RequestContext(), event.eventKey, "A-1", "A-2")
+	require.NoError(t, err)
+	require.Equal(t, "Ada", infos["A-1"].ExtraData["name"])
+	require.Equal(t, "Floor", infos["A-2"].ExtraData["section"])
+
+	rotated, err = encryptor.Rotate(test_util.RequestContext(), event.client, event.eventKey)
+	require.NoError(t, err)
+	require.Equal(t, 0, rotated)
+}
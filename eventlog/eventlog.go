@@ -14,6 +14,10 @@ func (eventLog EventLog) ListAll(context context.Context, opts ...shared.Paginat
 	return eventLog.lister(context).All(opts...)
 }
 
+func (eventLog EventLog) List(context context.Context) *shared.Lister[EventLogItem] {
+	return eventLog.lister(context)
+}
+
 func (eventLog EventLog) ListFirstPage(context context.Context, opts ...shared.PaginationParamsOption) (*shared.Page[EventLogItem], error) {
 	return eventLog.lister(context).ListFirstPage(opts...)
 }
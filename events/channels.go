@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/imroc/req/v3"
 	"github.com/seatsio/seatsio-go/v12/shared"
@@ -29,6 +30,19 @@ type UpdateChannelParams struct {
 	AreaPlaces map[string]int `json:"areaPlaces,omitempty"`
 }
 
+// MarshalJSON sends AreaPlaces whenever it is not nil, so that an empty map removes all area places of the channel.
+func (params UpdateChannelParams) MarshalJSON() ([]byte, error) {
+	type plainParams UpdateChannelParams
+	var areaPlaces *map[string]int
+	if params.AreaPlaces != nil {
+		areaPlaces = &params.AreaPlaces
+	}
+	return json.Marshal(struct {
+		plainParams
+		AreaPlaces *map[string]int `json:"areaPlaces,omitempty"`
+	}{plainParams(params), areaPlaces})
+}
+
 type changeChannelObjectsRequest struct {
 	Objects    []string       `json:"objects,omitempty"`
 	AreaPlaces map[string]int `json:"areaPlaces,omitempty"`
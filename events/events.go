@@ -2,6 +2,7 @@ package events
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/imroc/req/v3"
@@ -9,7 +10,9 @@ import (
 )
 
 type Events struct {
-	Client *req.Client
+	Client          *req.Client
+	Statuses        *StatusRegistry
+	ExtraDataSchema *ExtraDataSchema
 }
 
 type EventParams struct {
@@ -205,10 +208,15 @@ func (events *Events) ChangeObjectStatus(context context.Context, eventKeys []st
 }
 
 func (events *Events) ChangeObjectStatusWithOptions(context context.Context, statusChangeparams *StatusChangeParams) (*ChangeObjectStatusResult, error) {
+	// the status registry fills in the allowed previous statuses, on a copy so that the caller's params are untouched
+	params := *statusChangeparams
+	if err := events.checkStatusChanges(&params.StatusChanges); err != nil {
+		return nil, err
+	}
 	var changeObjectStatusResult ChangeObjectStatusResult
 	result, err := events.Client.R().
 		SetContext(context).
-		SetBody(statusChangeparams).
+		SetBody(&params).
 		SetQueryParam("expand", "objects").
 		SetSuccessResult(&changeObjectStatusResult).
 		Post("/events/groups/actions/change-object-status")
@@ -216,11 +224,17 @@ func (events *Events) ChangeObjectStatusWithOptions(context context.Context, sta
 }
 
 func (events *Events) ChangeObjectStatusInBatch(context context.Context, statusChangeInBatchParams ...StatusChangeInBatchParams) (*ChangeObjectStatusInBatchResult, error) {
+	params := slices.Clone(statusChangeInBatchParams)
+	for i := range params {
+		if err := events.checkStatusChanges(&params[i].StatusChanges); err != nil {
+			return nil, err
+		}
+	}
 	var changeObjectStatusInBatchResult ChangeObjectStatusInBatchResult
 	result, err := events.Client.R().
 		SetContext(context).
 		SetBody(&StatusChangeInBatchRequest{
-			StatusChanges: statusChangeInBatchParams,
+			StatusChanges: params,
 		}).
 		SetQueryParam("expand", "objects").
 		SetSuccessResult(&changeObjectStatusInBatchResult).
@@ -229,6 +243,9 @@ func (events *Events) ChangeObjectStatusInBatch(context context.Context, statusC
 }
 
 func (events *Events) ChangeBestAvailableObjectStatus(context context.Context, eventKey string, bestAvailableStatusChangeParams *BestAvailableStatusChangeParams) (*BestAvailableResult, error) {
+	if err := events.checkBestAvailable(bestAvailableStatusChangeParams); err != nil {
+		return nil, err
+	}
 	var bestAvailableResult BestAvailableResult
 	result, err := events.Client.R().
 		SetContext(context).
@@ -266,6 +283,11 @@ func (events *Events) UseSeasonObjectStatus(context context.Context, eventKey st
 }
 
 func (events *Events) UpdateExtraData(context context.Context, eventKey string, extraData map[string]ExtraData) error {
+	for objectId, objectExtraData := range extraData {
+		if err := events.validateExtraData(objectId, objectExtraData); err != nil {
+			return err
+		}
+	}
 	result, err := events.Client.R().
 		SetContext(context).
 		SetBody(&UpdateExtraDataRequest{
@@ -502,6 +524,41 @@ func (events *Events) changeStatus(context context.Context, status string, event
 	return events.ChangeObjectStatusWithOptions(context, &params)
 }
 
+// checkStatusChanges validates a status change against the status registry and the extra data schema, if set,
+// before it is sent.
+func (events *Events) checkStatusChanges(statusChanges *StatusChanges) error {
+	if events.Statuses != nil {
+		if err := events.Statuses.Apply(statusChanges); err != nil {
+			return err
+		}
+	}
+	for _, object := range statusChanges.Objects {
+		if err := events.validateExtraData(object.ObjectId, object.ExtraData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (events *Events) checkBestAvailable(params *BestAvailableStatusChangeParams) error {
+	if events.Statuses != nil {
+		status := ObjectStatus(params.Status)
+		if err := events.Statuses.check(status); err != nil {
+			return err
+		}
+		// best available only ever picks free objects
+		if !events.Statuses.CanTransition(FREE, status) {
+			return &IllegalTransitionError{From: FREE, To: status}
+		}
+	}
+	for _, extraData := range params.BestAvailable.ExtraData {
+		if err := events.validateExtraData("", extraData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (events *Events) toObjectProperties(objects []string) []ObjectProperties {
 	objectProperties := make([]ObjectProperties, len(objects))
 	for i, object := range objects {
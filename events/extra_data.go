@@ -0,0 +1,84 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+// ToExtraData converts a value to extra data through its JSON representation, so its JSON tags decide the keys.
+func ToExtraData[T any](value T) (ExtraData, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var extraData ExtraData
+	if err := json.Unmarshal(encoded, &extraData); err != nil {
+		return nil, err
+	}
+	return extraData, nil
+}
+
+// FromExtraData converts extra data to a value of type T, the inverse of ToExtraData.
+func FromExtraData[T any](extraData ExtraData) (T, error) {
+	var value T
+	if extraData == nil {
+		return value, nil
+	}
+	encoded, err := json.Marshal(extraData)
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(encoded, &value); err != nil {
+		return value, err
+	}
+	return value, nil
+}
+
+func DecodeExtraData[T any](info EventObjectInfo) (T, error) {
+	return FromExtraData[T](info.ExtraData)
+}
+
+func DecodeStatusChangeExtraData[T any](statusChange StatusChange) (T, error) {
+	return FromExtraData[T](statusChange.ExtraData)
+}
+
+// ObjectPropertiesWithExtraData returns object properties for the given objects, ordered by object id, with their
+// extra data converted by ToExtraData.
+func ObjectPropertiesWithExtraData[T any](extraData map[string]T) ([]ObjectProperties, error) {
+	objectIds := make([]string, 0, len(extraData))
+	for objectId := range extraData {
+		objectIds = append(objectIds, objectId)
+	}
+	sort.Strings(objectIds)
+	objectProperties := make([]ObjectProperties, len(objectIds))
+	for i, objectId := range objectIds {
+		converted, err := ToExtraData(extraData[objectId])
+		if err != nil {
+			return nil, err
+		}
+		objectProperties[i] = ObjectProperties{ObjectId: objectId, ExtraData: converted}
+	}
+	return objectProperties, nil
+}
+
+func UpdateExtraDataTyped[T any](context context.Context, events *Events, eventKey string, extraData map[string]T) error {
+	converted := make(map[string]ExtraData, len(extraData))
+	for objectId, value := range extraData {
+		objectExtraData, err := ToExtraData(value)
+		if err != nil {
+			return err
+		}
+		converted[objectId] = objectExtraData
+	}
+	return events.UpdateExtraData(context, eventKey, converted)
+}
+
+// BookWithExtraData books the given objects, each with its own extra data.
+func BookWithExtraData[T any](context context.Context, events *Events, eventKey string, extraData map[string]T) (*ChangeObjectStatusResult, error) {
+	objectProperties, err := ObjectPropertiesWithExtraData(extraData)
+	if err != nil {
+		return nil, err
+	}
+	return events.BookWithObjectProperties(context, eventKey, objectProperties...)
+}
@@ -0,0 +1,314 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExtraDataSchema is a JSON Schema that extra data must match. When it's set on Events, extra data is validated
+// before it is sent, and requests with invalid extra data fail with an *ExtraDataValidationError.
+//
+// The keywords type, enum, const, properties, required, additionalProperties, items, minItems, maxItems, minimum,
+// maximum, exclusiveMinimum, exclusiveMaximum, multipleOf, minLength, maxLength and pattern are supported, as well as
+// annotations like title and description. Schemas with other keywords are rejected, rather than silently validating
+// less than they say.
+type ExtraDataSchema struct {
+	rejectAll            bool
+	types                []string
+	enum                 []any
+	constant             *any
+	properties           map[string]*ExtraDataSchema
+	required             []string
+	additionalProperties *ExtraDataSchema
+	items                *ExtraDataSchema
+	minItems             *int
+	maxItems             *int
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	multipleOf           *float64
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+}
+
+type ExtraDataValidationError struct {
+	// ObjectId is empty for the extra data of best available requests.
+	ObjectId string
+	Problems []string
+}
+
+func (err *ExtraDataValidationError) Error() string {
+	if err.ObjectId == "" {
+		return "invalid extra data: " + strings.Join(err.Problems, "; ")
+	}
+	return fmt.Sprintf("invalid extra data for %s: %s", err.ObjectId, strings.Join(err.Problems, "; "))
+}
+
+var supportedKeywords = []string{
+	"type", "enum", "const", "properties", "required", "additionalProperties", "items", "minItems", "maxItems",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf", "minLength", "maxLength", "pattern",
+	"$schema", "$id", "$comment", "title", "description", "default", "examples", "deprecated", "readOnly", "writeOnly",
+}
+
+func ParseExtraDataSchema(schema []byte) (*ExtraDataSchema, error) {
+	var parsed ExtraDataSchema
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return nil, fmt.Errorf("invalid extra data schema: %w", err)
+	}
+	return &parsed, nil
+}
+
+func (schema *ExtraDataSchema) UnmarshalJSON(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if string(trimmed) == "true" {
+		return nil
+	}
+	if string(trimmed) == "false" {
+		schema.rejectAll = true
+		return nil
+	}
+	var raw struct {
+		Type                 json.RawMessage             `json:"type"`
+		Enum                 []any                       `json:"enum"`
+		Const                json.RawMessage             `json:"const"`
+		Properties           map[string]*ExtraDataSchema `json:"properties"`
+		Required             []string                    `json:"required"`
+		AdditionalProperties *ExtraDataSchema            `json:"additionalProperties"`
+		Items                *ExtraDataSchema            `json:"items"`
+		MinItems             *int                        `json:"minItems"`
+		MaxItems             *int                        `json:"maxItems"`
+		Minimum              *float64                    `json:"minimum"`
+		Maximum              *float64                    `json:"maximum"`
+		ExclusiveMinimum     *float64                    `json:"exclusiveMinimum"`
+		ExclusiveMaximum     *float64                    `json:"exclusiveMaximum"`
+		MultipleOf           *float64                    `json:"multipleOf"`
+		MinLength            *int                        `json:"minLength"`
+		MaxLength            *int                        `json:"maxLength"`
+		Pattern              *string                     `json:"pattern"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return err
+	}
+	for keyword := range keywords {
+		if !slices.Contains(supportedKeywords, keyword) {
+			return fmt.Errorf("unsupported keyword %s", keyword)
+		}
+	}
+	if len(raw.Type) > 0 {
+		if raw.Type[0] == '[' {
+			if err := json.Unmarshal(raw.Type, &schema.types); err != nil {
+				return err
+			}
+		} else {
+			var single string
+			if err := json.Unmarshal(raw.Type, &single); err != nil {
+				return err
+			}
+			schema.types = []string{single}
+		}
+	}
+	if len(raw.Const) > 0 {
+		var constant any
+		if err := json.Unmarshal(raw.Const, &constant); err != nil {
+			return err
+		}
+		schema.constant = &constant
+	}
+	if raw.Pattern != nil {
+		pattern, err := regexp.Compile(*raw.Pattern)
+		if err != nil {
+			return err
+		}
+		schema.pattern = pattern
+	}
+	schema.enum = raw.Enum
+	schema.properties = raw.Properties
+	schema.required = raw.Required
+	schema.additionalProperties = raw.AdditionalProperties
+	schema.items = raw.Items
+	schema.minItems = raw.MinItems
+	schema.maxItems = raw.MaxItems
+	schema.minimum = raw.Minimum
+	schema.maximum = raw.Maximum
+	schema.exclusiveMinimum = raw.ExclusiveMinimum
+	schema.exclusiveMaximum = raw.ExclusiveMaximum
+	schema.multipleOf = raw.MultipleOf
+	schema.minLength = raw.MinLength
+	schema.maxLength = raw.MaxLength
+	return nil
+}
+
+// Validate returns the problems with the given value, which can be extra data or any value that marshals to JSON.
+func (schema *ExtraDataSchema) Validate(value any) ([]string, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var decoded any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		return nil, err
+	}
+	var problems []string
+	schema.validate("", decoded, &problems)
+	return problems, nil
+}
+
+func (schema *ExtraDataSchema) validate(path string, value any, problems *[]string) {
+	problem := func(format string, args ...any) {
+		location := path
+		if location == "" {
+			location = "/"
+		}
+		*problems = append(*problems, location+": "+fmt.Sprintf(format, args...))
+	}
+	if schema.rejectAll {
+		problem("is not allowed")
+		return
+	}
+	if len(schema.types) > 0 && !slices.ContainsFunc(schema.types, func(t string) bool { return hasJsonType(value, t) }) {
+		problem("must be of type %s", strings.Join(schema.types, " or "))
+		return
+	}
+	if schema.enum != nil && !slices.ContainsFunc(schema.enum, func(allowed any) bool { return reflect.DeepEqual(allowed, value) }) {
+		problem("must be one of %v", schema.enum)
+	}
+	if schema.constant != nil && !reflect.DeepEqual(*schema.constant, value) {
+		problem("must be %v", *schema.constant)
+	}
+	switch typed := value.(type) {
+	case map[string]any:
+		schema.validateObject(path, typed, problems, problem)
+	case []any:
+		if schema.minItems != nil && len(typed) < *schema.minItems {
+			problem("must have at least %d items", *schema.minItems)
+		}
+		if schema.maxItems != nil && len(typed) > *schema.maxItems {
+			problem("must have at most %d items", *schema.maxItems)
+		}
+		if schema.items != nil {
+			for i, item := range typed {
+				schema.items.validate(fmt.Sprintf("%s/%d", path, i), item, problems)
+			}
+		}
+	case float64:
+		if schema.minimum != nil && typed < *schema.minimum {
+			problem("must be at least %v", *schema.minimum)
+		}
+		if schema.maximum != nil && typed > *schema.maximum {
+			problem("must be at most %v", *schema.maximum)
+		}
+		if schema.exclusiveMinimum != nil && typed <= *schema.exclusiveMinimum {
+			problem("must be more than %v", *schema.exclusiveMinimum)
+		}
+		if schema.exclusiveMaximum != nil && typed >= *schema.exclusiveMaximum {
+			problem("must be less than %v", *schema.exclusiveMaximum)
+		}
+		if schema.multipleOf != nil && *schema.multipleOf != 0 && !isMultipleOf(typed, *schema.multipleOf) {
+			problem("must be a multiple of %v", *schema.multipleOf)
+		}
+	case string:
+		length := utf8.RuneCountInString(typed)
+		if schema.minLength != nil && length < *schema.minLength {
+			problem("must be at least %d characters long", *schema.minLength)
+		}
+		if schema.maxLength != nil && length > *schema.maxLength {
+			problem("must be at most %d characters long", *schema.maxLength)
+		}
+		if schema.pattern != nil && !schema.pattern.MatchString(typed) {
+			problem("must match %s", schema.pattern)
+		}
+	}
+}
+
+func (schema *ExtraDataSchema) validateObject(path string, object map[string]any, problems *[]string, problem func(string, ...any)) {
+	for _, name := range schema.required {
+		if _, ok := object[name]; !ok {
+			problem("%s is required", name)
+		}
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property, ok := schema.properties[name]; ok {
+			property.validate(path+"/"+name, object[name], problems)
+		} else if schema.additionalProperties != nil {
+			schema.additionalProperties.validate(path+"/"+name, object[name], problems)
+		}
+	}
+}
+
+func hasJsonType(value any, jsonType string) bool {
+	switch jsonType {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		number, ok := value.(float64)
+		return ok && isInteger(number)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+// isMultipleOf divides the decimal values of the numbers, as written in JSON, so that e.g. 0.3 is a multiple of 0.1.
+func isMultipleOf(number float64, divisor float64) bool {
+	decimalNumber, ok := new(big.Rat).SetString(strconv.FormatFloat(number, 'g', -1, 64))
+	if !ok {
+		return false
+	}
+	decimalDivisor, ok := new(big.Rat).SetString(strconv.FormatFloat(divisor, 'g', -1, 64))
+	if !ok {
+		return false
+	}
+	return new(big.Rat).Quo(decimalNumber, decimalDivisor).IsInt()
+}
+
+func isInteger(number float64) bool {
+	return number == math.Trunc(number) && !math.IsInf(number, 0)
+}
+
+func (events *Events) validateExtraData(objectId string, extraData ExtraData) error {
+	if events.ExtraDataSchema == nil || extraData == nil {
+		return nil
+	}
+	problems, err := events.ExtraDataSchema.Validate(extraData)
+	if err != nil {
+		return err
+	}
+	if len(problems) > 0 {
+		return &ExtraDataValidationError{ObjectId: objectId, Problems: problems}
+	}
+	return nil
+}
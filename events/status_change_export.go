@@ -0,0 +1,71 @@
+package events
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+var statusChangeCSVHeader = []string{
+	"id", "eventId", "status", "date", "orderId", "objectLabel", "holdToken",
+	"originType", "originIp", "isPresentOnChart", "notPresentOnChartReason", "extraData",
+}
+
+func ExportStatusChangesToCSV(lister *shared.Lister[StatusChange], writer io.Writer, opts ...shared.PaginationParamsOption) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(statusChangeCSVHeader); err != nil {
+		return err
+	}
+	err := lister.ForEach(func(statusChange StatusChange) error {
+		record, err := statusChangeToCSVRecord(statusChange)
+		if err != nil {
+			return err
+		}
+		return csvWriter.Write(record)
+	}, opts...)
+	if err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func ExportStatusChangesToJSONLines(lister *shared.Lister[StatusChange], writer io.Writer, opts ...shared.PaginationParamsOption) error {
+	encoder := json.NewEncoder(writer)
+	return lister.ForEach(func(statusChange StatusChange) error {
+		return encoder.Encode(statusChange)
+	}, opts...)
+}
+
+func statusChangeToCSVRecord(statusChange StatusChange) ([]string, error) {
+	date := ""
+	if statusChange.Date != nil {
+		date = statusChange.Date.Format(time.RFC3339)
+	}
+	extraData := ""
+	if statusChange.ExtraData != nil {
+		extraDataJson, err := json.Marshal(statusChange.ExtraData)
+		if err != nil {
+			return nil, err
+		}
+		extraData = string(extraDataJson)
+	}
+	return []string{
+		strconv.FormatInt(statusChange.Id, 10),
+		strconv.FormatInt(statusChange.EventId, 10),
+		statusChange.Status,
+		date,
+		statusChange.OrderId,
+		statusChange.ObjectLabel,
+		statusChange.HoldToken,
+		statusChange.Origin.Type,
+		statusChange.Origin.Ip,
+		strconv.FormatBool(statusChange.IsPresentOnChart),
+		statusChange.NotPresentOnChartReason,
+		extraData,
+	}, nil
+}
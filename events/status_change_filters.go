@@ -0,0 +1,74 @@
+package events
+
+import (
+	"slices"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+// The status changes endpoint only supports filtering on object label, so the filters below are applied
+// client-side on every fetched page. Pages can therefore contain fewer items than the requested page size.
+
+func (eventSupportNS) WithStatus(statuses ...string) ListParamsOption {
+	return withStatusChangeFilter(func(statusChange StatusChange) bool {
+		return slices.Contains(statuses, statusChange.Status)
+	})
+}
+
+func (eventSupportNS) WithOrderId(orderIds ...string) ListParamsOption {
+	return withStatusChangeFilter(func(statusChange StatusChange) bool {
+		return slices.Contains(orderIds, statusChange.OrderId)
+	})
+}
+
+func (eventSupportNS) WithHoldToken(holdTokens ...string) ListParamsOption {
+	return withStatusChangeFilter(func(statusChange StatusChange) bool {
+		return slices.Contains(holdTokens, statusChange.HoldToken)
+	})
+}
+
+func (eventSupportNS) WithOriginType(originTypes ...string) ListParamsOption {
+	return withStatusChangeFilter(func(statusChange StatusChange) bool {
+		return slices.Contains(originTypes, statusChange.Origin.Type)
+	})
+}
+
+func (eventSupportNS) WithObjectLabels(objectLabels ...string) ListParamsOption {
+	return withStatusChangeFilter(func(statusChange StatusChange) bool {
+		return slices.Contains(objectLabels, statusChange.ObjectLabel)
+	})
+}
+
+// WithDateRange keeps status changes with from <= date < until. A nil bound is open.
+func (eventSupportNS) WithDateRange(from *time.Time, until *time.Time) ListParamsOption {
+	return withStatusChangeFilter(func(statusChange StatusChange) bool {
+		if statusChange.Date == nil {
+			return false
+		}
+		if from != nil && statusChange.Date.Before(*from) {
+			return false
+		}
+		if until != nil && !statusChange.Date.Before(*until) {
+			return false
+		}
+		return true
+	})
+}
+
+func (eventSupportNS) WithStatusChangeFilter(filter func(statusChange StatusChange) bool) ListParamsOption {
+	return withStatusChangeFilter(filter)
+}
+
+func withStatusChangeFilter(filter func(statusChange StatusChange) bool) ListParamsOption {
+	return func(pageFetcher *shared.PageFetcher[StatusChange]) {
+		previous := pageFetcher.Filter
+		if previous == nil {
+			pageFetcher.Filter = filter
+			return
+		}
+		pageFetcher.Filter = func(statusChange StatusChange) bool {
+			return previous(statusChange) && filter(statusChange)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package events
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// StatusSnapshot holds the state of every object that had at least one status change before the snapshot time.
+// Objects that are not in the snapshot were never touched and are free.
+type StatusSnapshot map[string]EventObjectInfo
+
+func (snapshot StatusSnapshot) Status(objectLabel string) string {
+	if info, ok := snapshot[objectLabel]; ok {
+		return info.Status
+	}
+	return FREE
+}
+
+func (snapshot StatusSnapshot) IsFree(objectLabel string) bool {
+	return snapshot.Status(objectLabel) == FREE
+}
+
+// ReplayStatusChanges rebuilds the state of an event's objects as of the given time, from its status change history.
+func ReplayStatusChanges(statusChanges []StatusChange, at time.Time) StatusSnapshot {
+	snapshot := StatusSnapshot{}
+	for _, statusChange := range sortedStatusChangesUntil(statusChanges, at) {
+		snapshot.apply(statusChange)
+	}
+	return snapshot
+}
+
+// ReplaySeasonStatusChanges rebuilds the state of an event in a season as of the given time. Objects follow the
+// season history, unless the event history overrides them: a status change with status OVERRIDE_SEASON_STATUS
+// (or any regular status change on the event) makes the event history authoritative for that object, and a status
+// change with status USE_SEASON_STATUS hands the object back to the season.
+func ReplaySeasonStatusChanges(seasonStatusChanges []StatusChange, eventStatusChanges []StatusChange, at time.Time) StatusSnapshot {
+	type sourcedStatusChange struct {
+		StatusChange
+		fromSeason bool
+	}
+	var all []sourcedStatusChange
+	for _, statusChange := range sortedStatusChangesUntil(seasonStatusChanges, at) {
+		all = append(all, sourcedStatusChange{statusChange, true})
+	}
+	for _, statusChange := range sortedStatusChangesUntil(eventStatusChanges, at) {
+		all = append(all, sourcedStatusChange{statusChange, false})
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return statusChangeBefore(all[i].StatusChange, all[j].StatusChange)
+	})
+
+	seasonSnapshot := StatusSnapshot{}
+	eventSnapshot := StatusSnapshot{}
+	overridden := map[string]bool{}
+	for _, statusChange := range all {
+		label := statusChange.ObjectLabel
+		switch {
+		case statusChange.fromSeason:
+			seasonSnapshot.apply(statusChange.StatusChange)
+		case statusChange.Status == string(OVERRIDE_SEASON_STATUS):
+			overridden[label] = true
+			if info, ok := seasonSnapshot[label]; ok {
+				eventSnapshot[label] = info
+			} else {
+				delete(eventSnapshot, label)
+			}
+		case statusChange.Status == string(USE_SEASON_STATUS):
+			overridden[label] = false
+			delete(eventSnapshot, label)
+		default:
+			overridden[label] = true
+			eventSnapshot.apply(statusChange.StatusChange)
+		}
+	}
+
+	snapshot := StatusSnapshot{}
+	for label, info := range seasonSnapshot {
+		if !overridden[label] {
+			snapshot[label] = info
+		}
+	}
+	for label, info := range eventSnapshot {
+		if overridden[label] {
+			snapshot[label] = info
+		}
+	}
+	return snapshot
+}
+
+func (snapshot StatusSnapshot) apply(statusChange StatusChange) {
+	snapshot[statusChange.ObjectLabel] = EventObjectInfo{
+		Label:     statusChange.ObjectLabel,
+		Status:    statusChange.Status,
+		OrderId:   statusChange.OrderId,
+		HoldToken: statusChange.HoldToken,
+		ExtraData: statusChange.ExtraData,
+	}
+}
+
+func sortedStatusChangesUntil(statusChanges []StatusChange, at time.Time) []StatusChange {
+	var result []StatusChange
+	for _, statusChange := range statusChanges {
+		if statusChange.Date != nil && !statusChange.Date.After(at) {
+			result = append(result, statusChange)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return statusChangeBefore(result[i], result[j])
+	})
+	return result
+}
+
+// statusChangeBefore orders status changes by date, and status changes with the same date by id.
+func statusChangeBefore(statusChange StatusChange, other StatusChange) bool {
+	if statusChange.Date.Equal(*other.Date) {
+		return statusChange.Id < other.Id
+	}
+	return statusChange.Date.Before(*other.Date)
+}
+
+func (events *Events) StatusSnapshotAt(context context.Context, eventKey string, at time.Time) (StatusSnapshot, error) {
+	statusChanges, err := events.statusChangesUntil(context, eventKey, at)
+	if err != nil {
+		return nil, err
+	}
+	return ReplayStatusChanges(statusChanges, at), nil
+}
+
+func (events *Events) SeasonStatusSnapshotAt(context context.Context, seasonKey string, eventKey string, at time.Time) (StatusSnapshot, error) {
+	seasonStatusChanges, err := events.statusChangesUntil(context, seasonKey, at)
+	if err != nil {
+		return nil, err
+	}
+	eventStatusChanges, err := events.statusChangesUntil(context, eventKey, at)
+	if err != nil {
+		return nil, err
+	}
+	return ReplaySeasonStatusChanges(seasonStatusChanges, eventStatusChanges, at), nil
+}
+
+func (events *Events) statusChangesUntil(context context.Context, eventKey string, at time.Time) ([]StatusChange, error) {
+	until := at.Add(time.Nanosecond)
+	return events.StatusChanges(context, eventKey, EventSupport.WithDateRange(nil, &until)).All()
+}
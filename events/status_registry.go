@@ -0,0 +1,168 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ObjectStatus is the status of an object in an event: one of FREE, BOOKED, HELD or RESALE, or a custom status. The
+// API types hold statuses as strings; EventObjectInfo.ObjectStatus and StatusChange.ObjectStatus return them typed.
+type ObjectStatus string
+
+func (status ObjectStatus) String() string {
+	return string(status)
+}
+
+func (info EventObjectInfo) ObjectStatus() ObjectStatus {
+	return ObjectStatus(info.Status)
+}
+
+func (statusChange StatusChange) ObjectStatus() ObjectStatus {
+	return ObjectStatus(statusChange.Status)
+}
+
+// StatusStrings converts statuses to the strings that StatusChanges.AllowedPreviousStatuses and
+// RejectedPreviousStatuses hold.
+func StatusStrings(statuses ...ObjectStatus) []string {
+	if statuses == nil {
+		return nil
+	}
+	result := make([]string, len(statuses))
+	for i, status := range statuses {
+		result[i] = string(status)
+	}
+	return result
+}
+
+// StatusRegistry declares the object statuses a workspace uses and the transitions between them. When it's set on
+// Events, status changes are checked against it before they are sent, and AllowedPreviousStatuses is filled in from
+// the declared transitions.
+//
+// Statuses without declared transitions can be reached from any status, which is how the built-in statuses behave
+// until transitions to them are declared.
+type StatusRegistry struct {
+	mu          sync.RWMutex
+	statuses    map[ObjectStatus]bool
+	transitions map[ObjectStatus][]ObjectStatus
+}
+
+type UnknownStatusError struct {
+	Status ObjectStatus
+}
+
+func (err *UnknownStatusError) Error() string {
+	return fmt.Sprintf("unknown object status %q; declare it in the status registry first", err.Status)
+}
+
+type IllegalTransitionError struct {
+	From ObjectStatus
+	To   ObjectStatus
+}
+
+func (err *IllegalTransitionError) Error() string {
+	return fmt.Sprintf("objects cannot go from status %q to %q", err.From, err.To)
+}
+
+// NewStatusRegistry returns a registry that knows the built-in statuses.
+func NewStatusRegistry() *StatusRegistry {
+	registry := &StatusRegistry{
+		statuses:    map[ObjectStatus]bool{},
+		transitions: map[ObjectStatus][]ObjectStatus{},
+	}
+	registry.Declare(FREE)
+	registry.Declare(BOOKED)
+	registry.Declare(HELD)
+	registry.Declare(RESALE)
+	return registry
+}
+
+// Declare registers a status. If previous statuses are given, objects can only move to the status from one of them.
+func (registry *StatusRegistry) Declare(status ObjectStatus, allowedPreviousStatuses ...ObjectStatus) *StatusRegistry {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.statuses[status] = true
+	for _, previousStatus := range allowedPreviousStatuses {
+		registry.statuses[previousStatus] = true
+		if !slices.Contains(registry.transitions[status], previousStatus) {
+			registry.transitions[status] = append(registry.transitions[status], previousStatus)
+		}
+	}
+	return registry
+}
+
+// AllowTransition declares both statuses and allows objects to move from one to the other.
+func (registry *StatusRegistry) AllowTransition(from ObjectStatus, to ObjectStatus) *StatusRegistry {
+	return registry.Declare(to, from)
+}
+
+func (registry *StatusRegistry) IsDeclared(status ObjectStatus) bool {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return registry.statuses[status]
+}
+
+// AllowedPreviousStatuses returns the statuses objects can move to the given status from, or nil if they can move
+// to it from any status.
+func (registry *StatusRegistry) AllowedPreviousStatuses(status ObjectStatus) []ObjectStatus {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	return slices.Clone(registry.transitions[status])
+}
+
+func (registry *StatusRegistry) CanTransition(from ObjectStatus, to ObjectStatus) bool {
+	allowed := registry.AllowedPreviousStatuses(to)
+	return registry.IsDeclared(from) && registry.IsDeclared(to) && (allowed == nil || slices.Contains(allowed, from))
+}
+
+// Statuses returns all declared statuses.
+func (registry *StatusRegistry) Statuses() []ObjectStatus {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	statuses := make([]ObjectStatus, 0, len(registry.statuses))
+	for status := range registry.statuses {
+		statuses = append(statuses, status)
+	}
+	slices.Sort(statuses)
+	return statuses
+}
+
+// Apply checks a status change against the registry and fills in its AllowedPreviousStatuses if they're not set.
+// Previous statuses that were set explicitly must be declared transitions.
+func (registry *StatusRegistry) Apply(statusChanges *StatusChanges) error {
+	target := ObjectStatus(statusChanges.Status)
+	if statusChanges.Type == RELEASE {
+		target = FREE
+	}
+	if statusChanges.Type == OVERRIDE_SEASON_STATUS || statusChanges.Type == USE_SEASON_STATUS {
+		return nil
+	}
+	if err := registry.check(target); err != nil {
+		return err
+	}
+	for _, previousStatus := range statusChanges.RejectedPreviousStatuses {
+		if err := registry.check(ObjectStatus(previousStatus)); err != nil {
+			return err
+		}
+	}
+	for _, allowedPreviousStatus := range statusChanges.AllowedPreviousStatuses {
+		previousStatus := ObjectStatus(allowedPreviousStatus)
+		if err := registry.check(previousStatus); err != nil {
+			return err
+		}
+		if !registry.CanTransition(previousStatus, target) {
+			return &IllegalTransitionError{From: previousStatus, To: target}
+		}
+	}
+	if len(statusChanges.AllowedPreviousStatuses) == 0 {
+		statusChanges.AllowedPreviousStatuses = StatusStrings(registry.AllowedPreviousStatuses(target)...)
+	}
+	return nil
+}
+
+func (registry *StatusRegistry) check(status ObjectStatus) error {
+	if !registry.IsDeclared(status) {
+		return &UnknownStatusError{Status: status}
+	}
+	return nil
+}
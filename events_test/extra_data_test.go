@@ -0,0 +1,144 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type attendee struct {
+	Name  string `json:"name"`
+	Age   int    `json:"age,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+const attendeeSchema = `{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"type": "string", "minLength": 1},
+		"age": {"type": "integer", "minimum": 0},
+		"email": {"type": "string", "pattern": "^[^@]+@[^@]+$"}
+	},
+	"additionalProperties": false
+}`
+
+func TestTypedExtraDataRoundTrip(t *testing.T) {
+	t.Parallel()
+	extraData, err := events.ToExtraData(attendee{Name: "Ada", Age: 36})
+	require.NoError(t, err)
+	require.Equal(t, events.ExtraData{"name": "Ada", "age": float64(36)}, extraData)
+
+	decoded, err := events.DecodeExtraData[attendee](events.EventObjectInfo{ExtraData: extraData})
+	require.NoError(t, err)
+	require.Equal(t, attendee{Name: "Ada", Age: 36}, decoded)
+
+	empty, err := events.DecodeStatusChangeExtraData[attendee](events.StatusChange{})
+	require.NoError(t, err)
+	require.Equal(t, attendee{}, empty)
+}
+
+func TestBookWithExtraData(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+
+	_, err := events.BookWithExtraData(test_util.RequestContext(), client.Events, "event1", map[string]attendee{
+		"A-2": {Name: "Grace"},
+		"A-1": {Name: "Ada", Email: "ada@example.com"},
+	})
+	require.NoError(t, err)
+
+	request := recorder.recorded()[0]
+	require.Equal(t, events.BOOKED, request.Status)
+	require.Equal(t, []events.ObjectProperties{
+		{ObjectId: "A-1", ExtraData: events.ExtraData{"name": "Ada", "email": "ada@example.com"}},
+		{ObjectId: "A-2", ExtraData: events.ExtraData{"name": "Grace"}},
+	}, request.Objects)
+}
+
+func TestInvalidExtraDataIsRejectedBeforeSending(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+	schema, err := events.ParseExtraDataSchema([]byte(attendeeSchema))
+	require.NoError(t, err)
+	client.Events.ExtraDataSchema = schema
+
+	_, err = events.BookWithExtraData(test_util.RequestContext(), client.Events, "event1", map[string]attendee{
+		"A-1": {Name: "Ada"},
+		"A-2": {Name: "", Age: -1, Email: "nope"},
+	})
+	var validationError *events.ExtraDataValidationError
+	require.ErrorAs(t, err, &validationError)
+	require.Equal(t, "A-2", validationError.ObjectId)
+	require.Equal(t, []string{"/age: must be at least 0", "/email: must match ^[^@]+@[^@]+$", "/name: must be at least 1 characters long"}, validationError.Problems)
+
+	err = client.Events.UpdateExtraData(test_util.RequestContext(), "event1", map[string]events.ExtraData{"A-1": {"nickname": "Ada"}})
+	require.ErrorAs(t, err, &validationError)
+	require.Equal(t, []string{"/: name is required", "/nickname: is not allowed"}, validationError.Problems)
+
+	_, err = client.Events.ChangeBestAvailableObjectStatus(test_util.RequestContext(), "event1", &events.BestAvailableStatusChangeParams{
+		Status:        events.BOOKED,
+		BestAvailable: events.BestAvailableParams{Number: 1, ExtraData: []events.ExtraData{{"name": 12}}},
+	})
+	require.ErrorAs(t, err, &validationError)
+	require.Equal(t, []string{"/name: must be of type string"}, validationError.Problems)
+	require.Empty(t, recorder.recorded())
+
+	_, err = events.BookWithExtraData(test_util.RequestContext(), client.Events, "event1", map[string]attendee{"A-1": {Name: "Ada", Age: 36}})
+	require.NoError(t, err)
+	require.Len(t, recorder.recorded(), 1)
+}
+
+func TestExtraDataSchemaKeywords(t *testing.T) {
+	t.Parallel()
+	schema, err := events.ParseExtraDataSchema([]byte(`{
+		"type": "object",
+		"properties": {
+			"tags": {"type": "array", "items": {"enum": ["vip", "press"]}, "maxItems": 2},
+			"seats": {"type": ["integer", "null"], "exclusiveMaximum": 10, "multipleOf": 2},
+			"kind": {"const": "guest"}
+		}
+	}`))
+	require.NoError(t, err)
+
+	problems, err := schema.Validate(map[string]any{"tags": []string{"vip", "fan", "press"}, "seats": 3, "kind": "host"})
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/kind: must be guest",
+		"/seats: must be a multiple of 2",
+		"/tags: must have at most 2 items",
+		"/tags/1: must be one of [vip press]",
+	}, problems)
+
+	problems, err = schema.Validate(map[string]any{"tags": []string{"press"}, "seats": nil, "kind": "guest"})
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	_, err = events.ParseExtraDataSchema([]byte(`{"pattern": "("}`))
+	require.Error(t, err)
+}
+
+func TestExtraDataSchemaRejectsUnsupportedKeywords(t *testing.T) {
+	t.Parallel()
+	_, err := events.ParseExtraDataSchema([]byte(`{"title": "attendee", "properties": {"email": {"type": "string", "format": "email"}}}`))
+	require.EqualError(t, err, "invalid extra data schema: unsupported keyword format")
+
+	_, err = events.ParseExtraDataSchema([]byte(`{"anyOf": [{"type": "string"}, {"type": "integer"}]}`))
+	require.EqualError(t, err, "invalid extra data schema: unsupported keyword anyOf")
+}
+
+func TestExtraDataSchemaMultipleOfDecimals(t *testing.T) {
+	t.Parallel()
+	schema, err := events.ParseExtraDataSchema([]byte(`{"multipleOf": 0.1}`))
+	require.NoError(t, err)
+
+	problems, err := schema.Validate(0.3)
+	require.NoError(t, err)
+	require.Empty(t, problems)
+
+	problems, err = schema.Validate(0.35)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/: must be a multiple of 0.1"}, problems)
+}
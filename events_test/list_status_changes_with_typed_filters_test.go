@@ -0,0 +1,125 @@
+package events_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/shared"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func createEventWithStatusChanges(t *testing.T) (*seatsio.SeatsioClient, *events.Event) {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+
+	_, err = client.Events.ChangeObjectStatusInBatch(
+		test_util.RequestContext(),
+		events.StatusChangeInBatchParams{Event: event.Key, StatusChanges: events.StatusChanges{Status: events.BOOKED, OrderId: "order1", Objects: []events.ObjectProperties{{ObjectId: "A-1"}}}},
+		events.StatusChangeInBatchParams{Event: event.Key, StatusChanges: events.StatusChanges{Status: "s2", OrderId: "order2", Objects: []events.ObjectProperties{{ObjectId: "A-2"}}}},
+		events.StatusChangeInBatchParams{Event: event.Key, StatusChanges: events.StatusChanges{Status: events.BOOKED, OrderId: "order1", Objects: []events.ObjectProperties{{ObjectId: "A-3"}}}},
+	)
+	require.NoError(t, err)
+	return client, event
+}
+
+func TestListStatusChangesWithStatusAndOrderId(t *testing.T) {
+	t.Parallel()
+	client, event := createEventWithStatusChanges(t)
+	support := events.EventSupport
+
+	statusChanges, err := client.Events.StatusChanges(test_util.RequestContext(), event.Key, support.WithStatus(events.BOOKED), support.WithOrderId("order1")).All(shared.Pagination.PageSize(1))
+	require.NoError(t, err)
+
+	require.Len(t, statusChanges, 2)
+	require.Equal(t, "A-3", statusChanges[0].ObjectLabel)
+	require.Equal(t, "A-1", statusChanges[1].ObjectLabel)
+}
+
+func TestListStatusChangesWithObjectLabelsAndOriginType(t *testing.T) {
+	t.Parallel()
+	client, event := createEventWithStatusChanges(t)
+	support := events.EventSupport
+
+	statusChanges, err := client.Events.StatusChanges(test_util.RequestContext(), event.Key, support.WithObjectLabels("A-1", "A-2"), support.WithOriginType("API_CALL")).All()
+	require.NoError(t, err)
+
+	require.Len(t, statusChanges, 2)
+	require.Equal(t, "A-2", statusChanges[0].ObjectLabel)
+	require.Equal(t, "A-1", statusChanges[1].ObjectLabel)
+}
+
+func TestListStatusChangesWithDateRange(t *testing.T) {
+	t.Parallel()
+	client, event := createEventWithStatusChanges(t)
+	support := events.EventSupport
+	past := time.Now().Add(-1 * time.Hour)
+	future := time.Now().Add(1 * time.Hour)
+
+	inRange, err := client.Events.StatusChanges(test_util.RequestContext(), event.Key, support.WithDateRange(&past, &future)).All()
+	require.NoError(t, err)
+	require.Len(t, inRange, 3)
+
+	outOfRange, err := client.Events.StatusChanges(test_util.RequestContext(), event.Key, support.WithDateRange(&future, nil)).All()
+	require.NoError(t, err)
+	require.Empty(t, outOfRange)
+}
+
+func TestForEachStatusChangeStopsEarly(t *testing.T) {
+	t.Parallel()
+	client, event := createEventWithStatusChanges(t)
+
+	var labels []string
+	err := client.Events.StatusChanges(test_util.RequestContext(), event.Key).ForEach(func(statusChange events.StatusChange) error {
+		labels = append(labels, statusChange.ObjectLabel)
+		if len(labels) == 2 {
+			return shared.ErrStopIteration
+		}
+		return nil
+	}, shared.Pagination.PageSize(1))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"A-3", "A-2"}, labels)
+}
+
+func TestExportStatusChangesToCSV(t *testing.T) {
+	t.Parallel()
+	client, event := createEventWithStatusChanges(t)
+	var buffer bytes.Buffer
+
+	err := events.ExportStatusChangesToCSV(client.Events.StatusChanges(test_util.RequestContext(), event.Key, events.EventSupport.WithOrderId("order2")), &buffer)
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(&buffer).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, "status", records[0][2])
+	require.Equal(t, "s2", records[1][2])
+	require.Equal(t, "order2", records[1][4])
+	require.Equal(t, "A-2", records[1][5])
+}
+
+func TestExportStatusChangesToJSONLines(t *testing.T) {
+	t.Parallel()
+	client, event := createEventWithStatusChanges(t)
+	var buffer bytes.Buffer
+
+	err := events.ExportStatusChangesToJSONLines(client.Events.StatusChanges(test_util.RequestContext(), event.Key), &buffer)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	require.Len(t, lines, 3)
+	var statusChange events.StatusChange
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &statusChange))
+	require.Equal(t, "A-3", statusChange.ObjectLabel)
+}
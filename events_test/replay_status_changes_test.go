@@ -0,0 +1,117 @@
+package events_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+var replayStart = time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC)
+
+func statusChangeAt(id int64, minutes int, objectLabel string, status string, orderId string) events.StatusChange {
+	date := replayStart.Add(time.Duration(minutes) * time.Minute)
+	return events.StatusChange{Id: id, Date: &date, ObjectLabel: objectLabel, Status: status, OrderId: orderId}
+}
+
+func TestReplayStatusChanges(t *testing.T) {
+	t.Parallel()
+	statusChanges := []events.StatusChange{
+		statusChangeAt(4, 30, "A-1", events.FREE, ""),
+		statusChangeAt(2, 10, "A-1", events.BOOKED, "order1"),
+		statusChangeAt(3, 20, "A-2", events.HELD, ""),
+		statusChangeAt(1, 0, "A-1", events.HELD, ""),
+	}
+
+	before := events.ReplayStatusChanges(statusChanges, replayStart.Add(-1*time.Minute))
+	require.Empty(t, before)
+	require.True(t, before.IsFree("A-1"))
+
+	atBooking := events.ReplayStatusChanges(statusChanges, replayStart.Add(10*time.Minute))
+	require.Equal(t, events.BOOKED, atBooking["A-1"].Status)
+	require.Equal(t, "order1", atBooking["A-1"].OrderId)
+	require.True(t, atBooking.IsFree("A-2"))
+
+	afterRelease := events.ReplayStatusChanges(statusChanges, replayStart.Add(1*time.Hour))
+	require.Equal(t, events.FREE, afterRelease.Status("A-1"))
+	require.Equal(t, events.HELD, afterRelease.Status("A-2"))
+}
+
+func TestReplayStatusChangesUsesIdForEqualDates(t *testing.T) {
+	t.Parallel()
+	statusChanges := []events.StatusChange{
+		statusChangeAt(2, 0, "A-1", "s2", ""),
+		statusChangeAt(1, 0, "A-1", "s1", ""),
+	}
+
+	snapshot := events.ReplayStatusChanges(statusChanges, replayStart)
+
+	require.Equal(t, "s2", snapshot.Status("A-1"))
+}
+
+func TestReplaySeasonStatusChanges(t *testing.T) {
+	t.Parallel()
+	seasonStatusChanges := []events.StatusChange{
+		statusChangeAt(1, 0, "A-1", events.BOOKED, "seasonTicket"),
+		statusChangeAt(2, 0, "A-2", events.BOOKED, "seasonTicket"),
+	}
+	eventStatusChanges := []events.StatusChange{
+		statusChangeAt(3, 10, "A-1", string(events.OVERRIDE_SEASON_STATUS), ""),
+		statusChangeAt(4, 20, "A-1", events.FREE, ""),
+		statusChangeAt(5, 30, "A-1", string(events.USE_SEASON_STATUS), ""),
+	}
+
+	overridden := events.ReplaySeasonStatusChanges(seasonStatusChanges, eventStatusChanges, replayStart.Add(10*time.Minute))
+	require.Equal(t, events.BOOKED, overridden.Status("A-1"))
+	require.Equal(t, "seasonTicket", overridden["A-1"].OrderId)
+
+	released := events.ReplaySeasonStatusChanges(seasonStatusChanges, eventStatusChanges, replayStart.Add(20*time.Minute))
+	require.Equal(t, events.FREE, released.Status("A-1"))
+	require.Equal(t, events.BOOKED, released.Status("A-2"))
+
+	backToSeason := events.ReplaySeasonStatusChanges(seasonStatusChanges, eventStatusChanges, replayStart.Add(30*time.Minute))
+	require.Equal(t, events.BOOKED, backToSeason.Status("A-1"))
+}
+
+func TestReplaySeasonStatusChangesUsesIdForEqualDates(t *testing.T) {
+	t.Parallel()
+	seasonStatusChanges := []events.StatusChange{
+		statusChangeAt(2, 0, "A-1", events.BOOKED, "seasonTicket"),
+	}
+	eventStatusChanges := []events.StatusChange{
+		statusChangeAt(1, 0, "A-1", string(events.OVERRIDE_SEASON_STATUS), ""),
+	}
+
+	snapshot := events.ReplaySeasonStatusChanges(seasonStatusChanges, eventStatusChanges, replayStart)
+
+	require.True(t, snapshot.IsFree("A-1"))
+}
+
+func TestStatusSnapshotAt(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey})
+	require.NoError(t, err)
+
+	_, err = client.Events.Book(test_util.RequestContext(), event.Key, "A-1")
+	require.NoError(t, err)
+	statusChanges, err := client.Events.StatusChanges(test_util.RequestContext(), event.Key).All()
+	require.NoError(t, err)
+	bookedAt := *statusChanges[0].Date
+	time.Sleep(1 * time.Second)
+	_, err = client.Events.Release(test_util.RequestContext(), event.Key, "A-1")
+	require.NoError(t, err)
+
+	snapshot, err := client.Events.StatusSnapshotAt(test_util.RequestContext(), event.Key, bookedAt)
+	require.NoError(t, err)
+	require.Equal(t, events.BOOKED, snapshot.Status("A-1"))
+
+	now, err := client.Events.StatusSnapshotAt(test_util.RequestContext(), event.Key, time.Now().Add(1*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, events.FREE, now.Status("A-1"))
+}
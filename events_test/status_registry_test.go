@@ -0,0 +1,160 @@
+package events_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type statusChangeRecorder struct {
+	mutex    sync.Mutex
+	requests []events.StatusChangeParams
+}
+
+func (recorder *statusChangeRecorder) recorded() []events.StatusChangeParams {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.requests
+}
+
+func newStatusChangeRecorder(t *testing.T) (*statusChangeRecorder, *seatsio.SeatsioClient) {
+	recorder := &statusChangeRecorder{}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var params events.StatusChangeParams
+		_ = json.NewDecoder(request.Body).Decode(&params)
+		recorder.mutex.Lock()
+		recorder.requests = append(recorder.requests, params)
+		recorder.mutex.Unlock()
+		writer.Header().Set("Content-Type", "application/json")
+		_, _ = writer.Write([]byte(`{"objects": {}}`))
+	}))
+	t.Cleanup(server.Close)
+	return recorder, seatsio.NewSeatsioClient(server.URL, "secretKey")
+}
+
+func checkInRegistry() *events.StatusRegistry {
+	return events.NewStatusRegistry().
+		AllowTransition(events.BOOKED, "checked-in").
+		AllowTransition("checked-in", "checked-out")
+}
+
+func TestStatusRegistryKnowsBuiltInStatuses(t *testing.T) {
+	t.Parallel()
+	registry := checkInRegistry()
+
+	require.Equal(t, []events.ObjectStatus{"booked", "checked-in", "checked-out", "free", "resale", "reservedByToken"}, registry.Statuses())
+	require.True(t, registry.CanTransition(events.FREE, events.BOOKED))
+	require.True(t, registry.CanTransition(events.BOOKED, "checked-in"))
+	require.False(t, registry.CanTransition(events.FREE, "checked-in"))
+	require.False(t, registry.CanTransition("chekced-in", events.FREE))
+	require.Nil(t, registry.AllowedPreviousStatuses(events.BOOKED))
+}
+
+func TestStatusesCanBeReadTyped(t *testing.T) {
+	t.Parallel()
+	status := events.ObjectStatus("checked-in")
+
+	require.Equal(t, status, events.EventObjectInfo{Status: "checked-in"}.ObjectStatus())
+	require.Equal(t, status, events.StatusChange{Status: "checked-in"}.ObjectStatus())
+	require.Equal(t, []string{"booked", "checked-in"}, events.StatusStrings(events.BOOKED, status))
+}
+
+func TestAllowedPreviousStatusesAreFilledIn(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+	client.Events.Statuses = checkInRegistry()
+
+	_, err := client.Events.ChangeObjectStatus(test_util.RequestContext(), []string{"event1"}, []string{"A-1"}, "checked-in")
+	require.NoError(t, err)
+
+	_, err = client.Events.Book(test_util.RequestContext(), "event1", "A-2")
+	require.NoError(t, err)
+
+	requests := recorder.recorded()
+	require.Len(t, requests, 2)
+	require.Equal(t, []string{events.BOOKED}, requests[0].AllowedPreviousStatuses)
+	require.Empty(t, requests[1].AllowedPreviousStatuses)
+}
+
+func TestUnknownStatusesAreRejectedBeforeSending(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+	client.Events.Statuses = checkInRegistry()
+
+	_, err := client.Events.ChangeObjectStatus(test_util.RequestContext(), []string{"event1"}, []string{"A-1"}, "chekced-in")
+
+	var unknownStatusError *events.UnknownStatusError
+	require.ErrorAs(t, err, &unknownStatusError)
+	require.Equal(t, events.ObjectStatus("chekced-in"), unknownStatusError.Status)
+	require.Empty(t, recorder.recorded())
+}
+
+func TestUndeclaredTransitionsAreRejectedBeforeSending(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+	client.Events.Statuses = checkInRegistry()
+
+	_, err := client.Events.ChangeObjectStatusWithOptions(test_util.RequestContext(), &events.StatusChangeParams{
+		Events: []string{"event1"},
+		StatusChanges: events.StatusChanges{
+			Status:                  "checked-out",
+			Objects:                 []events.ObjectProperties{{ObjectId: "A-1"}},
+			AllowedPreviousStatuses: []string{events.BOOKED},
+		},
+	})
+	require.ErrorAs(t, err, new(*events.IllegalTransitionError))
+
+	_, err = client.Events.ChangeBestAvailableObjectStatus(test_util.RequestContext(), "event1", &events.BestAvailableStatusChangeParams{
+		Status:        "checked-in",
+		BestAvailable: events.BestAvailableParams{Number: 2},
+	})
+	require.ErrorAs(t, err, new(*events.IllegalTransitionError))
+
+	_, err = client.Events.ChangeObjectStatusInBatch(test_util.RequestContext(), events.StatusChangeInBatchParams{
+		Event:         "event1",
+		StatusChanges: events.StatusChanges{Status: "checked-inn", Objects: []events.ObjectProperties{{ObjectId: "A-1"}}},
+	})
+	require.ErrorAs(t, err, new(*events.UnknownStatusError))
+	require.Empty(t, recorder.recorded())
+}
+
+func TestReleasesFollowDeclaredTransitionsToFree(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+	client.Events.Statuses = checkInRegistry().Declare(events.FREE, events.HELD, events.BOOKED)
+
+	_, err := client.Events.Release(test_util.RequestContext(), "event1", "A-1")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{events.HELD, events.BOOKED}, recorder.recorded()[0].AllowedPreviousStatuses)
+}
+
+func TestCallerParamsAreNotChanged(t *testing.T) {
+	t.Parallel()
+	recorder, client := newStatusChangeRecorder(t)
+	client.Events.Statuses = checkInRegistry()
+	params := &events.StatusChangeParams{
+		Events:        []string{"event1"},
+		StatusChanges: events.StatusChanges{Status: "checked-in", Objects: []events.ObjectProperties{{ObjectId: "A-1"}}},
+	}
+	batch := []events.StatusChangeInBatchParams{{
+		Event:         "event1",
+		StatusChanges: events.StatusChanges{Status: "checked-in", Objects: []events.ObjectProperties{{ObjectId: "A-1"}}},
+	}}
+
+	_, err := client.Events.ChangeObjectStatusWithOptions(test_util.RequestContext(), params)
+	require.NoError(t, err)
+	_, err = client.Events.ChangeObjectStatusInBatch(test_util.RequestContext(), batch...)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{events.BOOKED}, recorder.recorded()[0].AllowedPreviousStatuses)
+	require.Nil(t, params.AllowedPreviousStatuses)
+	require.Nil(t, batch[0].AllowedPreviousStatuses)
+}
@@ -95,3 +95,17 @@ func TestUpdateAreaPlaces(t *testing.T) {
 		AreaPlaces: map[string]int{"GA1": 3},
 	}, ch)
 }
+
+func TestUpdateRemovesAllAreaPlaces(t *testing.T) {
+	t.Parallel()
+
+	event, client := CreateChannel(t, &events.CreateChannelParams{Key: "channelKey1", Name: "bar", Color: "#ED303D", Index: 1, Objects: []string{"A-1", "A-2"}, AreaPlaces: map[string]int{"GA1": 5}})
+	updateParams := events.UpdateChannelParams{AreaPlaces: map[string]int{}}
+	err := client.Channels.Update(test_util.RequestContext(), event.Key, "channelKey1", updateParams)
+	require.NoError(t, err)
+
+	postUpdateEvent, _ := client.Events.Retrieve(test_util.RequestContext(), event.Key)
+	require.Len(t, postUpdateEvent.Channels, 1)
+	require.Empty(t, postUpdateEvent.Channels[0].AreaPlaces)
+	require.Equal(t, []string{"A-1", "A-2"}, postUpdateEvent.Channels[0].Objects)
+}
@@ -0,0 +1,106 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+func (engine *Engine) chartChanges(ctx context.Context, manifest *Manifest, _ bool) ([]Change, error) {
+	var changes []Change
+	for _, desired := range manifest.Charts {
+		live, err := engine.Charts.Retrieve(ctx, desired.Key)
+		if shared.IsNotFound(err) {
+			// charts created through the API get a generated key, so they have to exist before they can be managed
+			return nil, fmt.Errorf("chart %s does not exist; draw it in the designer or copy it into this workspace first", desired.Key)
+		}
+		if err != nil {
+			return nil, err
+		}
+		chartChanges, err := engine.updateChart(ctx, desired, live)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, chartChanges...)
+	}
+	return changes, nil
+}
+
+func (engine *Engine) updateChart(ctx context.Context, desired Chart, live *charts.Chart) ([]Change, error) {
+	var changes []Change
+	key := desired.Key
+	if desired.Name != "" && desired.Name != live.Name {
+		changes = append(changes, Change{
+			Resource:    "chart",
+			Key:         key,
+			Action:      Update,
+			Description: fmt.Sprintf("rename from %q to %q", live.Name, desired.Name),
+			apply: func(context context.Context) error {
+				return engine.Charts.Update(context, key, &charts.UpdateChartParams{Name: desired.Name})
+			},
+		})
+	}
+	if desired.Tags != nil {
+		for _, tag := range desired.Tags {
+			if !slices.Contains(live.Tags, tag) {
+				changes = append(changes, Change{Resource: "tag", Key: key, Action: Create, Description: "add tag " + tag,
+					apply: func(context context.Context) error { return engine.Charts.AddTag(context, key, tag) }})
+			}
+		}
+		for _, tag := range live.Tags {
+			if !slices.Contains(desired.Tags, tag) {
+				changes = append(changes, Change{Resource: "tag", Key: key, Action: Delete, Description: "remove tag " + tag,
+					apply: func(context context.Context) error { return engine.Charts.RemoveTag(context, key, tag) }})
+			}
+		}
+	}
+	if desired.Categories != nil {
+		liveCategories, err := engine.Charts.ListCategories(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, engine.categoryChanges(key, desired.Categories, liveCategories)...)
+	}
+	return changes, nil
+}
+
+func (engine *Engine) categoryChanges(chartKey string, desired []*Category, live []events.Category) []Change {
+	var changes []Change
+	liveByKey := map[string]events.Category{}
+	for _, category := range live {
+		liveByKey[category.Key.KeyAsString()] = category
+	}
+	desiredKeys := map[string]bool{}
+	for _, desiredCategory := range desired {
+		category := desiredCategory.toCategory()
+		categoryKey := category.Key.KeyAsString()
+		desiredKeys[categoryKey] = true
+		liveCategory, exists := liveByKey[categoryKey]
+		if !exists {
+			changes = append(changes, Change{Resource: "category", Key: chartKey + "/" + categoryKey, Action: Create, Description: "add category " + category.Label,
+				apply: func(context context.Context) error { return engine.Charts.AddCategory(context, chartKey, category) }})
+			continue
+		}
+		if liveCategory.Label != category.Label || liveCategory.Color != category.Color || liveCategory.Accessible != category.Accessible {
+			params := charts.UpdateCategoryParams{Label: category.Label, Color: category.Color, Accessible: category.Accessible}
+			changes = append(changes, Change{Resource: "category", Key: chartKey + "/" + categoryKey, Action: Update, Description: "update category " + category.Label,
+				apply: func(context context.Context) error {
+					return engine.Charts.UpdateCategory(context, chartKey, category.Key, params)
+				}})
+		}
+	}
+	for _, category := range live {
+		categoryKey := category.Key.KeyAsString()
+		if !desiredKeys[categoryKey] {
+			changes = append(changes, Change{Resource: "category", Key: chartKey + "/" + categoryKey, Action: Delete, Description: "remove category " + category.Label,
+				apply: func(context context.Context) error {
+					return engine.Charts.RemoveCategory(context, chartKey, category.Key)
+				}})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,102 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+)
+
+type Engine struct {
+	Charts   *charts.Charts
+	Events   *events.Events
+	Channels *events.Channels
+	Seasons  *seasons.Seasons
+}
+
+func NewEngine(client *seatsio.SeatsioClient) *Engine {
+	return &Engine{Charts: client.Charts, Events: client.Events, Channels: client.Channels, Seasons: client.Seasons}
+}
+
+type stage func(context context.Context, manifest *Manifest, planning bool) ([]Change, error)
+
+func (engine *Engine) stages() []stage {
+	return []stage{engine.chartChanges, engine.seasonChanges, engine.eventChanges, engine.partialSeasonChanges}
+}
+
+// Plan compares the manifest with the live state, without changing anything.
+func (engine *Engine) Plan(context context.Context, manifest *Manifest) (*Plan, error) {
+	plan := &Plan{}
+	for _, stage := range engine.stages() {
+		changes, err := stage(context, manifest, true)
+		if err != nil {
+			return nil, err
+		}
+		plan.Changes = append(plan.Changes, changes...)
+	}
+	return plan, nil
+}
+
+// Apply converges the live state towards the manifest. Charts are handled first, then seasons (which create
+// their events), then events and finally partial seasons. Every stage is diffed against the live state right
+// before it is applied, so that it sees the result of the previous stages. The returned plan holds the changes
+// that were applied, up to and including a failing one.
+func (engine *Engine) Apply(context context.Context, manifest *Manifest) (*Plan, error) {
+	applied := &Plan{}
+	for _, stage := range engine.stages() {
+		changes, err := stage(context, manifest, false)
+		if err != nil {
+			return applied, err
+		}
+		for _, change := range changes {
+			applied.Changes = append(applied.Changes, change)
+			if err := change.apply(context); err != nil {
+				return applied, fmt.Errorf("%s %s %s: %w", change.Action, change.Resource, change.Key, err)
+			}
+		}
+	}
+	return applied, nil
+}
+
+func (engine *Engine) channelChanges(eventKey string, live *events.Event, desired []channelsync.DesiredChannel) ([]Change, error) {
+	if desired == nil {
+		return nil, nil
+	}
+	channelsPlan, err := channelsync.PlanFor(live, desired)
+	if err != nil {
+		return nil, err
+	}
+	reconciler := &channelsync.Reconciler{Events: engine.Events, Channels: engine.Channels}
+	var changes []Change
+	for _, action := range channelsPlan.Actions {
+		channelPlan := channelsync.Plan{EventKey: eventKey, Actions: []channelsync.Action{action}}
+		key := eventKey
+		if action.ChannelKey != "" {
+			key += "/" + action.ChannelKey
+		}
+		changes = append(changes, Change{
+			Resource:    "channel",
+			Key:         key,
+			Action:      channelAction(action.Type),
+			Description: action.String(),
+			apply: func(context context.Context) error {
+				return reconciler.Apply(context, []channelsync.Plan{channelPlan}).Results[0].Err
+			},
+		})
+	}
+	return changes, nil
+}
+
+func channelAction(actionType channelsync.ActionType) Action {
+	switch actionType {
+	case channelsync.CreateChannel:
+		return Create
+	case channelsync.DeleteChannel:
+		return Delete
+	}
+	return Update
+}
@@ -0,0 +1,170 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+func (engine *Engine) eventChanges(ctx context.Context, manifest *Manifest, planning bool) ([]Change, error) {
+	seasonOfEvent := map[string]string{}
+	for _, season := range manifest.Seasons {
+		for _, eventKey := range season.Events {
+			seasonOfEvent[eventKey] = season.Key
+		}
+	}
+	var changes []Change
+	for _, desired := range manifest.Events {
+		if err := channelsync.Validate(desired.Channels); err != nil {
+			return nil, fmt.Errorf("channels of event %s: %w", desired.Key, err)
+		}
+		live, err := engine.Events.Retrieve(ctx, desired.Key)
+		if shared.IsNotFound(err) {
+			if seasonKey, inSeason := seasonOfEvent[desired.Key]; inSeason && planning {
+				// the event is created by its season in an earlier stage, and configured afterwards
+				live = &events.Event{Key: desired.Key, ChartKey: desired.Chart}
+				updates, err := engine.updateEvent(desired, live)
+				if err != nil {
+					return nil, err
+				}
+				for _, change := range updates {
+					change.Description += fmt.Sprintf(" (after season %s creates the event)", seasonKey)
+					changes = append(changes, change)
+				}
+				continue
+			}
+			changes = append(changes, engine.createEvent(desired))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if live.ChartKey != desired.Chart {
+			return nil, fmt.Errorf("event %s uses chart %s instead of %s; the chart of an event cannot be changed", desired.Key, live.ChartKey, desired.Chart)
+		}
+		updates, err := engine.updateEvent(desired, live)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, updates...)
+	}
+	return changes, nil
+}
+
+func (engine *Engine) createEvent(desired Event) Change {
+	params := &events.CreateEventParams{
+		ChartKey:      desired.Chart,
+		ForSaleConfig: desired.ForSaleConfig.toForSaleConfig(),
+		EventParams: &events.EventParams{
+			EventKey:           desired.Key,
+			Name:               desired.Name,
+			Date:               desired.Date,
+			TableBookingConfig: desired.TableBookingConfig.toTableBookingConfig(),
+		},
+	}
+	if desired.Channels != nil {
+		channels := make([]events.CreateChannelParams, len(desired.Channels))
+		for i, channel := range desired.Channels {
+			channels[i] = events.CreateChannelParams{Key: channel.Key, Name: channel.Name, Color: channel.Color, Index: channel.Index, Objects: channel.Objects, AreaPlaces: channel.AreaPlaces}
+		}
+		params.Channels = &channels
+	}
+	return Change{
+		Resource:    "event",
+		Key:         desired.Key,
+		Action:      Create,
+		Description: fmt.Sprintf("create event on chart %s", desired.Chart),
+		apply: func(ctx context.Context) error {
+			_, err := engine.Events.Create(ctx, params)
+			return err
+		},
+	}
+}
+
+func (engine *Engine) updateEvent(desired Event, live *events.Event) ([]Change, error) {
+	var changes []Change
+	if change := engine.eventPropertiesChange(desired.Key, desired.Name, desired.Date, desired.TableBookingConfig, live); change != nil {
+		changes = append(changes, *change)
+	}
+	if change := engine.forSaleConfigChange(desired.Key, desired.ForSaleConfig, live.ForSaleConfig); change != nil {
+		changes = append(changes, *change)
+	}
+	channelChanges, err := engine.channelChanges(desired.Key, live, desired.Channels)
+	return append(changes, channelChanges...), err
+}
+
+func (engine *Engine) eventPropertiesChange(key string, name string, date string, tableBookingConfig *TableBookingConfig, live *events.Event) *Change {
+	params := &events.EventParams{}
+	var differences []string
+	if name != "" && name != live.Name {
+		params.Name = name
+		differences = append(differences, fmt.Sprintf("name %q -> %q", live.Name, name))
+	}
+	if date != "" && date != live.Date {
+		params.Date = date
+		differences = append(differences, fmt.Sprintf("date %q -> %q", live.Date, date))
+	}
+	if desiredConfig := tableBookingConfig.toTableBookingConfig(); desiredConfig != nil && !sameTableBookingConfig(*desiredConfig, live.TableBookingConfig) {
+		params.TableBookingConfig = desiredConfig
+		differences = append(differences, fmt.Sprintf("table booking mode %s -> %s", live.TableBookingConfig.Mode, desiredConfig.Mode))
+	}
+	if len(differences) == 0 {
+		return nil
+	}
+	return &Change{
+		Resource:    "event",
+		Key:         key,
+		Action:      Update,
+		Description: strings.Join(differences, ", "),
+		apply: func(ctx context.Context) error {
+			return engine.Events.Update(ctx, key, &events.UpdateEventParams{EventParams: params})
+		},
+	}
+}
+
+func sameTableBookingConfig(a events.TableBookingConfig, b events.TableBookingConfig) bool {
+	return a.Mode == b.Mode && maps.Equal(a.Tables, b.Tables)
+}
+
+func (engine *Engine) forSaleConfigChange(key string, desired *ForSaleConfig, live *events.ForSaleConfig) *Change {
+	if desired == nil {
+		return nil
+	}
+	everythingForSale := desired.ForSale && len(desired.Objects) == 0 && len(desired.AreaPlaces) == 0 && len(desired.Categories) == 0
+	if everythingForSale {
+		if live == nil {
+			return nil
+		}
+		return &Change{Resource: "for sale config", Key: key, Action: Update, Description: "mark everything as for sale",
+			apply: func(ctx context.Context) error { return engine.Events.MarkEverythingAsForSale(ctx, key) }}
+	}
+	if live != nil && sameForSaleConfig(*desired.toForSaleConfig(), *live) {
+		return nil
+	}
+	params := &events.ForSaleConfigParams{Objects: desired.Objects, AreaPlaces: desired.AreaPlaces, Categories: desired.Categories}
+	return &Change{Resource: "for sale config", Key: key, Action: Update, Description: fmt.Sprintf("replace for sale config (forSale: %t)", desired.ForSale),
+		apply: func(ctx context.Context) error {
+			return engine.Events.ReplaceForSaleConfig(ctx, key, desired.ForSale, params)
+		}}
+}
+
+func sameForSaleConfig(a events.ForSaleConfig, b events.ForSaleConfig) bool {
+	return a.ForSale == b.ForSale &&
+		reflect.DeepEqual(sortedOrEmpty(a.Objects), sortedOrEmpty(b.Objects)) &&
+		reflect.DeepEqual(sortedOrEmpty(a.Categories), sortedOrEmpty(b.Categories)) &&
+		maps.Equal(a.AreaPlaces, b.AreaPlaces)
+}
+
+func sortedOrEmpty(values []string) []string {
+	if len(values) == 0 {
+		return []string{}
+	}
+	return slices.Sorted(slices.Values(values))
+}
@@ -0,0 +1,118 @@
+package manifest
+
+import (
+	"os"
+
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"gopkg.in/yaml.v3"
+)
+
+// Manifest describes the desired configuration of a workspace. Charts, events and seasons that are not in the
+// manifest are left alone. Within a resource, only the properties that are present are managed: leaving out
+// `tags` or `channels` keeps whatever is configured live.
+type Manifest struct {
+	Charts  []Chart  `json:"charts,omitempty" yaml:"charts,omitempty"`
+	Events  []Event  `json:"events,omitempty" yaml:"events,omitempty"`
+	Seasons []Season `json:"seasons,omitempty" yaml:"seasons,omitempty"`
+}
+
+type Chart struct {
+	Key        string      `json:"key" yaml:"key"`
+	Name       string      `json:"name,omitempty" yaml:"name,omitempty"`
+	Tags       []string    `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Categories []*Category `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+type Category struct {
+	// Key is either a number or a string, like events.CategoryKey
+	Key        any    `json:"key" yaml:"key"`
+	Label      string `json:"label" yaml:"label"`
+	Color      string `json:"color" yaml:"color"`
+	Accessible bool   `json:"accessible,omitempty" yaml:"accessible,omitempty"`
+}
+
+type Event struct {
+	Key                string                       `json:"key" yaml:"key"`
+	Chart              string                       `json:"chart" yaml:"chart"`
+	Name               string                       `json:"name,omitempty" yaml:"name,omitempty"`
+	Date               string                       `json:"date,omitempty" yaml:"date,omitempty"`
+	TableBookingConfig *TableBookingConfig          `json:"tableBookingConfig,omitempty" yaml:"tableBookingConfig,omitempty"`
+	Channels           []channelsync.DesiredChannel `json:"channels,omitempty" yaml:"channels,omitempty"`
+	ForSaleConfig      *ForSaleConfig               `json:"forSaleConfig,omitempty" yaml:"forSaleConfig,omitempty"`
+}
+
+type Season struct {
+	Key                string                       `json:"key" yaml:"key"`
+	Chart              string                       `json:"chart" yaml:"chart"`
+	Name               string                       `json:"name,omitempty" yaml:"name,omitempty"`
+	TableBookingConfig *TableBookingConfig          `json:"tableBookingConfig,omitempty" yaml:"tableBookingConfig,omitempty"`
+	Channels           []channelsync.DesiredChannel `json:"channels,omitempty" yaml:"channels,omitempty"`
+	Events             []string                     `json:"events,omitempty" yaml:"events,omitempty"`
+	PartialSeasons     []PartialSeason              `json:"partialSeasons,omitempty" yaml:"partialSeasons,omitempty"`
+}
+
+type TableBookingConfig struct {
+	Mode   events.Mode                        `json:"mode" yaml:"mode"`
+	Tables map[string]events.TableBookingMode `json:"tables,omitempty" yaml:"tables,omitempty"`
+}
+
+type ForSaleConfig struct {
+	ForSale    bool           `json:"forSale" yaml:"forSale"`
+	Objects    []string       `json:"objects,omitempty" yaml:"objects,omitempty"`
+	AreaPlaces map[string]int `json:"areaPlaces,omitempty" yaml:"areaPlaces,omitempty"`
+	Categories []string       `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+type PartialSeason struct {
+	Key    string   `json:"key" yaml:"key"`
+	Name   string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+}
+
+// Parse reads a YAML or a JSON manifest. JSON is parsed as YAML, of which it is a subset.
+func Parse(data []byte) (*Manifest, error) {
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+func Load(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data)
+}
+
+func (category *Category) categoryKey() events.CategoryKey {
+	switch key := category.Key.(type) {
+	case int:
+		return events.CategoryKey{Key: key}
+	case float64:
+		return events.CategoryKey{Key: int(key)}
+	case string:
+		return events.CategoryKey{Key: key}
+	}
+	return events.CategoryKey{Key: ""}
+}
+
+func (category *Category) toCategory() events.Category {
+	return events.Category{Key: category.categoryKey(), Label: category.Label, Color: category.Color, Accessible: category.Accessible}
+}
+
+func (config *TableBookingConfig) toTableBookingConfig() *events.TableBookingConfig {
+	if config == nil {
+		return nil
+	}
+	return &events.TableBookingConfig{Mode: config.Mode, Tables: config.Tables}
+}
+
+func (config *ForSaleConfig) toForSaleConfig() *events.ForSaleConfig {
+	if config == nil {
+		return nil
+	}
+	return &events.ForSaleConfig{ForSale: config.ForSale, Objects: config.Objects, AreaPlaces: config.AreaPlaces, Categories: config.Categories}
+}
@@ -0,0 +1,53 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+type Change struct {
+	Resource    string
+	Key         string
+	Action      Action
+	Description string
+	apply       func(context context.Context) error
+}
+
+func (change Change) String() string {
+	symbol := map[Action]string{Create: "+", Update: "~", Delete: "-"}[change.Action]
+	return fmt.Sprintf("%s %s %s: %s", symbol, change.Resource, change.Key, change.Description)
+}
+
+type Plan struct {
+	Changes []Change
+}
+
+func (plan *Plan) IsEmpty() bool {
+	return len(plan.Changes) == 0
+}
+
+func (plan *Plan) String() string {
+	if plan.IsEmpty() {
+		return "No changes. The live state matches the manifest.\n"
+	}
+	var builder strings.Builder
+	for _, change := range plan.Changes {
+		builder.WriteString(change.String())
+		builder.WriteString("\n")
+	}
+	counts := map[Action]int{}
+	for _, change := range plan.Changes {
+		counts[change.Action]++
+	}
+	builder.WriteString(fmt.Sprintf("Plan: %d to create, %d to update, %d to delete.\n", counts[Create], counts[Update], counts[Delete]))
+	return builder.String()
+}
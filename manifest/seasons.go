@@ -0,0 +1,161 @@
+package manifest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+func (engine *Engine) seasonChanges(ctx context.Context, manifest *Manifest, _ bool) ([]Change, error) {
+	var changes []Change
+	for _, desired := range manifest.Seasons {
+		if err := channelsync.Validate(desired.Channels); err != nil {
+			return nil, fmt.Errorf("channels of season %s: %w", desired.Key, err)
+		}
+		live, err := engine.Seasons.Retrieve(ctx, desired.Key)
+		if shared.IsNotFound(err) {
+			changes = append(changes, engine.createSeason(desired))
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if live.ChartKey != desired.Chart {
+			return nil, fmt.Errorf("season %s uses chart %s instead of %s; the chart of a season cannot be changed", desired.Key, live.ChartKey, desired.Chart)
+		}
+		updates, err := engine.updateSeason(desired, live)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, updates...)
+	}
+	return changes, nil
+}
+
+func (engine *Engine) createSeason(desired Season) Change {
+	params := &seasons.CreateSeasonParams{
+		Key:                desired.Key,
+		Name:               desired.Name,
+		TableBookingConfig: desired.TableBookingConfig.toTableBookingConfig(),
+		EventKeys:          desired.Events,
+	}
+	if desired.Channels != nil {
+		channels := make([]events.CreateChannelParams, len(desired.Channels))
+		for i, channel := range desired.Channels {
+			channels[i] = events.CreateChannelParams{Key: channel.Key, Name: channel.Name, Color: channel.Color, Index: channel.Index, Objects: channel.Objects, AreaPlaces: channel.AreaPlaces}
+		}
+		params.Channels = &channels
+	}
+	return Change{
+		Resource:    "season",
+		Key:         desired.Key,
+		Action:      Create,
+		Description: fmt.Sprintf("create season on chart %s with events %v", desired.Chart, desired.Events),
+		apply: func(ctx context.Context) error {
+			_, err := engine.Seasons.CreateWithOptions(ctx, desired.Chart, params)
+			return err
+		},
+	}
+}
+
+func (engine *Engine) updateSeason(desired Season, live *seasons.Season) ([]Change, error) {
+	var changes []Change
+	if change := engine.eventPropertiesChange(desired.Key, desired.Name, "", desired.TableBookingConfig, &live.Event); change != nil {
+		change.Resource = "season"
+		changes = append(changes, *change)
+	}
+	var missingEvents []string
+	for _, eventKey := range desired.Events {
+		if !slices.ContainsFunc(live.Events, func(event events.Event) bool { return event.Key == eventKey }) {
+			missingEvents = append(missingEvents, eventKey)
+		}
+	}
+	if len(missingEvents) > 0 {
+		key := desired.Key
+		changes = append(changes, Change{Resource: "season", Key: key, Action: Update, Description: fmt.Sprintf("create events %v in season", missingEvents),
+			apply: func(ctx context.Context) error {
+				_, err := engine.Seasons.CreateEventsWithEventKeys(ctx, key, missingEvents...)
+				return err
+			}})
+	}
+	channelChanges, err := engine.channelChanges(desired.Key, &live.Event, desired.Channels)
+	return append(changes, channelChanges...), err
+}
+
+func (engine *Engine) partialSeasonChanges(ctx context.Context, manifest *Manifest, _ bool) ([]Change, error) {
+	var changes []Change
+	for _, season := range manifest.Seasons {
+		for _, desired := range season.PartialSeasons {
+			live, err := engine.Seasons.Retrieve(ctx, desired.Key)
+			if shared.IsNotFound(err) {
+				changes = append(changes, engine.createPartialSeason(season.Key, desired))
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			changes = append(changes, engine.updatePartialSeason(season.Key, desired, live)...)
+		}
+	}
+	return changes, nil
+}
+
+func (engine *Engine) createPartialSeason(topLevelSeasonKey string, desired PartialSeason) Change {
+	params := &seasons.CreatePartialSeasonParams{Key: desired.Key, Name: desired.Name, EventKeys: desired.Events}
+	return Change{
+		Resource:    "partial season",
+		Key:         desired.Key,
+		Action:      Create,
+		Description: fmt.Sprintf("create partial season of %s with events %v", topLevelSeasonKey, desired.Events),
+		apply: func(ctx context.Context) error {
+			_, err := engine.Seasons.CreatePartialSeasonWithOptions(ctx, topLevelSeasonKey, params)
+			return err
+		},
+	}
+}
+
+func (engine *Engine) updatePartialSeason(topLevelSeasonKey string, desired PartialSeason, live *seasons.Season) []Change {
+	var changes []Change
+	key := desired.Key
+	if desired.Name != "" && desired.Name != live.Name {
+		changes = append(changes, Change{Resource: "partial season", Key: key, Action: Update, Description: fmt.Sprintf("rename from %q to %q", live.Name, desired.Name),
+			apply: func(ctx context.Context) error {
+				return engine.Seasons.Update(ctx, key, &seasons.UpdateSeasonParams{Name: desired.Name})
+			}})
+	}
+	if desired.Events == nil {
+		return changes
+	}
+	liveEvents := make([]string, len(live.Events))
+	for i, event := range live.Events {
+		liveEvents[i] = event.Key
+	}
+	var toAdd []string
+	for _, eventKey := range desired.Events {
+		if !slices.Contains(liveEvents, eventKey) {
+			toAdd = append(toAdd, eventKey)
+		}
+	}
+	if len(toAdd) > 0 {
+		changes = append(changes, Change{Resource: "partial season", Key: key, Action: Update, Description: fmt.Sprintf("add events %v", toAdd),
+			apply: func(ctx context.Context) error {
+				_, err := engine.Seasons.AddEventsToPartialSeason(ctx, topLevelSeasonKey, key, toAdd...)
+				return err
+			}})
+	}
+	for _, eventKey := range liveEvents {
+		if !slices.Contains(desired.Events, eventKey) {
+			changes = append(changes, Change{Resource: "partial season", Key: key, Action: Update, Description: "remove event " + eventKey,
+				apply: func(ctx context.Context) error {
+					_, err := engine.Seasons.RemoveEventFromPartialSeason(ctx, topLevelSeasonKey, key, eventKey)
+					return err
+				}})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,68 @@
+package manifest_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/manifest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYAMLManifest(t *testing.T) {
+	t.Parallel()
+	parsed, err := manifest.Parse([]byte(`
+charts:
+  - key: chart1
+    tags: [concerts]
+    categories:
+      - {key: 1, label: Floor, color: "#FF0000"}
+      - {key: balcony, label: Balcony, color: "#00FF00", accessible: true}
+events:
+  - key: event1
+    chart: chart1
+    name: Concert
+    date: "2025-06-01"
+    tableBookingConfig: {mode: ALL_BY_SEAT}
+    forSaleConfig: {forSale: false, objects: [A-1]}
+    channels:
+      - {key: boxOffice, name: Box office, color: "#0000FF", objects: [A-2]}
+seasons:
+  - key: season1
+    chart: chart1
+    events: [match1, match2]
+    partialSeasons:
+      - {key: firstHalf, events: [match1]}
+`))
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"concerts"}, parsed.Charts[0].Tags)
+	require.Equal(t, 1, parsed.Charts[0].Categories[0].Key)
+	require.Equal(t, "balcony", parsed.Charts[0].Categories[1].Key)
+	require.True(t, parsed.Charts[0].Categories[1].Accessible)
+	require.Equal(t, events.ALL_BY_SEAT, parsed.Events[0].TableBookingConfig.Mode)
+	require.Equal(t, []string{"A-1"}, parsed.Events[0].ForSaleConfig.Objects)
+	require.Equal(t, "boxOffice", parsed.Events[0].Channels[0].Key)
+	require.Equal(t, []string{"match1", "match2"}, parsed.Seasons[0].Events)
+	require.Equal(t, []string{"match1"}, parsed.Seasons[0].PartialSeasons[0].Events)
+}
+
+func TestParseJSONManifest(t *testing.T) {
+	t.Parallel()
+	parsed, err := manifest.Parse([]byte(`{"events": [{"key": "event1", "chart": "chart1", "forSaleConfig": {"forSale": true}}]}`))
+	require.NoError(t, err)
+
+	require.Equal(t, "chart1", parsed.Events[0].Chart)
+	require.True(t, parsed.Events[0].ForSaleConfig.ForSale)
+	require.Nil(t, parsed.Events[0].Channels)
+}
+
+func TestPlanString(t *testing.T) {
+	t.Parallel()
+	plan := &manifest.Plan{Changes: []manifest.Change{
+		{Resource: "event", Key: "event1", Action: manifest.Create, Description: "create event on chart chart1"},
+		{Resource: "tag", Key: "chart1", Action: manifest.Delete, Description: "remove tag old"},
+	}}
+
+	require.Equal(t, "+ event event1: create event on chart chart1\n- tag chart1: remove tag old\nPlan: 1 to create, 0 to update, 1 to delete.\n", plan.String())
+	require.Equal(t, "No changes. The live state matches the manifest.\n", (&manifest.Plan{}).String())
+}
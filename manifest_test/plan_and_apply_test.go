@@ -0,0 +1,70 @@
+package manifest_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/channelsync"
+	"github.com/seatsio/seatsio-go/v12/manifest"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyConvergesLiveState(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	desired := &manifest.Manifest{
+		Charts: []manifest.Chart{{Key: chartKey, Tags: []string{"concerts"}}},
+		Events: []manifest.Event{{
+			Key:           "event1",
+			Chart:         chartKey,
+			Name:          "Concert",
+			Date:          "2025-06-01",
+			ForSaleConfig: &manifest.ForSaleConfig{ForSale: false, Objects: []string{"A-1"}},
+			Channels:      []channelsync.DesiredChannel{{Key: "boxOffice", Name: "Box office", Color: "#0000FF", Objects: []string{"A-2"}}},
+		}},
+		Seasons: []manifest.Season{{
+			Key:            "season1",
+			Chart:          chartKey,
+			Events:         []string{"match1", "match2"},
+			PartialSeasons: []manifest.PartialSeason{{Key: "firstHalf", Events: []string{"match1"}}},
+		}},
+	}
+	engine := manifest.NewEngine(client)
+
+	plan, err := engine.Plan(test_util.RequestContext(), desired)
+	require.NoError(t, err)
+	require.False(t, plan.IsEmpty())
+
+	_, err = engine.Apply(test_util.RequestContext(), desired)
+	require.NoError(t, err)
+
+	chart, err := client.Charts.Retrieve(test_util.RequestContext(), chartKey)
+	require.NoError(t, err)
+	require.Equal(t, []string{"concerts"}, chart.Tags)
+	event, err := client.Events.Retrieve(test_util.RequestContext(), "event1")
+	require.NoError(t, err)
+	require.Equal(t, "Concert", event.Name)
+	require.False(t, event.ForSaleConfig.ForSale)
+	require.Equal(t, []string{"A-1"}, event.ForSaleConfig.Objects)
+	require.Equal(t, []string{"A-2"}, event.Channels[0].Objects)
+	partialSeason, err := client.Seasons.Retrieve(test_util.RequestContext(), "firstHalf")
+	require.NoError(t, err)
+	require.Equal(t, "match1", partialSeason.Events[0].Key)
+
+	planAfterApply, err := engine.Plan(test_util.RequestContext(), desired)
+	require.NoError(t, err)
+	require.True(t, planAfterApply.IsEmpty(), planAfterApply.String())
+}
+
+func TestPlanFailsForUnknownChart(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+
+	_, err := manifest.NewEngine(client).Plan(test_util.RequestContext(), &manifest.Manifest{Charts: []manifest.Chart{{Key: "unknownChart"}}})
+
+	require.ErrorContains(t, err, "chart unknownChart does not exist")
+}
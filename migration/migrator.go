@@ -0,0 +1,364 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+)
+
+// Migrator copies the charts, seasons, partial seasons, events, channels, for-sale configs, object statuses and
+// extra data of a source, usually another workspace, to a workspace.
+type Migrator struct {
+	Source Source
+	Target *seatsio.SeatsioClient
+	// CopyChart copies the published version of a chart from the source to the target workspace.
+	CopyChart func(context context.Context, chartKey string) (*charts.Chart, error)
+}
+
+// NewMigrator creates a Migrator that uses a company admin key to access both workspaces.
+func NewMigrator(baseUrl string, companyAdminKey string, sourceWorkspaceKey string, targetWorkspaceKey string) *Migrator {
+	admin := seatsio.NewSeatsioClient(baseUrl, companyAdminKey)
+	return &Migrator{
+		Source: WorkspaceSource{Client: seatsio.NewSeatsioClient(baseUrl, companyAdminKey, seatsio.ClientSupport.WorkspaceKey(sourceWorkspaceKey))},
+		Target: seatsio.NewSeatsioClient(baseUrl, companyAdminKey, seatsio.ClientSupport.WorkspaceKey(targetWorkspaceKey)),
+		CopyChart: func(context context.Context, chartKey string) (*charts.Chart, error) {
+			return admin.Charts.CopyFromWorkspaceTo(context, chartKey, sourceWorkspaceKey, targetWorkspaceKey)
+		},
+	}
+}
+
+// Failure describes something that could not be carried over to the target workspace.
+type Failure struct {
+	// Resource is chart, season, partialSeason, event, forSaleConfig, extraData or object.
+	Resource string
+	Key      string
+	// Object is the object label, for object failures.
+	Object string
+	Err    error
+}
+
+func (failure Failure) Error() string {
+	if failure.Object != "" {
+		return fmt.Sprintf("%s %s in %s: %s", failure.Resource, failure.Object, failure.Key, failure.Err)
+	}
+	return fmt.Sprintf("%s %s: %s", failure.Resource, failure.Key, failure.Err)
+}
+
+// Result maps the keys in the source workspace to the keys in the target workspace, and lists what could not be
+// migrated. Charts get a new key when they are copied; events and seasons keep theirs.
+type Result struct {
+	Charts         map[string]string
+	Seasons        map[string]string
+	PartialSeasons map[string]string
+	Events         map[string]string
+	Failures       []Failure
+}
+
+func (result *Result) fail(resource string, key string, err error) {
+	result.Failures = append(result.Failures, Failure{Resource: resource, Key: key, Err: err})
+}
+
+// Migrate copies the whole source workspace. Charts are copied first, then seasons with their events and partial
+// seasons, then the other events. Object statuses and extra data are replayed before the for-sale configs are
+// applied, so that objects that are not for sale can still be booked. Migrate carries on after a failure; the
+// returned error is only set when the source workspace cannot be read.
+func (migrator *Migrator) Migrate(context context.Context) (*Result, error) {
+	result := &Result{
+		Charts:         map[string]string{},
+		Seasons:        map[string]string{},
+		PartialSeasons: map[string]string{},
+		Events:         map[string]string{},
+	}
+	if err := migrator.migrateCharts(context, result); err != nil {
+		return result, err
+	}
+	all, err := migrator.Source.Events(context)
+	if err != nil {
+		return result, err
+	}
+	for _, season := range all {
+		if season.IsTopLevelSeason {
+			migrator.migrateSeason(context, season.Key, result)
+		}
+	}
+	for _, event := range all {
+		if !event.IsSeason && !event.IsEventInSeason && !event.IsPartialSeason {
+			migrator.migrateEvent(context, event.Event, result)
+		}
+	}
+	return result, nil
+}
+
+func (migrator *Migrator) migrateCharts(context context.Context, result *Result) error {
+	sourceCharts, err := migrator.Source.Charts(context)
+	if err != nil {
+		return err
+	}
+	for _, chart := range sourceCharts {
+		copied, err := migrator.CopyChart(context, chart.Key)
+		if err != nil {
+			result.fail("chart", chart.Key, err)
+			continue
+		}
+		result.Charts[chart.Key] = copied.Key
+		for _, tag := range chart.Tags {
+			if err := migrator.Target.Charts.AddTag(context, copied.Key, tag); err != nil {
+				result.fail("chart", chart.Key, fmt.Errorf("adding tag %s: %w", tag, err))
+			}
+		}
+		if chart.Archived {
+			if err := migrator.Target.Charts.MoveToArchive(context, copied.Key); err != nil {
+				result.fail("chart", chart.Key, fmt.Errorf("archiving: %w", err))
+			}
+		}
+	}
+	return nil
+}
+
+func (migrator *Migrator) chartKey(event events.Event, resource string, result *Result) (string, bool) {
+	chartKey, ok := result.Charts[event.ChartKey]
+	if !ok {
+		result.fail(resource, event.Key, fmt.Errorf("chart %s was not migrated", event.ChartKey))
+	}
+	return chartKey, ok
+}
+
+func (migrator *Migrator) migrateSeason(context context.Context, seasonKey string, result *Result) {
+	season, err := migrator.Source.Season(context, seasonKey)
+	if err != nil {
+		result.fail("season", seasonKey, err)
+		return
+	}
+	chartKey, ok := migrator.chartKey(season.Event, "season", result)
+	if !ok {
+		return
+	}
+	eventKeys := make([]string, len(season.Events))
+	for i, event := range season.Events {
+		eventKeys[i] = event.Key
+	}
+	forSalePropagated := season.ForSalePropagated
+	_, err = migrator.Target.Seasons.CreateWithOptions(context, chartKey, &seasons.CreateSeasonParams{
+		Key:                season.Key,
+		Name:               season.Name,
+		TableBookingConfig: tableBookingConfig(season.Event),
+		ObjectCategories:   objectCategories(season.Event),
+		Categories:         categories(season.Event),
+		EventKeys:          eventKeys,
+		Channels:           channels(season.Event),
+		ForSalePropagated:  &forSalePropagated,
+	})
+	if err != nil {
+		result.fail("season", season.Key, err)
+		return
+	}
+	result.Seasons[season.Key] = season.Key
+
+	for _, event := range season.Events {
+		result.Events[event.Key] = event.Key
+		if event.Name == "" && event.Date == "" {
+			continue
+		}
+		params := &events.UpdateEventParams{EventParams: &events.EventParams{Name: event.Name, Date: event.Date}}
+		if err := migrator.Target.Events.Update(context, event.Key, params); err != nil {
+			result.fail("event", event.Key, err)
+		}
+	}
+	for _, partialSeasonKey := range season.PartialSeasonKeys {
+		migrator.migratePartialSeason(context, season.Key, partialSeasonKey, result)
+	}
+
+	seasonObjects := migrator.replayObjects(context, season.Key, nil, result)
+	if seasonObjects == nil {
+		// without the season objects, every object of the events is replayed as an override
+		seasonObjects = map[string][]events.EventObjectInfo{}
+	}
+	for _, event := range season.Events {
+		migrator.replayObjects(context, event.Key, seasonObjects, result)
+	}
+	migrator.replaceForSaleConfig(context, season.Event, result)
+	for _, event := range season.Events {
+		if event.ForSaleConfig != nil && !sameForSaleConfig(event.ForSaleConfig, season.ForSaleConfig) {
+			migrator.replaceForSaleConfig(context, event, result)
+		}
+	}
+}
+
+func (migrator *Migrator) migratePartialSeason(context context.Context, topLevelSeasonKey string, partialSeasonKey string, result *Result) {
+	partialSeason, err := migrator.Source.Season(context, partialSeasonKey)
+	if err != nil {
+		result.fail("partialSeason", partialSeasonKey, err)
+		return
+	}
+	eventKeys := make([]string, len(partialSeason.Events))
+	for i, event := range partialSeason.Events {
+		eventKeys[i] = event.Key
+	}
+	_, err = migrator.Target.Seasons.CreatePartialSeasonWithOptions(context, topLevelSeasonKey, &seasons.CreatePartialSeasonParams{
+		Key:       partialSeason.Key,
+		Name:      partialSeason.Name,
+		EventKeys: eventKeys,
+	})
+	if err != nil {
+		result.fail("partialSeason", partialSeasonKey, err)
+		return
+	}
+	result.PartialSeasons[partialSeasonKey] = partialSeasonKey
+}
+
+func (migrator *Migrator) migrateEvent(context context.Context, event events.Event, result *Result) {
+	chartKey, ok := migrator.chartKey(event, "event", result)
+	if !ok {
+		return
+	}
+	_, err := migrator.Target.Events.Create(context, &events.CreateEventParams{
+		ChartKey: chartKey,
+		EventParams: &events.EventParams{
+			EventKey:           event.Key,
+			Name:               event.Name,
+			Date:               event.Date,
+			TableBookingConfig: tableBookingConfig(event),
+			ObjectCategories:   objectCategories(event),
+			Categories:         categories(event),
+			Channels:           channels(event),
+		},
+	})
+	if err != nil {
+		result.fail("event", event.Key, err)
+		return
+	}
+	result.Events[event.Key] = event.Key
+	migrator.replayObjects(context, event.Key, nil, result)
+	migrator.replaceForSaleConfig(context, event, result)
+}
+
+// replayObjects gives the objects of the migrated event the statuses and extra data they have in the source
+// workspace, and returns the source objects.
+func (migrator *Migrator) replayObjects(context context.Context, eventKey string, season map[string][]events.EventObjectInfo, result *Result) map[string][]events.EventObjectInfo {
+	objects, err := migrator.Source.Objects(context, eventKey)
+	if err != nil {
+		result.fail("object", eventKey, fmt.Errorf("reading object statuses: %w", err))
+		return nil
+	}
+	replay := PlanObjectReplay(eventKey, objects, season)
+	result.Failures = append(result.Failures, replay.Skipped...)
+	target := migrator.Target.Events
+	if len(replay.Overrides) > 0 {
+		if err := target.OverrideSeasonObjectStatus(context, eventKey, replay.Overrides); err != nil {
+			migrator.failObjects(eventKey, replay.Overrides, err, result)
+		}
+	}
+	if len(replay.Releases) > 0 {
+		params := &events.StatusChangeParams{Events: []string{eventKey}, StatusChanges: events.StatusChanges{Objects: objectProperties(replay.Releases), IgnoreChannels: true}}
+		if _, err := target.ReleaseWithOptions(context, params); err != nil {
+			migrator.failObjects(eventKey, replay.Releases, err, result)
+		}
+	}
+	for _, statusChanges := range replay.StatusChanges {
+		migrator.changeStatus(context, eventKey, statusChanges, result)
+	}
+	if len(replay.ExtraData) > 0 {
+		if err := target.UpdateExtraData(context, eventKey, replay.ExtraData); err != nil {
+			result.fail("extraData", eventKey, err)
+		}
+	}
+	return objects
+}
+
+// changeStatus changes the status of a group of objects at once. When that fails, the objects are retried one by
+// one, so that only the objects that cannot be carried over are reported.
+func (migrator *Migrator) changeStatus(context context.Context, eventKey string, statusChanges events.StatusChanges, result *Result) {
+	params := &events.StatusChangeParams{Events: []string{eventKey}, StatusChanges: statusChanges}
+	if _, err := migrator.Target.Events.ChangeObjectStatusWithOptions(context, params); err == nil {
+		return
+	}
+	for _, object := range statusChanges.Objects {
+		single := statusChanges
+		single.Objects = []events.ObjectProperties{object}
+		params := &events.StatusChangeParams{Events: []string{eventKey}, StatusChanges: single}
+		if _, err := migrator.Target.Events.ChangeObjectStatusWithOptions(context, params); err != nil {
+			result.Failures = append(result.Failures, Failure{Resource: "object", Key: eventKey, Object: object.ObjectId, Err: err})
+		}
+	}
+}
+
+func (migrator *Migrator) failObjects(eventKey string, objects []string, err error, result *Result) {
+	for _, object := range objects {
+		result.Failures = append(result.Failures, Failure{Resource: "object", Key: eventKey, Object: object, Err: err})
+	}
+}
+
+func (migrator *Migrator) replaceForSaleConfig(context context.Context, event events.Event, result *Result) {
+	config := event.ForSaleConfig
+	if config == nil {
+		return
+	}
+	params := &events.ForSaleConfigParams{Objects: config.Objects, AreaPlaces: config.AreaPlaces, Categories: config.Categories}
+	if err := migrator.Target.Events.ReplaceForSaleConfig(context, event.Key, config.ForSale, params); err != nil {
+		result.fail("forSaleConfig", event.Key, err)
+	}
+}
+
+func sameForSaleConfig(config *events.ForSaleConfig, other *events.ForSaleConfig) bool {
+	if other == nil {
+		return false
+	}
+	return config.ForSale == other.ForSale &&
+		slices.Equal(config.Objects, other.Objects) &&
+		slices.Equal(config.Categories, other.Categories) &&
+		fmt.Sprint(config.AreaPlaces) == fmt.Sprint(other.AreaPlaces)
+}
+
+func tableBookingConfig(event events.Event) *events.TableBookingConfig {
+	if event.TableBookingConfig.Mode == "" {
+		return nil
+	}
+	return &event.TableBookingConfig
+}
+
+func objectCategories(event events.Event) *map[string]events.CategoryKey {
+	if len(event.ObjectCategories) == 0 {
+		return nil
+	}
+	return &event.ObjectCategories
+}
+
+// categories returns the categories that were added to the event itself. Categories of the chart are copied with
+// the chart.
+func categories(event events.Event) *[]events.Category {
+	if len(event.Categories) == 0 {
+		return nil
+	}
+	return &event.Categories
+}
+
+func channels(event events.Event) *[]events.CreateChannelParams {
+	if len(event.Channels) == 0 {
+		return nil
+	}
+	params := make([]events.CreateChannelParams, len(event.Channels))
+	for i, channel := range event.Channels {
+		params[i] = events.CreateChannelParams{
+			Key:        channel.Key,
+			Name:       channel.Name,
+			Color:      channel.Color,
+			Index:      channel.Index,
+			Objects:    channel.Objects,
+			AreaPlaces: channel.AreaPlaces,
+		}
+	}
+	return &params
+}
+
+func objectProperties(objects []string) []events.ObjectProperties {
+	properties := make([]events.ObjectProperties, len(objects))
+	for i, object := range objects {
+		properties[i] = events.ObjectProperties{ObjectId: object}
+	}
+	return properties
+}
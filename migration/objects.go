@@ -0,0 +1,143 @@
+package migration
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// ObjectReplay holds what has to be done on a migrated event to give its objects the statuses and extra data they
+// have in the source workspace.
+type ObjectReplay struct {
+	// Overrides lists the objects of an event in a season that no longer follow the season status.
+	Overrides []string
+	// Releases lists overridden objects that are free in the event, but not in the season.
+	Releases []string
+	// StatusChanges groups the objects per status, order id and resale listing.
+	StatusChanges []events.StatusChanges
+	// ExtraData holds the extra data of objects that are free, which is not carried by a status change.
+	ExtraData map[string]events.ExtraData
+	// Skipped lists the objects that cannot be carried over, such as objects held with a hold token, and general
+	// admission areas whose booked places lose their order ids.
+	Skipped []Failure
+}
+
+func (replay *ObjectReplay) IsEmpty() bool {
+	return len(replay.Overrides) == 0 && len(replay.Releases) == 0 && len(replay.StatusChanges) == 0 && len(replay.ExtraData) == 0
+}
+
+// PlanObjectReplay compares the objects of an event, as returned by EventReports.ByLabel, with the objects of the
+// season it belongs to. Pass nil as season for events that are not part of a season, or for the season itself.
+// Objects of an event in a season that are identical to the season object are left alone: replaying the season
+// gives them their status.
+func PlanObjectReplay(eventKey string, objects map[string][]events.EventObjectInfo, season map[string][]events.EventObjectInfo) ObjectReplay {
+	replay := ObjectReplay{ExtraData: map[string]events.ExtraData{}}
+	groups := map[statusGroup][]events.ObjectProperties{}
+	for _, label := range sortedLabels(objects) {
+		object := objects[label][0]
+		if season != nil {
+			seasonObject, inSeason := season[label]
+			if inSeason && sameObject(object, seasonObject[0]) {
+				continue
+			}
+			replay.Overrides = append(replay.Overrides, label)
+			if isFree(object) && inSeason && !isFree(seasonObject[0]) {
+				replay.Releases = append(replay.Releases, label)
+			}
+		}
+		if object.NumHeld > 0 || object.Status == events.HELD {
+			replay.Skipped = append(replay.Skipped, Failure{
+				Resource: "object",
+				Key:      eventKey,
+				Object:   label,
+				Err:      fmt.Errorf("held with hold token %s, hold tokens cannot be migrated", object.HoldToken),
+			})
+		}
+		switch {
+		case isGeneralAdmission(object) && object.NumBooked > 0:
+			// the event report only has the booked quantity, so the places are booked, but without their order ids
+			group := statusGroup{status: events.BOOKED}
+			groups[group] = append(groups[group], events.ObjectProperties{ObjectId: label, Quantity: object.NumBooked})
+			replay.Skipped = append(replay.Skipped, Failure{
+				Resource: "object",
+				Key:      eventKey,
+				Object:   label,
+				Err:      fmt.Errorf("%d booked places are migrated without their order ids, which are not in the event report", object.NumBooked),
+			})
+		case !isGeneralAdmission(object) && !isFree(object) && object.Status != events.HELD:
+			group := statusGroup{status: object.Status, orderId: object.OrderId, resaleListingId: object.ResaleListingId}
+			groups[group] = append(groups[group], events.ObjectProperties{ObjectId: label, ExtraData: object.ExtraData, TicketType: object.TicketType})
+			continue
+		}
+		if len(object.ExtraData) > 0 {
+			replay.ExtraData[label] = object.ExtraData
+		}
+	}
+	for _, group := range sortedGroups(groups) {
+		replay.StatusChanges = append(replay.StatusChanges, events.StatusChanges{
+			Status:          group.status,
+			Objects:         groups[group],
+			OrderId:         group.orderId,
+			ResaleListingId: group.resaleListingId,
+			IgnoreChannels:  true,
+		})
+	}
+	return replay
+}
+
+type statusGroup struct {
+	status          string
+	orderId         string
+	resaleListingId string
+}
+
+func sortedGroups(groups map[statusGroup][]events.ObjectProperties) []statusGroup {
+	result := make([]statusGroup, 0, len(groups))
+	for group := range groups {
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].status != result[j].status {
+			return result[i].status < result[j].status
+		}
+		if result[i].orderId != result[j].orderId {
+			return result[i].orderId < result[j].orderId
+		}
+		return result[i].resaleListingId < result[j].resaleListingId
+	})
+	return result
+}
+
+func sortedLabels(objects map[string][]events.EventObjectInfo) []string {
+	labels := make([]string, 0, len(objects))
+	for label, infos := range objects {
+		if len(infos) > 0 {
+			labels = append(labels, label)
+		}
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+func isGeneralAdmission(object events.EventObjectInfo) bool {
+	return object.ObjectType == "generalAdmission"
+}
+
+func isFree(object events.EventObjectInfo) bool {
+	if isGeneralAdmission(object) {
+		return object.NumBooked == 0 && object.NumHeld == 0
+	}
+	return object.Status == "" || object.Status == events.FREE
+}
+
+func sameObject(object events.EventObjectInfo, seasonObject events.EventObjectInfo) bool {
+	return object.Status == seasonObject.Status &&
+		object.OrderId == seasonObject.OrderId &&
+		object.TicketType == seasonObject.TicketType &&
+		object.NumBooked == seasonObject.NumBooked &&
+		object.NumHeld == seasonObject.NumHeld &&
+		reflect.DeepEqual(object.ExtraData, seasonObject.ExtraData)
+}
@@ -0,0 +1,62 @@
+package migration
+
+import (
+	"context"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/charts"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+// Source is what a Migrator copies from: a live workspace, or a backup archive.
+type Source interface {
+	// Charts returns the active and the archived charts.
+	Charts(context context.Context) ([]charts.Chart, error)
+	// Events returns the events, seasons and partial seasons, including the flags that tell them apart.
+	Events(context context.Context) ([]seasons.Season, error)
+	// Season returns a season or partial season, with its events and partial season keys.
+	Season(context context.Context, key string) (*seasons.Season, error)
+	// Objects returns the objects of an event or season by label, as EventReports.ByLabel does.
+	Objects(context context.Context, eventKey string) (map[string][]events.EventObjectInfo, error)
+}
+
+// WorkspaceSource reads a live workspace.
+type WorkspaceSource struct {
+	Client *seatsio.SeatsioClient
+}
+
+func (source WorkspaceSource) Charts(context context.Context) ([]charts.Chart, error) {
+	active, err := source.Client.Charts.ListAll(context)
+	if err != nil {
+		return nil, err
+	}
+	archived, err := source.Client.Charts.Archive.All(context)
+	if err != nil {
+		return nil, err
+	}
+	return append(active, archived...), nil
+}
+
+func (source WorkspaceSource) Events(context context.Context) ([]seasons.Season, error) {
+	lister := &shared.Lister[seasons.Season]{PageFetcher: &shared.PageFetcher[seasons.Season]{
+		Client:    source.Client.Events.Client,
+		Url:       "/events",
+		UrlParams: map[string]string{},
+		Context:   &context,
+	}}
+	return lister.All()
+}
+
+func (source WorkspaceSource) Season(context context.Context, key string) (*seasons.Season, error) {
+	return source.Client.Seasons.Retrieve(context, key)
+}
+
+func (source WorkspaceSource) Objects(context context.Context, eventKey string) (map[string][]events.EventObjectInfo, error) {
+	report, err := source.Client.EventReports.ByLabel(context, eventKey)
+	if err != nil {
+		return nil, err
+	}
+	return report.Items, nil
+}
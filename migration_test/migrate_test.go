@@ -0,0 +1,62 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/migration"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateWorkspace(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	source := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	event, err := source.Events.Create(test_util.RequestContext(), &events.CreateEventParams{
+		ChartKey:    chartKey,
+		EventParams: &events.EventParams{EventKey: "concert", Name: "Concert"},
+	})
+	require.NoError(t, err)
+	_, err = source.Events.BookWithOptions(test_util.RequestContext(), &events.StatusChangeParams{
+		Events:        []string{event.Key},
+		StatusChanges: events.StatusChanges{Objects: []events.ObjectProperties{{ObjectId: "A-1", ExtraData: events.ExtraData{"name": "John"}}}, OrderId: "order1"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, source.Events.ReplaceForSaleConfig(test_util.RequestContext(), event.Key, false, &events.ForSaleConfigParams{Objects: []string{"A-2"}}))
+	_, err = source.Seasons.CreateWithOptions(test_util.RequestContext(), chartKey, &seasons.CreateSeasonParams{Key: "season", EventKeys: []string{"match1", "match2"}})
+	require.NoError(t, err)
+	_, err = source.Seasons.CreatePartialSeasonWithOptions(test_util.RequestContext(), "season", &seasons.CreatePartialSeasonParams{Key: "half", EventKeys: []string{"match1"}})
+	require.NoError(t, err)
+	_, err = source.Events.Book(test_util.RequestContext(), "season", "A-3")
+	require.NoError(t, err)
+	target, err := source.Workspaces.CreateProductionWorkspace(test_util.RequestContext(), "target")
+	require.NoError(t, err)
+
+	result, err := migration.NewMigrator(test_util.BaseUrl, company.Admin.SecretKey, company.Workspace.Key, target.Key).Migrate(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Empty(t, result.Failures)
+	require.Contains(t, result.Charts, chartKey)
+	require.Equal(t, map[string]string{"season": "season"}, result.Seasons)
+	require.Equal(t, map[string]string{"half": "half"}, result.PartialSeasons)
+	require.Equal(t, map[string]string{"concert": "concert", "match1": "match1", "match2": "match2"}, result.Events)
+
+	targetClient := seatsio.NewSeatsioClient(test_util.BaseUrl, target.SecretKey)
+	migratedEvent, err := targetClient.Events.Retrieve(test_util.RequestContext(), "concert")
+	require.NoError(t, err)
+	require.Equal(t, result.Charts[chartKey], migratedEvent.ChartKey)
+	require.Equal(t, "Concert", migratedEvent.Name)
+	require.Equal(t, []string{"A-2"}, migratedEvent.ForSaleConfig.Objects)
+	objectInfos, err := targetClient.Events.RetrieveObjectInfo(test_util.RequestContext(), "concert", "A-1")
+	require.NoError(t, err)
+	require.Equal(t, events.BOOKED, objectInfos["A-1"].Status)
+	require.Equal(t, "order1", objectInfos["A-1"].OrderId)
+	require.Equal(t, events.ExtraData{"name": "John"}, objectInfos["A-1"].ExtraData)
+	seasonObjectInfos, err := targetClient.Events.RetrieveObjectInfo(test_util.RequestContext(), "match2", "A-3")
+	require.NoError(t, err)
+	require.Equal(t, events.BOOKED, seasonObjectInfos["A-3"].Status)
+}
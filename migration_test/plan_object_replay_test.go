@@ -0,0 +1,88 @@
+package migration_test
+
+import (
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/migration"
+	"github.com/stretchr/testify/require"
+)
+
+func objectsByLabel(objects ...events.EventObjectInfo) map[string][]events.EventObjectInfo {
+	result := map[string][]events.EventObjectInfo{}
+	for _, object := range objects {
+		result[object.Label] = []events.EventObjectInfo{object}
+	}
+	return result
+}
+
+func TestPlanObjectReplayGroupsStatusChanges(t *testing.T) {
+	t.Parallel()
+	objects := objectsByLabel(
+		events.EventObjectInfo{Label: "A-1", Status: events.BOOKED, OrderId: "order1", ExtraData: events.ExtraData{"name": "John"}},
+		events.EventObjectInfo{Label: "A-2", Status: events.BOOKED, OrderId: "order1", TicketType: "adult"},
+		events.EventObjectInfo{Label: "A-3", Status: "lolzor"},
+		events.EventObjectInfo{Label: "A-4", Status: events.FREE, ExtraData: events.ExtraData{"note": "wheelchair"}},
+		events.EventObjectInfo{Label: "A-5", Status: events.FREE},
+	)
+
+	replay := migration.PlanObjectReplay("event1", objects, nil)
+
+	require.Empty(t, replay.Overrides)
+	require.Empty(t, replay.Skipped)
+	require.Equal(t, []events.StatusChanges{
+		{
+			Status: events.BOOKED,
+			Objects: []events.ObjectProperties{
+				{ObjectId: "A-1", ExtraData: events.ExtraData{"name": "John"}},
+				{ObjectId: "A-2", TicketType: "adult"},
+			},
+			OrderId:        "order1",
+			IgnoreChannels: true,
+		},
+		{Status: "lolzor", Objects: []events.ObjectProperties{{ObjectId: "A-3"}}, IgnoreChannels: true},
+	}, replay.StatusChanges)
+	require.Equal(t, map[string]events.ExtraData{"A-4": {"note": "wheelchair"}}, replay.ExtraData)
+}
+
+func TestPlanObjectReplaySkipsHeldObjects(t *testing.T) {
+	t.Parallel()
+	objects := objectsByLabel(
+		events.EventObjectInfo{Label: "A-1", Status: events.HELD, HoldToken: "token1"},
+		events.EventObjectInfo{Label: "GA1", ObjectType: "generalAdmission", NumBooked: 3, NumHeld: 2},
+	)
+
+	replay := migration.PlanObjectReplay("event1", objects, nil)
+
+	require.Len(t, replay.Skipped, 3)
+	require.Equal(t, "A-1", replay.Skipped[0].Object)
+	require.Equal(t, "GA1", replay.Skipped[1].Object)
+	require.ErrorContains(t, replay.Skipped[1].Err, "hold token")
+	require.Equal(t, "GA1", replay.Skipped[2].Object)
+	require.EqualError(t, replay.Skipped[2].Err, "3 booked places are migrated without their order ids, which are not in the event report")
+	require.Equal(t, []events.StatusChanges{
+		{Status: events.BOOKED, Objects: []events.ObjectProperties{{ObjectId: "GA1", Quantity: 3}}, IgnoreChannels: true},
+	}, replay.StatusChanges)
+}
+
+func TestPlanObjectReplayOnlyOverridesObjectsThatDifferFromTheSeason(t *testing.T) {
+	t.Parallel()
+	season := objectsByLabel(
+		events.EventObjectInfo{Label: "A-1", Status: events.BOOKED, OrderId: "seasonTicket"},
+		events.EventObjectInfo{Label: "A-2", Status: events.BOOKED, OrderId: "seasonTicket"},
+		events.EventObjectInfo{Label: "A-3", Status: events.FREE},
+	)
+	event := objectsByLabel(
+		events.EventObjectInfo{Label: "A-1", Status: events.BOOKED, OrderId: "seasonTicket"},
+		events.EventObjectInfo{Label: "A-2", Status: events.FREE},
+		events.EventObjectInfo{Label: "A-3", Status: events.BOOKED, OrderId: "order1"},
+	)
+
+	replay := migration.PlanObjectReplay("event1", event, season)
+
+	require.Equal(t, []string{"A-2", "A-3"}, replay.Overrides)
+	require.Equal(t, []string{"A-2"}, replay.Releases)
+	require.Equal(t, []events.StatusChanges{
+		{Status: events.BOOKED, Objects: []events.ObjectProperties{{ObjectId: "A-3"}}, OrderId: "order1", IgnoreChannels: true},
+	}, replay.StatusChanges)
+}
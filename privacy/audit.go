@@ -0,0 +1,56 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+type AuditAction string
+
+const (
+	AuditExport AuditAction = "export"
+	// AuditEraseStarted is recorded before extra data is erased, and AuditErase after it was, so an erasure that
+	// cannot be recorded afterwards is still in the audit log.
+	AuditEraseStarted AuditAction = "erase-started"
+	AuditErase        AuditAction = "erase"
+)
+
+// AuditRecord describes an export or erasure. It holds no personal data: only the subject's reference and the objects
+// that were exported or changed.
+type AuditRecord struct {
+	Time      time.Time       `json:"time"`
+	Action    AuditAction     `json:"action"`
+	Reference string          `json:"reference"`
+	Objects   []AuditedObject `json:"objects"`
+}
+
+type AuditedObject struct {
+	EventKey    string `json:"eventKey"`
+	ObjectLabel string `json:"objectLabel"`
+	// ErasedKeys lists the extra data keys that were removed. Empty for exports.
+	ErasedKeys []string `json:"erasedKeys,omitempty"`
+}
+
+type AuditLog interface {
+	Record(context context.Context, record AuditRecord) error
+}
+
+// JSONLinesAuditLog writes every record as a line of JSON, e.g. to an append-only file.
+type JSONLinesAuditLog struct {
+	Writer io.Writer
+	mutex  sync.Mutex
+}
+
+func (log *JSONLinesAuditLog) Record(_ context.Context, record AuditRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	log.mutex.Lock()
+	defer log.mutex.Unlock()
+	_, err = log.Writer.Write(append(line, '\n'))
+	return err
+}
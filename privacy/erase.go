@@ -0,0 +1,83 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// eraseBatchSize is the number of objects whose extra data is updated per request.
+const eraseBatchSize = 100
+
+// Erase removes all extra data of every object that holds data of the subject, not only the values under Keys.
+// Statuses are kept, and so are order ids, which can only change with a status change. Every batch of objects gets an
+// AuditEraseStarted record before its extra data is erased and an AuditErase record after, so the audit log is
+// accurate when an erasure fails halfway; erasing again then finishes the job. If the AuditErase record cannot be
+// written, the objects that were erased are returned along with the error.
+//
+// The extra data of a general admission area is shared by all its bookings, so it is erased as a whole too.
+func (toolkit *Toolkit) Erase(context context.Context, subject Subject) ([]Match, error) {
+	matches, err := toolkit.Find(context, subject)
+	if err != nil {
+		return nil, err
+	}
+	var erased []Match
+	for _, eventMatches := range matchesPerEvent(matches) {
+		eventKey := eventMatches[0].EventKey
+		for start := 0; start < len(eventMatches); start += eraseBatchSize {
+			batch := eventMatches[start:min(start+eraseBatchSize, len(eventMatches))]
+			extraData := map[string]events.ExtraData{}
+			record := AuditRecord{Time: toolkit.now(), Action: AuditErase, Reference: subject.Reference}
+			for _, match := range batch {
+				if len(match.ExtraData) == 0 {
+					continue
+				}
+				extraData[match.ObjectLabel] = events.ExtraData{}
+				record.Objects = append(record.Objects, AuditedObject{EventKey: eventKey, ObjectLabel: match.ObjectLabel, ErasedKeys: sortedKeys(match.ExtraData)})
+			}
+			if len(extraData) == 0 {
+				continue
+			}
+			started := record
+			started.Action = AuditEraseStarted
+			if err := toolkit.record(context, started); err != nil {
+				return erased, err
+			}
+			if err := toolkit.Client.Events.UpdateExtraData(context, eventKey, extraData); err != nil {
+				return erased, fmt.Errorf("erasing extra data in event %s: %w", eventKey, err)
+			}
+			for _, match := range batch {
+				if len(match.ExtraData) > 0 {
+					erased = append(erased, match)
+				}
+			}
+			record.Time = toolkit.now()
+			if err := toolkit.record(context, record); err != nil {
+				return erased, fmt.Errorf("erased extra data in event %s, but %w", eventKey, err)
+			}
+		}
+	}
+	return erased, nil
+}
+
+func matchesPerEvent(matches []Match) [][]Match {
+	var perEvent [][]Match
+	for _, match := range matches {
+		if len(perEvent) == 0 || perEvent[len(perEvent)-1][0].EventKey != match.EventKey {
+			perEvent = append(perEvent, nil)
+		}
+		perEvent[len(perEvent)-1] = append(perEvent[len(perEvent)-1], match)
+	}
+	return perEvent
+}
+
+func sortedKeys(extraData events.ExtraData) []string {
+	keys := make([]string, 0, len(extraData))
+	for key := range extraData {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,41 @@
+package privacy
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Bundle holds all data of a subject, to hand over on a subject access request.
+type Bundle struct {
+	Reference   string    `json:"reference"`
+	GeneratedAt time.Time `json:"generatedAt"`
+	Objects     []Match   `json:"objects"`
+}
+
+// Export finds the data of the subject and records the export in the audit log.
+func (toolkit *Toolkit) Export(context context.Context, subject Subject) (*Bundle, error) {
+	matches, err := toolkit.Find(context, subject)
+	if err != nil {
+		return nil, err
+	}
+	bundle := &Bundle{Reference: subject.Reference, GeneratedAt: toolkit.now(), Objects: matches}
+	if bundle.Objects == nil {
+		bundle.Objects = []Match{}
+	}
+	record := AuditRecord{Time: bundle.GeneratedAt, Action: AuditExport, Reference: subject.Reference, Objects: []AuditedObject{}}
+	for _, match := range matches {
+		record.Objects = append(record.Objects, AuditedObject{EventKey: match.EventKey, ObjectLabel: match.ObjectLabel})
+	}
+	if err := toolkit.record(context, record); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+func (bundle *Bundle) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(bundle)
+}
@@ -0,0 +1,121 @@
+package privacy
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// Toolkit finds, exports and erases the personal data of a customer (a data subject) in the objects of all events of
+// a workspace. Objects belong to a subject when one of their identifying extra data keys holds one of the subject's
+// identifiers, or when they were booked with one of the subject's order ids.
+type Toolkit struct {
+	Client *seatsio.SeatsioClient
+	// Keys are the extra data keys that identify customers, e.g. "email" or "customerId". Identifiers are compared
+	// case-insensitively.
+	Keys []string
+	// Audit receives a record of every export and erasure. Optional.
+	Audit AuditLog
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Subject identifies a customer who invokes their data protection rights.
+type Subject struct {
+	// Reference identifies the request in the audit log, e.g. a ticket number. It should not be personal data itself.
+	Reference   string
+	Identifiers []string
+	OrderIds    []string
+}
+
+// Match is an object that holds data of a subject.
+type Match struct {
+	EventKey    string              `json:"eventKey"`
+	ObjectLabel string              `json:"objectLabel"`
+	Status      events.ObjectStatus `json:"status"`
+	OrderId     string              `json:"orderId,omitempty"`
+	ExtraData   events.ExtraData    `json:"extraData,omitempty"`
+	// MatchedOn is the extra data key that holds an identifier of the subject, or "orderId".
+	MatchedOn []string `json:"matchedOn"`
+}
+
+const MatchedOnOrderId = "orderId"
+
+// Find returns the objects of all events that hold data of the subject, ordered by event key and object label.
+func (toolkit *Toolkit) Find(context context.Context, subject Subject) ([]Match, error) {
+	allEvents, err := toolkit.Client.Events.ListAll(context)
+	if err != nil {
+		return nil, fmt.Errorf("listing events: %w", err)
+	}
+	sort.Slice(allEvents, func(i, j int) bool {
+		return allEvents[i].Key < allEvents[j].Key
+	})
+	var matches []Match
+	for _, event := range allEvents {
+		report, err := toolkit.Client.EventReports.ByLabel(context, event.Key)
+		if err != nil {
+			return nil, fmt.Errorf("reading the objects of event %s: %w", event.Key, err)
+		}
+		labels := make([]string, 0, len(report.Items))
+		for label := range report.Items {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			for _, object := range report.Items[label] {
+				if matchedOn := toolkit.matchedOn(subject, object); len(matchedOn) > 0 {
+					matches = append(matches, Match{
+						EventKey:    event.Key,
+						ObjectLabel: label,
+						Status:      object.ObjectStatus(),
+						OrderId:     object.OrderId,
+						ExtraData:   object.ExtraData,
+						MatchedOn:   matchedOn,
+					})
+				}
+			}
+		}
+	}
+	return matches, nil
+}
+
+func (toolkit *Toolkit) matchedOn(subject Subject, object events.EventObjectInfo) []string {
+	var matchedOn []string
+	for _, key := range toolkit.Keys {
+		value, ok := object.ExtraData[key]
+		if !ok || value == nil {
+			continue
+		}
+		text := fmt.Sprint(value)
+		if slices.ContainsFunc(subject.Identifiers, func(identifier string) bool { return strings.EqualFold(identifier, text) }) {
+			matchedOn = append(matchedOn, key)
+		}
+	}
+	if object.OrderId != "" && slices.Contains(subject.OrderIds, object.OrderId) {
+		matchedOn = append(matchedOn, MatchedOnOrderId)
+	}
+	return matchedOn
+}
+
+func (toolkit *Toolkit) now() time.Time {
+	if toolkit.Now == nil {
+		return time.Now()
+	}
+	return toolkit.Now()
+}
+
+func (toolkit *Toolkit) record(context context.Context, record AuditRecord) error {
+	if toolkit.Audit == nil {
+		return nil
+	}
+	if err := toolkit.Audit.Record(context, record); err != nil {
+		return fmt.Errorf("writing the audit record: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package privacy_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/privacy"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+// book changes the status of objects of an event, with an order id and extra data per object.
+func book(t *testing.T, client *seatsio.SeatsioClient, eventKey string, status string, orderId string, objects ...events.ObjectProperties) {
+	_, err := client.Events.ChangeObjectStatusWithOptions(test_util.RequestContext(), &events.StatusChangeParams{
+		Events:        []string{eventKey},
+		StatusChanges: events.StatusChanges{Status: status, Objects: objects, OrderId: orderId},
+	})
+	require.NoError(t, err)
+}
+
+func object(t *testing.T, client *seatsio.SeatsioClient, eventKey string, label string) events.EventObjectInfo {
+	objects, err := client.Events.RetrieveObjectInfo(test_util.RequestContext(), eventKey, label)
+	require.NoError(t, err)
+	return objects[label]
+}
+
+// newWorkspace creates two events that hold data of several customers, and counts the extra data updates.
+func newWorkspace(t *testing.T) (*seatsio.SeatsioClient, *atomic.Int32) {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	for _, eventKey := range []string{"event1", "event2"} {
+		_, err := client.Events.Create(test_util.RequestContext(), &events.CreateEventParams{ChartKey: chartKey, EventParams: &events.EventParams{EventKey: eventKey}})
+		require.NoError(t, err)
+	}
+	book(t, client, "event1", events.BOOKED, "order1", events.ObjectProperties{ObjectId: "A-1", ExtraData: events.ExtraData{"email": "Ada@Example.com", "name": "Ada"}})
+	book(t, client, "event1", events.BOOKED, "order2", events.ObjectProperties{ObjectId: "A-2", ExtraData: events.ExtraData{"email": "grace@example.com"}})
+	book(t, client, "event2", "checked-in", "order3", events.ObjectProperties{ObjectId: "B-1", ExtraData: events.ExtraData{"customerId": 42}})
+	book(t, client, "event2", events.BOOKED, "order4", events.ObjectProperties{ObjectId: "B-2"})
+
+	updates := &atomic.Int32{}
+	client.Events.Client.OnAfterResponse(func(_ *req.Client, response *req.Response) error {
+		if response.Request != nil && response.Request.RawRequest != nil && strings.HasSuffix(response.Request.RawRequest.URL.Path, "/actions/update-extra-data") {
+			updates.Add(1)
+		}
+		return nil
+	})
+	return client, updates
+}
+
+// failingAuditLog fails to write records of one action.
+type failingAuditLog struct {
+	failOn  privacy.AuditAction
+	records []privacy.AuditRecord
+}
+
+func (log *failingAuditLog) Record(_ context.Context, record privacy.AuditRecord) error {
+	if record.Action == log.failOn {
+		return errors.New("disk full")
+	}
+	log.records = append(log.records, record)
+	return nil
+}
+
+var now = time.Date(2024, 5, 25, 12, 0, 0, 0, time.UTC)
+
+func newToolkit(client *seatsio.SeatsioClient, audit privacy.AuditLog) *privacy.Toolkit {
+	return &privacy.Toolkit{
+		Client: client,
+		Keys:   []string{"email", "customerId"},
+		Audit:  audit,
+		Now:    func() time.Time { return now },
+	}
+}
+
+var ada = privacy.Subject{Reference: "DSR-1", Identifiers: []string{"ada@example.com", "42"}, OrderIds: []string{"order4"}}
+
+func TestFind(t *testing.T) {
+	t.Parallel()
+	client, _ := newWorkspace(t)
+
+	matches, err := newToolkit(client, nil).Find(test_util.RequestContext(), ada)
+
+	require.NoError(t, err)
+	require.Equal(t, []privacy.Match{
+		{EventKey: "event1", ObjectLabel: "A-1", Status: events.BOOKED, OrderId: "order1", ExtraData: events.ExtraData{"email": "Ada@Example.com", "name": "Ada"}, MatchedOn: []string{"email"}},
+		{EventKey: "event2", ObjectLabel: "B-1", Status: "checked-in", OrderId: "order3", ExtraData: events.ExtraData{"customerId": float64(42)}, MatchedOn: []string{"customerId"}},
+		{EventKey: "event2", ObjectLabel: "B-2", Status: events.BOOKED, OrderId: "order4", MatchedOn: []string{privacy.MatchedOnOrderId}},
+	}, matches)
+}
+
+func TestExport(t *testing.T) {
+	t.Parallel()
+	client, _ := newWorkspace(t)
+	var audit bytes.Buffer
+
+	bundle, err := newToolkit(client, &privacy.JSONLinesAuditLog{Writer: &audit}).Export(test_util.RequestContext(), ada)
+	require.NoError(t, err)
+	require.Equal(t, "DSR-1", bundle.Reference)
+	require.Len(t, bundle.Objects, 3)
+
+	var exported bytes.Buffer
+	require.NoError(t, bundle.WriteJSON(&exported))
+	require.Contains(t, exported.String(), `"email": "Ada@Example.com"`)
+
+	var record privacy.AuditRecord
+	require.NoError(t, json.Unmarshal(audit.Bytes(), &record))
+	require.Equal(t, privacy.AuditExport, record.Action)
+	require.Len(t, record.Objects, 3)
+	require.NotContains(t, audit.String(), "Ada")
+}
+
+func TestErase(t *testing.T) {
+	t.Parallel()
+	client, updates := newWorkspace(t)
+	var audit bytes.Buffer
+	toolkit := newToolkit(client, &privacy.JSONLinesAuditLog{Writer: &audit})
+
+	erased, err := toolkit.Erase(test_util.RequestContext(), ada)
+	require.NoError(t, err)
+	require.Len(t, erased, 2)
+
+	require.Empty(t, object(t, client, "event1", "A-1").ExtraData)
+	require.Equal(t, events.BOOKED, object(t, client, "event1", "A-1").Status)
+	require.Equal(t, "order1", object(t, client, "event1", "A-1").OrderId)
+	require.Equal(t, events.ExtraData{"email": "grace@example.com"}, object(t, client, "event1", "A-2").ExtraData)
+	require.Empty(t, object(t, client, "event2", "B-1").ExtraData)
+	require.Equal(t, int32(2), updates.Load())
+
+	lines := strings.Split(strings.TrimSpace(audit.String()), "\n")
+	require.Len(t, lines, 4)
+	for i, action := range []privacy.AuditAction{privacy.AuditEraseStarted, privacy.AuditErase} {
+		var record privacy.AuditRecord
+		require.NoError(t, json.Unmarshal([]byte(lines[i]), &record))
+		require.Equal(t, privacy.AuditRecord{
+			Time:      now,
+			Action:    action,
+			Reference: "DSR-1",
+			Objects:   []privacy.AuditedObject{{EventKey: "event1", ObjectLabel: "A-1", ErasedKeys: []string{"email", "name"}}},
+		}, record)
+	}
+
+	erased, err = toolkit.Erase(test_util.RequestContext(), ada)
+	require.NoError(t, err)
+	require.Empty(t, erased)
+	require.Equal(t, int32(2), updates.Load())
+}
+
+func TestEraseDoesNotEraseWhenItCannotBeAudited(t *testing.T) {
+	t.Parallel()
+	client, updates := newWorkspace(t)
+
+	erased, err := newToolkit(client, &failingAuditLog{failOn: privacy.AuditEraseStarted}).Erase(test_util.RequestContext(), ada)
+
+	require.EqualError(t, err, "writing the audit record: disk full")
+	require.Empty(t, erased)
+	require.Equal(t, int32(0), updates.Load())
+	require.Equal(t, "Ada", object(t, client, "event1", "A-1").ExtraData["name"])
+}
+
+func TestEraseReturnsWhatWasErasedWhenItCannotBeRecorded(t *testing.T) {
+	t.Parallel()
+	client, _ := newWorkspace(t)
+	audit := &failingAuditLog{failOn: privacy.AuditErase}
+
+	erased, err := newToolkit(client, audit).Erase(test_util.RequestContext(), ada)
+
+	require.EqualError(t, err, "erased extra data in event event1, but writing the audit record: disk full")
+	require.Len(t, erased, 1)
+	require.Equal(t, "A-1", erased[0].ObjectLabel)
+	require.Empty(t, object(t, client, "event1", "A-1").ExtraData)
+	require.Len(t, audit.records, 1)
+	require.Equal(t, privacy.AuditEraseStarted, audit.records[0].Action)
+}
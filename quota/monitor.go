@@ -0,0 +1,223 @@
+package quota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/reports"
+)
+
+// Threshold is a number of used objects in a month, for one workspace or for all workspaces together.
+type Threshold struct {
+	// Name describes the threshold in alerts, e.g. "80% of quota". Optional.
+	Name string `json:"name,omitempty"`
+	// Workspace is the id of the workspace, or AllWorkspaces.
+	Workspace      int64 `json:"workspace"`
+	NumUsedObjects int   `json:"numUsedObjects"`
+}
+
+const AllWorkspaces int64 = 0
+
+func (threshold Threshold) key() string {
+	return fmt.Sprintf("%d/%d", threshold.Workspace, threshold.NumUsedObjects)
+}
+
+// Alert is sent when the usage of a month reaches a threshold.
+type Alert struct {
+	Month          reports.Month `json:"month"`
+	Threshold      Threshold     `json:"threshold"`
+	NumUsedObjects int           `json:"numUsedObjects"`
+	Time           time.Time     `json:"time"`
+}
+
+func (alert Alert) String() string {
+	scope := "all workspaces"
+	if alert.Threshold.Workspace != AllWorkspaces {
+		scope = fmt.Sprintf("workspace %d", alert.Threshold.Workspace)
+	}
+	name := ""
+	if alert.Threshold.Name != "" {
+		name = " (" + alert.Threshold.Name + ")"
+	}
+	return fmt.Sprintf("%s used %d objects in %s, reaching the threshold of %d%s", scope, alert.NumUsedObjects, alert.Month, alert.Threshold.NumUsedObjects, name)
+}
+
+// Monitor compares the usage of the current month with thresholds, and notifies when one is reached. Every threshold
+// is alerted at most once per month by every notifier: the alerts that were sent are kept in the State, per threshold
+// and notifier, so that they are not sent again after a restart. An alert that a notifier fails to send is retried on
+// the next check, by that notifier only. Notifiers are identified by their position in Notifiers.
+type Monitor struct {
+	Client     *seatsio.SeatsioClient
+	Thresholds []Threshold
+	Notifiers  []Notifier
+	// State defaults to a MemoryState, which does not survive a restart.
+	State State
+	// Interval is how often Run checks the usage. Defaults to an hour.
+	Interval time.Duration
+	// OnError receives the errors of the checks done by Run, which keeps running after them. Optional.
+	OnError func(err error)
+	// Now defaults to time.Now.
+	Now func() time.Time
+
+	mutex sync.Mutex
+}
+
+// Run checks the usage until the context is done.
+func (monitor *Monitor) Run(context context.Context) error {
+	interval := monitor.Interval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := monitor.Check(context); err != nil && context.Err() == nil && monitor.OnError != nil {
+			monitor.OnError(err)
+		}
+		select {
+		case <-ticker.C:
+		case <-context.Done():
+			return context.Err()
+		}
+	}
+}
+
+// Check reads the usage of the current month, and sends the alerts for the thresholds that were reached since the
+// previous check, or that a notifier failed to send before. It returns the alerts that were sent by at least one
+// notifier.
+func (monitor *Monitor) Check(context context.Context) ([]Alert, error) {
+	monitor.mutex.Lock()
+	defer monitor.mutex.Unlock()
+
+	month, usage, err := monitor.usage(context)
+	if err != nil {
+		return nil, err
+	}
+	sent, err := monitor.state().Load(context)
+	if err != nil {
+		return nil, fmt.Errorf("loading the quota monitor state: %w", err)
+	}
+	if sent.Month != month || sent.Sent == nil {
+		sent = SentAlerts{Month: month, Sent: map[string]time.Time{}}
+	}
+
+	var alerts []Alert
+	var errs []error
+	for _, threshold := range monitor.sortedThresholds() {
+		numUsedObjects := usage[threshold.Workspace]
+		if numUsedObjects < threshold.NumUsedObjects {
+			continue
+		}
+		alert := Alert{Month: month, Threshold: threshold, NumUsedObjects: numUsedObjects, Time: monitor.now()}
+		notified := false
+		for i, key := range monitor.sentKeys(threshold) {
+			if _, ok := sent.Sent[key]; ok {
+				continue
+			}
+			if i < len(monitor.Notifiers) {
+				if err := monitor.Notifiers[i].Notify(context, alert); err != nil {
+					errs = append(errs, fmt.Errorf("sending alert %q: %w", alert, err))
+					continue
+				}
+			}
+			sent.Sent[key] = alert.Time
+			notified = true
+		}
+		if notified {
+			alerts = append(alerts, alert)
+		}
+	}
+	if len(alerts) > 0 {
+		if err := monitor.state().Save(context, sent); err != nil {
+			errs = append(errs, fmt.Errorf("saving the quota monitor state: %w", err))
+		}
+	}
+	return alerts, errors.Join(errs...)
+}
+
+// usage returns the month that is being used, and the number of used objects in it per workspace, and for all
+// workspaces under AllWorkspaces.
+func (monitor *Monitor) usage(context context.Context) (reports.Month, map[int64]int, error) {
+	summary, err := monitor.Client.UsageReports.SummaryForAllMonths(context)
+	if err != nil {
+		return reports.Month{}, nil, fmt.Errorf("reading the usage summary: %w", err)
+	}
+	asOf := monitor.now()
+	if summary.UsageCutoffDate != nil {
+		asOf = *summary.UsageCutoffDate
+	}
+	month := reports.MonthOf(asOf.UTC())
+	usage := map[int64]int{AllWorkspaces: 0}
+	for _, monthUsage := range summary.Usage {
+		if monthUsage.Month == month {
+			usage[AllWorkspaces] = monthUsage.NumUsedObjects
+		}
+	}
+	if !monitor.hasWorkspaceThresholds() {
+		return month, usage, nil
+	}
+	details, err := monitor.Client.UsageReports.DetailsForMonth(context, month.Year, month.Month)
+	if err != nil {
+		return reports.Month{}, nil, fmt.Errorf("reading the usage of %s: %w", month, err)
+	}
+	table := &reports.UsageTable{}
+	table.Add(month, details)
+	for _, total := range table.Totals(reports.GroupByWorkspace) {
+		usage[total.Workspace] = total.NumUsedObjects
+	}
+	return month, usage, nil
+}
+
+func (monitor *Monitor) hasWorkspaceThresholds() bool {
+	for _, threshold := range monitor.Thresholds {
+		if threshold.Workspace != AllWorkspaces {
+			return true
+		}
+	}
+	return false
+}
+
+// sentKeys returns the keys under which the State records that the notifiers sent the alert of a threshold, one per
+// notifier. Without notifiers, the alert is only recorded under the threshold.
+func (monitor *Monitor) sentKeys(threshold Threshold) []string {
+	if len(monitor.Notifiers) == 0 {
+		return []string{threshold.key()}
+	}
+	keys := make([]string, len(monitor.Notifiers))
+	for i := range monitor.Notifiers {
+		keys[i] = fmt.Sprintf("%s/%d", threshold.key(), i)
+	}
+	return keys
+}
+
+func (monitor *Monitor) state() State {
+	if monitor.State == nil {
+		monitor.State = &MemoryState{}
+	}
+	return monitor.State
+}
+
+func (monitor *Monitor) now() time.Time {
+	if monitor.Now == nil {
+		return time.Now()
+	}
+	return monitor.Now()
+}
+
+// sortedThresholds orders the thresholds per workspace, lowest first, so that the alerts of one check are sent in
+// the order the thresholds were reached.
+func (monitor *Monitor) sortedThresholds() []Threshold {
+	thresholds := append([]Threshold(nil), monitor.Thresholds...)
+	sort.SliceStable(thresholds, func(i, j int) bool {
+		if thresholds[i].Workspace != thresholds[j].Workspace {
+			return thresholds[i].Workspace < thresholds[j].Workspace
+		}
+		return thresholds[i].NumUsedObjects < thresholds[j].NumUsedObjects
+	})
+	return thresholds
+}
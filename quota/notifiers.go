@@ -0,0 +1,79 @@
+package quota
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+)
+
+type Notifier interface {
+	Notify(context context.Context, alert Alert) error
+}
+
+// Func adapts a callback to a Notifier.
+type Func func(context context.Context, alert Alert) error
+
+func (f Func) Notify(context context.Context, alert Alert) error {
+	return f(context, alert)
+}
+
+// Slog logs alerts at warning level, with the details of the alert as attributes.
+type Slog struct {
+	// Logger defaults to slog.Default().
+	Logger *slog.Logger
+}
+
+func (notifier Slog) Notify(context context.Context, alert Alert) error {
+	logger := notifier.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	logger.WarnContext(context, "seats.io usage threshold reached",
+		slog.String("month", alert.Month.String()),
+		slog.Int64("workspace", alert.Threshold.Workspace),
+		slog.String("threshold", alert.Threshold.Name),
+		slog.Int("thresholdNumUsedObjects", alert.Threshold.NumUsedObjects),
+		slog.Int("numUsedObjects", alert.NumUsedObjects),
+	)
+	return nil
+}
+
+// Webhook POSTs alerts as JSON to a URL. Responses other than 2xx are errors.
+type Webhook struct {
+	URL string
+	// Header is added to every request, e.g. for authentication. Optional.
+	Header http.Header
+	// Client defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+func (webhook Webhook) Notify(context context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	request, err := http.NewRequestWithContext(context, http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for name, values := range webhook.Header {
+		request.Header[name] = values
+	}
+	request.Header.Set("Content-Type", "application/json")
+	client := webhook.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook %s responded with %s", webhook.URL, response.Status)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package quota
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/reports"
+)
+
+// SentAlerts are the alerts that were sent in a month, by threshold and notifier.
+type SentAlerts struct {
+	Month reports.Month        `json:"month"`
+	Sent  map[string]time.Time `json:"sent"`
+}
+
+// State keeps the alerts that were sent. Load returns empty SentAlerts when nothing was saved yet.
+type State interface {
+	Load(context context.Context) (SentAlerts, error)
+	Save(context context.Context, sent SentAlerts) error
+}
+
+type MemoryState struct {
+	mutex sync.Mutex
+	sent  SentAlerts
+}
+
+func (memory *MemoryState) Load(_ context.Context) (SentAlerts, error) {
+	memory.mutex.Lock()
+	defer memory.mutex.Unlock()
+	return copySentAlerts(memory.sent), nil
+}
+
+func (memory *MemoryState) Save(_ context.Context, sent SentAlerts) error {
+	memory.mutex.Lock()
+	defer memory.mutex.Unlock()
+	memory.sent = copySentAlerts(sent)
+	return nil
+}
+
+func copySentAlerts(sent SentAlerts) SentAlerts {
+	copied := SentAlerts{Month: sent.Month, Sent: make(map[string]time.Time, len(sent.Sent))}
+	for key, at := range sent.Sent {
+		copied.Sent[key] = at
+	}
+	return copied
+}
+
+// FileState keeps the alerts that were sent in a JSON file. The file is replaced atomically, so that a crash while
+// saving does not lose the alerts that were sent before.
+type FileState struct {
+	Path string
+}
+
+func (file FileState) Load(_ context.Context) (SentAlerts, error) {
+	var sent SentAlerts
+	content, err := os.ReadFile(file.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return sent, nil
+	}
+	if err != nil {
+		return sent, err
+	}
+	err = json.Unmarshal(content, &sent)
+	return sent, err
+}
+
+func (file FileState) Save(_ context.Context, sent SentAlerts) error {
+	content, err := json.MarshalIndent(sent, "", "  ")
+	if err != nil {
+		return err
+	}
+	temp, err := os.CreateTemp(filepath.Dir(file.Path), filepath.Base(file.Path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temp.Name())
+	if _, err := temp.Write(content); err != nil {
+		temp.Close()
+		return err
+	}
+	if err := temp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(temp.Name(), file.Path)
+}
@@ -0,0 +1,227 @@
+package quota_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/quota"
+	"github.com/seatsio/seatsio-go/v12/reports"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeUsage struct {
+	mutex      sync.Mutex
+	month      reports.Month
+	total      int
+	workspace2 int
+}
+
+func (usage *fakeUsage) set(month reports.Month, total int, workspace2 int) {
+	usage.mutex.Lock()
+	defer usage.mutex.Unlock()
+	usage.month, usage.total, usage.workspace2 = month, total, workspace2
+}
+
+// newFakeUsageApi serves usage that the test controls, to make it cross thresholds and move to the next month, which
+// the usage of a test company never does. The other tests run against the test server.
+func newFakeUsageApi(t *testing.T) (*fakeUsage, *seatsio.SeatsioClient) {
+	usage := &fakeUsage{month: may}
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		usage.mutex.Lock()
+		defer usage.mutex.Unlock()
+		writer.Header().Set("Content-Type", "application/json")
+		switch request.URL.Path {
+		case "/reports/usage":
+			_, _ = fmt.Fprintf(writer, `{"usage": [{"month": {"year": %d, "month": %d}, "numUsedObjects": %d}], "usageCutoffDate": "%d-%02d-10T00:00:00Z"}`,
+				usage.month.Year, usage.month.Month, usage.total, usage.month.Year, usage.month.Month)
+		case "/reports/usage/month/" + usage.month.String():
+			_, _ = fmt.Fprintf(writer, `[
+				{"workspace": 1, "usageByChart": [{"chart": {"key": "c1"}, "usageByEvent": [{"event": {"id": 1}, "numUsedObjects": %d}]}]},
+				{"workspace": 2, "usageByChart": [{"chart": {"key": "c2"}, "usageByEvent": [{"event": {"id": 2}, "numUsedObjects": %d}]}]}
+			]`, usage.total-usage.workspace2, usage.workspace2)
+		default:
+			writer.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return usage, seatsio.NewSeatsioClient(server.URL, "secretKey")
+}
+
+var may, june = reports.Month{Year: 2024, Month: 5}, reports.Month{Year: 2024, Month: 6}
+
+var thresholds = []quota.Threshold{
+	{Name: "quota", Workspace: quota.AllWorkspaces, NumUsedObjects: 1000},
+	{Name: "80% of quota", Workspace: quota.AllWorkspaces, NumUsedObjects: 800},
+	{Workspace: 2, NumUsedObjects: 300},
+}
+
+// newTestCompanyClient returns a client of a new company. Its usage is 0, so only thresholds of 0 are reached.
+func newTestCompanyClient(t *testing.T) *seatsio.SeatsioClient {
+	company := test_util.CreateTestCompany(t)
+	return seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+}
+
+var zeroUsageThresholds = []quota.Threshold{
+	{Name: "anything", Workspace: quota.AllWorkspaces, NumUsedObjects: 0},
+	{Name: "unreachable", Workspace: quota.AllWorkspaces, NumUsedObjects: 1000000},
+}
+
+type recorder struct {
+	mutex  sync.Mutex
+	alerts []quota.Alert
+}
+
+func (recorder *recorder) notify(_ context.Context, alert quota.Alert) error {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	recorder.alerts = append(recorder.alerts, alert)
+	return nil
+}
+
+func newMonitor(client *seatsio.SeatsioClient, state quota.State, notifiers ...quota.Notifier) *quota.Monitor {
+	return &quota.Monitor{Client: client, Thresholds: thresholds, Notifiers: notifiers, State: state}
+}
+
+func reachedThresholds(alerts []quota.Alert) []quota.Threshold {
+	var reached []quota.Threshold
+	for _, alert := range alerts {
+		reached = append(reached, alert.Threshold)
+	}
+	return reached
+}
+
+func TestAlertsAreSentOncePerMonth(t *testing.T) {
+	t.Parallel()
+	usage, client := newFakeUsageApi(t)
+	recorded := &recorder{}
+	monitor := newMonitor(client, &quota.MemoryState{}, quota.Func(recorded.notify))
+
+	usage.set(may, 500, 100)
+	alerts, err := monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+
+	usage.set(may, 1100, 350)
+	alerts, err = monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Equal(t, []quota.Threshold{thresholds[1], thresholds[0], thresholds[2]}, reachedThresholds(alerts))
+	require.Equal(t, 1100, alerts[0].NumUsedObjects)
+	require.Equal(t, 350, alerts[2].NumUsedObjects)
+	require.Equal(t, may, alerts[0].Month)
+	require.Equal(t, alerts, recorded.alerts)
+
+	alerts, err = monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+
+	usage.set(june, 900, 0)
+	alerts, err = monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Equal(t, []quota.Threshold{thresholds[1]}, reachedThresholds(alerts))
+	require.Len(t, recorded.alerts, 4)
+}
+
+func TestSentAlertsSurviveARestart(t *testing.T) {
+	t.Parallel()
+	client := newTestCompanyClient(t)
+	state := quota.FileState{Path: filepath.Join(t.TempDir(), "quota.json")}
+	newMonitor := func() *quota.Monitor {
+		return &quota.Monitor{Client: client, Thresholds: zeroUsageThresholds, State: state}
+	}
+
+	alerts, err := newMonitor().Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Equal(t, []quota.Threshold{zeroUsageThresholds[0]}, reachedThresholds(alerts))
+
+	alerts, err = newMonitor().Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+}
+
+func TestFailedAlertsAreRetried(t *testing.T) {
+	t.Parallel()
+	client := newTestCompanyClient(t)
+	failing := true
+	notifier := quota.Func(func(_ context.Context, alert quota.Alert) error {
+		if failing {
+			return errors.New("unreachable")
+		}
+		return nil
+	})
+	monitor := &quota.Monitor{Client: client, Thresholds: zeroUsageThresholds, Notifiers: []quota.Notifier{notifier}}
+
+	alerts, err := monitor.Check(test_util.RequestContext())
+	require.ErrorContains(t, err, "unreachable")
+	require.Empty(t, alerts)
+
+	failing = false
+	alerts, err = monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+}
+
+func TestOnlyFailedNotifiersAreRetried(t *testing.T) {
+	t.Parallel()
+	client := newTestCompanyClient(t)
+	recorded := &recorder{}
+	failing := true
+	var failingAttempts int
+	notifier := quota.Func(func(_ context.Context, alert quota.Alert) error {
+		failingAttempts++
+		if failing {
+			return errors.New("unreachable")
+		}
+		return nil
+	})
+	monitor := &quota.Monitor{Client: client, Thresholds: zeroUsageThresholds, Notifiers: []quota.Notifier{quota.Func(recorded.notify), notifier}}
+
+	alerts, err := monitor.Check(test_util.RequestContext())
+	require.ErrorContains(t, err, "unreachable")
+	require.Len(t, alerts, 1)
+
+	failing = false
+	alerts, err = monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+
+	alerts, err = monitor.Check(test_util.RequestContext())
+	require.NoError(t, err)
+	require.Empty(t, alerts)
+	require.Len(t, recorded.alerts, 1)
+	require.Equal(t, 2, failingAttempts)
+}
+
+func TestWebhook(t *testing.T) {
+	t.Parallel()
+	client := newTestCompanyClient(t)
+	received := make(chan quota.Alert, 1)
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		require.Equal(t, "Bearer token", request.Header.Get("Authorization"))
+		var alert quota.Alert
+		_ = json.NewDecoder(request.Body).Decode(&alert)
+		received <- alert
+	}))
+	t.Cleanup(webhookServer.Close)
+	webhook := quota.Webhook{URL: webhookServer.URL, Header: http.Header{"Authorization": {"Bearer token"}}}
+
+	monitor := &quota.Monitor{Client: client, Thresholds: zeroUsageThresholds, Notifiers: []quota.Notifier{webhook}}
+
+	alerts, err := monitor.Check(test_util.RequestContext())
+
+	require.NoError(t, err)
+	alert := <-received
+	require.Equal(t, alerts[0].Month, alert.Month)
+	require.Equal(t, zeroUsageThresholds[0], alert.Threshold)
+	require.Equal(t, 0, alert.NumUsedObjects)
+	require.WithinDuration(t, time.Now(), alert.Time, time.Minute)
+}
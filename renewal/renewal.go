@@ -0,0 +1,297 @@
+package renewal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+// Renewal gives the season ticket holders of a previous season the same seats in a new season. It runs in phases:
+// Plan maps the booked seats of the previous season onto the new season, Hold holds them for every holder during a
+// renewal window, Claim books the seats of a holder who renewed, and ReleaseUnclaimed releases the seats that were
+// not claimed when the window closes, so that they go on general sale. Every phase returns a per-holder report.
+//
+// Seats are held with a hold token per holder, which expires at the end of the window, or, when Status is set, by
+// changing their status to a custom status (such as "renewal") with the holder's order id, which does not expire.
+type Renewal struct {
+	Client         *seatsio.SeatsioClient
+	PreviousSeason string
+	Season         string
+	// LabelMapping maps labels of the previous season to labels of the new season, for areas that were redesigned.
+	// Labels that are not mapped stay the same.
+	LabelMapping map[string]string
+	// RenewedStatuses are the statuses of the seats of the previous season that are renewed. Defaults to booked.
+	RenewedStatuses []events.ObjectStatus
+	// Status holds the seats with a custom status instead of hold tokens.
+	Status events.ObjectStatus
+	// Window is how long hold tokens last.
+	Window time.Duration
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+// Plan reads the seats of the previous season and their order ids, and checks whether their counterparts are free in
+// the new season. It changes nothing.
+func (renewal *Renewal) Plan(context context.Context) (*Report, error) {
+	previous, err := renewal.previousSeats(context)
+	if err != nil {
+		return nil, err
+	}
+	current, err := renewal.seasonObjects(context)
+	if err != nil {
+		return nil, err
+	}
+	holders := map[string]*HolderReport{}
+	for _, object := range previous {
+		holder, ok := holders[object.OrderId]
+		if !ok {
+			holder = &HolderReport{OrderId: object.OrderId}
+			holders[object.OrderId] = holder
+		}
+		holder.Seats = append(holder.Seats, renewal.planSeat(object, current))
+	}
+	report := renewal.newReport(PhasePlan)
+	for _, holder := range holders {
+		sort.Slice(holder.Seats, func(i, j int) bool { return holder.Seats[i].PreviousLabel < holder.Seats[j].PreviousLabel })
+		report.Holders = append(report.Holders, *holder)
+	}
+	sort.Slice(report.Holders, func(i, j int) bool { return report.Holders[i].OrderId < report.Holders[j].OrderId })
+	return report, nil
+}
+
+func (renewal *Renewal) planSeat(object events.EventObjectInfo, current map[string]events.EventObjectInfo) Seat {
+	seat := Seat{PreviousLabel: object.Label, Label: renewal.mapLabel(object.Label)}
+	counterpart, onChart := current[seat.Label]
+	switch {
+	case object.ObjectType == "generalAdmission":
+		seat.Outcome = GeneralAdmission
+	case object.OrderId == "":
+		seat.Outcome = NoHolder
+	case !onChart:
+		seat.Outcome = NotOnChart
+	case counterpart.Status != events.FREE:
+		seat.Outcome = Unavailable
+	default:
+		seat.Outcome = Renewable
+	}
+	return seat
+}
+
+func (renewal *Renewal) mapLabel(label string) string {
+	if mapped, ok := renewal.LabelMapping[label]; ok {
+		return mapped
+	}
+	return label
+}
+
+func (renewal *Renewal) previousSeats(context context.Context) ([]events.EventObjectInfo, error) {
+	statuses := renewal.RenewedStatuses
+	if len(statuses) == 0 {
+		statuses = []events.ObjectStatus{events.BOOKED}
+	}
+	var seats []events.EventObjectInfo
+	for _, status := range statuses {
+		objects, err := renewal.Client.EventReports.BySpecificStatus(context, renewal.PreviousSeason, status.String())
+		if err != nil {
+			return nil, fmt.Errorf("reading the %s seats of season %s: %w", status, renewal.PreviousSeason, err)
+		}
+		seats = append(seats, objects...)
+	}
+	return seats, nil
+}
+
+func (renewal *Renewal) seasonObjects(context context.Context) (map[string]events.EventObjectInfo, error) {
+	report, err := renewal.Client.EventReports.ByLabel(context, renewal.Season)
+	if err != nil {
+		return nil, fmt.Errorf("reading the objects of season %s: %w", renewal.Season, err)
+	}
+	objects := make(map[string]events.EventObjectInfo, len(report.Items))
+	for label, infos := range report.Items {
+		if len(infos) > 0 {
+			objects[label] = infos[0]
+		}
+	}
+	return objects, nil
+}
+
+// Hold holds the renewable seats of the plan, per holder. The seats of a holder are held together or not at all, for
+// instance when one of them was sold since the plan was made; such holders are reported with an error, and the other
+// holders are still held.
+func (renewal *Renewal) Hold(context context.Context, plan *Report) (*Report, error) {
+	if renewal.Status == "" && renewal.Window <= 0 {
+		return nil, errors.New("a renewal window is needed to hold seats with hold tokens")
+	}
+	report := renewal.newReport(PhaseHold)
+	for _, planned := range plan.Holders {
+		holder := HolderReport{OrderId: planned.OrderId, Seats: append([]Seat(nil), planned.Seats...)}
+		labels := labelsWithOutcome(holder.Seats, Renewable)
+		if len(labels) > 0 {
+			if err := renewal.hold(context, &holder, labels); err != nil {
+				holder.Error = err.Error()
+				setOutcome(holder.Seats, Renewable, Failed)
+			} else {
+				setOutcome(holder.Seats, Renewable, Held)
+			}
+		}
+		report.Holders = append(report.Holders, holder)
+	}
+	return report, context.Err()
+}
+
+func (renewal *Renewal) hold(context context.Context, holder *HolderReport, labels []string) error {
+	params := &events.StatusChangeParams{
+		Events: []string{renewal.Season},
+		StatusChanges: events.StatusChanges{
+			Objects:                 objectProperties(labels),
+			OrderId:                 holder.OrderId,
+			AllowedPreviousStatuses: []string{events.FREE},
+		},
+	}
+	if renewal.Status != "" {
+		params.Status = renewal.Status.String()
+		_, err := renewal.Client.Events.ChangeObjectStatusWithOptions(context, params)
+		return err
+	}
+	holdToken, err := renewal.Client.HoldTokens.CreateWithExpiration(context, int(math.Ceil(renewal.Window.Minutes())))
+	if err != nil {
+		return fmt.Errorf("creating a hold token: %w", err)
+	}
+	params.HoldToken = holdToken.HoldToken
+	if _, err := renewal.Client.Events.HoldWithOptions(context, params); err != nil {
+		return err
+	}
+	holder.HoldToken = holdToken.HoldToken
+	holder.ExpiresAt = holdToken.ExpiresAt
+	return nil
+}
+
+// Claim books the held seats of a holder who renewed.
+func (renewal *Renewal) Claim(context context.Context, holds *Report, orderId string) (*HolderReport, error) {
+	held, ok := holds.Holder(orderId)
+	if !ok {
+		return nil, fmt.Errorf("order %s is not in the renewal", orderId)
+	}
+	holder := HolderReport{OrderId: orderId, Seats: append([]Seat(nil), held.Seats...), HoldToken: held.HoldToken}
+	labels := labelsWithOutcome(holder.Seats, Held)
+	if len(labels) == 0 {
+		return &holder, nil
+	}
+	params := &events.StatusChangeParams{
+		Events: []string{renewal.Season},
+		StatusChanges: events.StatusChanges{
+			Objects:   objectProperties(labels),
+			OrderId:   orderId,
+			HoldToken: held.HoldToken,
+		},
+	}
+	if renewal.Status != "" {
+		params.AllowedPreviousStatuses = events.StatusStrings(renewal.Status)
+	}
+	if _, err := renewal.Client.Events.BookWithOptions(context, params); err != nil {
+		holder.Error = err.Error()
+		return &holder, err
+	}
+	setOutcome(holder.Seats, Held, Claimed)
+	return &holder, nil
+}
+
+// ReleaseUnclaimed releases the seats that are still held for their holder. Seats that were booked since they were
+// held are reported as claimed.
+func (renewal *Renewal) ReleaseUnclaimed(context context.Context, holds *Report) (*Report, error) {
+	current, err := renewal.seasonObjects(context)
+	if err != nil {
+		return nil, err
+	}
+	report := renewal.newReport(PhaseRelease)
+	for _, held := range holds.Holders {
+		holder := HolderReport{OrderId: held.OrderId, Seats: append([]Seat(nil), held.Seats...), HoldToken: held.HoldToken}
+		var unclaimed []string
+		for i, seat := range holder.Seats {
+			if seat.Outcome != Held {
+				continue
+			}
+			object := current[seat.Label]
+			switch {
+			case renewal.isStillHeld(holder, object):
+				unclaimed = append(unclaimed, seat.Label)
+			case object.Status == events.BOOKED:
+				holder.Seats[i].Outcome = Claimed
+			default:
+				holder.Seats[i].Outcome = Expired
+			}
+		}
+		if len(unclaimed) > 0 {
+			if err := renewal.release(context, holder, unclaimed); err != nil {
+				holder.Error = err.Error()
+			} else {
+				setOutcome(holder.Seats, Held, Released)
+			}
+		}
+		report.Holders = append(report.Holders, holder)
+	}
+	return report, context.Err()
+}
+
+func (renewal *Renewal) isStillHeld(holder HolderReport, object events.EventObjectInfo) bool {
+	if renewal.Status != "" {
+		return object.ObjectStatus() == renewal.Status && object.OrderId == holder.OrderId
+	}
+	return object.Status == events.HELD && object.HoldToken == holder.HoldToken
+}
+
+func (renewal *Renewal) release(context context.Context, holder HolderReport, labels []string) error {
+	params := &events.StatusChangeParams{
+		Events:        []string{renewal.Season},
+		StatusChanges: events.StatusChanges{Objects: objectProperties(labels)},
+	}
+	if renewal.Status != "" {
+		params.AllowedPreviousStatuses = events.StatusStrings(renewal.Status)
+	} else {
+		params.HoldToken = holder.HoldToken
+	}
+	_, err := renewal.Client.Events.ReleaseWithOptions(context, params)
+	return err
+}
+
+func (renewal *Renewal) newReport(phase Phase) *Report {
+	return &Report{Phase: phase, PreviousSeason: renewal.PreviousSeason, Season: renewal.Season, Holders: []HolderReport{}, CompletedAt: renewal.now()}
+}
+
+func (renewal *Renewal) now() time.Time {
+	if renewal.Now == nil {
+		return time.Now()
+	}
+	return renewal.Now()
+}
+
+func labelsWithOutcome(seats []Seat, outcome SeatOutcome) []string {
+	var labels []string
+	for _, seat := range seats {
+		if seat.Outcome == outcome {
+			labels = append(labels, seat.Label)
+		}
+	}
+	return labels
+}
+
+func setOutcome(seats []Seat, from SeatOutcome, to SeatOutcome) {
+	for i := range seats {
+		if seats[i].Outcome == from {
+			seats[i].Outcome = to
+		}
+	}
+}
+
+func objectProperties(labels []string) []events.ObjectProperties {
+	objects := make([]events.ObjectProperties, len(labels))
+	for i, label := range labels {
+		objects[i] = events.ObjectProperties{ObjectId: label}
+	}
+	return objects
+}
@@ -0,0 +1,129 @@
+package renewal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type Phase string
+
+const (
+	PhasePlan    Phase = "plan"
+	PhaseHold    Phase = "hold"
+	PhaseClaim   Phase = "claim"
+	PhaseRelease Phase = "release"
+)
+
+type SeatOutcome string
+
+const (
+	// Renewable seats are free in the new season, and can be held for their holder.
+	Renewable SeatOutcome = "renewable"
+	// NotOnChart seats have no counterpart on the chart of the new season, after the label mapping.
+	NotOnChart SeatOutcome = "notOnChart"
+	// Unavailable seats are not free in the new season.
+	Unavailable SeatOutcome = "unavailable"
+	// NoHolder seats were booked without an order id in the previous season, so they cannot be attributed to a holder.
+	NoHolder SeatOutcome = "noHolder"
+	// GeneralAdmission areas are not renewed: their bookings cannot be told apart in the event report.
+	GeneralAdmission SeatOutcome = "generalAdmission"
+	Held             SeatOutcome = "held"
+	Claimed          SeatOutcome = "claimed"
+	Released         SeatOutcome = "released"
+	// Expired seats were held, but are neither held nor claimed anymore, e.g. because the hold token expired.
+	Expired SeatOutcome = "expired"
+	Failed  SeatOutcome = "failed"
+)
+
+type Seat struct {
+	PreviousLabel string      `json:"previousLabel"`
+	Label         string      `json:"label,omitempty"`
+	Outcome       SeatOutcome `json:"outcome"`
+}
+
+// HolderReport is the outcome of a phase for one season ticket holder, who is identified by the order id of their
+// booking in the previous season.
+type HolderReport struct {
+	OrderId   string     `json:"orderId"`
+	Seats     []Seat     `json:"seats"`
+	HoldToken string     `json:"holdToken,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Report is the outcome of a phase for all holders, ordered by order id. The report of the hold phase is needed to
+// claim and release the seats, so it is usually saved, e.g. with WriteJSON, until the renewal window closes.
+type Report struct {
+	Phase          Phase          `json:"phase"`
+	PreviousSeason string         `json:"previousSeason"`
+	Season         string         `json:"season"`
+	Holders        []HolderReport `json:"holders"`
+	CompletedAt    time.Time      `json:"completedAt"`
+}
+
+func (report *Report) Holder(orderId string) (*HolderReport, bool) {
+	for i := range report.Holders {
+		if report.Holders[i].OrderId == orderId {
+			return &report.Holders[i], true
+		}
+	}
+	return nil, false
+}
+
+// Failed returns the holders for whom the phase failed.
+func (report *Report) Failed() []HolderReport {
+	var failed []HolderReport
+	for _, holder := range report.Holders {
+		if holder.Error != "" {
+			failed = append(failed, holder)
+		}
+	}
+	return failed
+}
+
+// Count returns the number of seats per outcome.
+func (report *Report) Count() map[SeatOutcome]int {
+	count := map[SeatOutcome]int{}
+	for _, holder := range report.Holders {
+		for _, seat := range holder.Seats {
+			count[seat.Outcome]++
+		}
+	}
+	return count
+}
+
+func (report *Report) WriteJSON(writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+func ReadReport(reader io.Reader) (*Report, error) {
+	var report Report
+	if err := json.NewDecoder(reader).Decode(&report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+var reportCSVHeader = []string{"orderId", "previousLabel", "label", "outcome", "holdToken", "error"}
+
+// WriteCSV writes one line per seat.
+func (report *Report) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(reportCSVHeader); err != nil {
+		return err
+	}
+	for _, holder := range report.Holders {
+		for _, seat := range holder.Seats {
+			record := []string{holder.OrderId, seat.PreviousLabel, seat.Label, string(seat.Outcome), holder.HoldToken, holder.Error}
+			if err := csvWriter.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
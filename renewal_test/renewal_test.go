@@ -0,0 +1,173 @@
+package renewal_test
+
+import (
+	"bytes"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/renewal"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+type testSeasons struct {
+	client     *seatsio.SeatsioClient
+	holdTokens atomic.Int32
+}
+
+func (fixture *testSeasons) object(t *testing.T, label string) events.EventObjectInfo {
+	objects, err := fixture.client.Events.RetrieveObjectInfo(test_util.RequestContext(), "season2025", label)
+	require.NoError(t, err)
+	return objects[label]
+}
+
+func (fixture *testSeasons) book(t *testing.T, seasonKey string, orderId string, labels ...string) {
+	var objects []events.ObjectProperties
+	for _, label := range labels {
+		objects = append(objects, events.ObjectProperties{ObjectId: label})
+	}
+	_, err := fixture.client.Events.BookWithOptions(test_util.RequestContext(), &events.StatusChangeParams{
+		Events:        []string{seasonKey},
+		StatusChanges: events.StatusChanges{Objects: objects, OrderId: orderId},
+	})
+	require.NoError(t, err)
+}
+
+// newSeasons creates the seasons season2024 and season2025 on the same chart, and counts the hold tokens that are
+// created. Most seats of season2024 are booked per season ticket holder; C-1 is already sold in season2025.
+func newSeasons(t *testing.T) *testSeasons {
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	fixture := &testSeasons{client: seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)}
+	for _, seasonKey := range []string{"season2024", "season2025"} {
+		_, err := fixture.client.Seasons.CreateWithOptions(test_util.RequestContext(), chartKey, &seasons.CreateSeasonParams{Key: seasonKey})
+		require.NoError(t, err)
+	}
+	fixture.book(t, "season2024", "holder1", "A-1", "A-2")
+	fixture.book(t, "season2024", "holder2", "B-1")
+	fixture.book(t, "season2024", "holder3", "C-1")
+	fixture.book(t, "season2024", "holder4", "D-1")
+	fixture.book(t, "season2024", "", "E-1")
+	fixture.book(t, "season2024", "holder5", "GA1")
+	fixture.book(t, "season2025", "someoneElse", "C-1")
+	fixture.client.HoldTokens.Client.OnAfterResponse(func(_ *req.Client, response *req.Response) error {
+		if response.Request != nil && response.Request.RawRequest != nil && response.Request.RawRequest.URL.Path == "/hold-tokens" {
+			fixture.holdTokens.Add(1)
+		}
+		return nil
+	})
+	return fixture
+}
+
+// newRenewal renews B-1 as B-2, and D-1 as Z-99, which is not on the chart.
+func newRenewal(client *seatsio.SeatsioClient) *renewal.Renewal {
+	return &renewal.Renewal{
+		Client:         client,
+		PreviousSeason: "season2024",
+		Season:         "season2025",
+		LabelMapping:   map[string]string{"B-1": "B-2", "D-1": "Z-99"},
+		Window:         14 * 24 * time.Hour,
+	}
+}
+
+func TestPlanRenewal(t *testing.T) {
+	t.Parallel()
+	fixture := newSeasons(t)
+
+	plan, err := newRenewal(fixture.client).Plan(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Equal(t, renewal.PhasePlan, plan.Phase)
+	require.Equal(t, []renewal.HolderReport{
+		// the event report does not have the order ids of general admission bookings
+		{OrderId: "", Seats: []renewal.Seat{{PreviousLabel: "E-1", Label: "E-1", Outcome: renewal.NoHolder}, {PreviousLabel: "GA1", Label: "GA1", Outcome: renewal.GeneralAdmission}}},
+		{OrderId: "holder1", Seats: []renewal.Seat{{PreviousLabel: "A-1", Label: "A-1", Outcome: renewal.Renewable}, {PreviousLabel: "A-2", Label: "A-2", Outcome: renewal.Renewable}}},
+		{OrderId: "holder2", Seats: []renewal.Seat{{PreviousLabel: "B-1", Label: "B-2", Outcome: renewal.Renewable}}},
+		{OrderId: "holder3", Seats: []renewal.Seat{{PreviousLabel: "C-1", Label: "C-1", Outcome: renewal.Unavailable}}},
+		{OrderId: "holder4", Seats: []renewal.Seat{{PreviousLabel: "D-1", Label: "Z-99", Outcome: renewal.NotOnChart}}},
+	}, plan.Holders)
+	require.Equal(t, events.FREE, fixture.object(t, "A-1").Status)
+}
+
+func TestRenewWithHoldTokens(t *testing.T) {
+	t.Parallel()
+	fixture := newSeasons(t)
+	seasonRenewal := newRenewal(fixture.client)
+	plan, err := seasonRenewal.Plan(test_util.RequestContext())
+	require.NoError(t, err)
+
+	holds, err := seasonRenewal.Hold(test_util.RequestContext(), plan)
+	require.NoError(t, err)
+	holder1, _ := holds.Holder("holder1")
+	require.NotEmpty(t, holder1.HoldToken)
+	require.NotNil(t, holder1.ExpiresAt)
+	require.Equal(t, events.HELD, fixture.object(t, "A-2").Status)
+	require.Equal(t, holder1.HoldToken, fixture.object(t, "A-2").HoldToken)
+	require.Equal(t, map[renewal.SeatOutcome]int{renewal.Held: 3, renewal.Unavailable: 1, renewal.NotOnChart: 1, renewal.NoHolder: 1, renewal.GeneralAdmission: 1}, holds.Count())
+	require.Equal(t, int32(2), fixture.holdTokens.Load())
+
+	claimed, err := seasonRenewal.Claim(test_util.RequestContext(), holds, "holder1")
+	require.NoError(t, err)
+	require.Equal(t, []renewal.Seat{{PreviousLabel: "A-1", Label: "A-1", Outcome: renewal.Claimed}, {PreviousLabel: "A-2", Label: "A-2", Outcome: renewal.Claimed}}, claimed.Seats)
+	require.Equal(t, events.BOOKED, fixture.object(t, "A-1").Status)
+
+	released, err := seasonRenewal.ReleaseUnclaimed(test_util.RequestContext(), holds)
+	require.NoError(t, err)
+	require.Equal(t, map[renewal.SeatOutcome]int{renewal.Claimed: 2, renewal.Released: 1, renewal.Unavailable: 1, renewal.NotOnChart: 1, renewal.NoHolder: 1, renewal.GeneralAdmission: 1}, released.Count())
+	require.Equal(t, events.FREE, fixture.object(t, "B-2").Status)
+	require.Equal(t, events.BOOKED, fixture.object(t, "A-1").Status)
+}
+
+func TestRenewWithCustomStatus(t *testing.T) {
+	t.Parallel()
+	fixture := newSeasons(t)
+	seasonRenewal := newRenewal(fixture.client)
+	seasonRenewal.Status = "renewal"
+	seasonRenewal.Window = 0
+	plan, err := seasonRenewal.Plan(test_util.RequestContext())
+	require.NoError(t, err)
+	fixture.book(t, "season2025", "sold since the plan", "A-2")
+
+	holds, err := seasonRenewal.Hold(test_util.RequestContext(), plan)
+	require.NoError(t, err)
+	require.Len(t, holds.Failed(), 1)
+	require.Equal(t, "holder1", holds.Failed()[0].OrderId)
+	require.Contains(t, holds.Failed()[0].Error, "A-2")
+	require.Equal(t, events.FREE, fixture.object(t, "A-1").Status)
+	require.Equal(t, "renewal", fixture.object(t, "B-2").Status)
+	require.Equal(t, "holder2", fixture.object(t, "B-2").OrderId)
+	require.Equal(t, int32(0), fixture.holdTokens.Load())
+
+	var saved bytes.Buffer
+	require.NoError(t, holds.WriteJSON(&saved))
+	holds, err = renewal.ReadReport(&saved)
+	require.NoError(t, err)
+
+	released, err := seasonRenewal.ReleaseUnclaimed(test_util.RequestContext(), holds)
+	require.NoError(t, err)
+	holder2, _ := released.Holder("holder2")
+	require.Equal(t, []renewal.Seat{{PreviousLabel: "B-1", Label: "B-2", Outcome: renewal.Released}}, holder2.Seats)
+	require.Equal(t, events.FREE, fixture.object(t, "B-2").Status)
+
+	var csv bytes.Buffer
+	require.NoError(t, released.WriteCSV(&csv))
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	require.Equal(t, "orderId,previousLabel,label,outcome,holdToken,error", lines[0])
+	require.Contains(t, lines, "holder2,B-1,B-2,released,,")
+}
+
+func TestHoldTokensNeedAWindow(t *testing.T) {
+	t.Parallel()
+	seasonRenewal := newRenewal(nil)
+	seasonRenewal.Window = 0
+
+	_, err := seasonRenewal.Hold(test_util.RequestContext(), &renewal.Report{})
+
+	require.ErrorContains(t, err, "window")
+}
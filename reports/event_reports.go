@@ -42,6 +42,16 @@ type EventSummaryReportItem struct {
 	ByChannel            map[string]int `json:"byChannel,omitempty"`
 }
 
+// StatusSummary returns the summary of the objects with the given status, from ByStatus.
+func (item EventDeepSummaryReportItem) StatusSummary(status events.ObjectStatus) EventSummaryReportItem {
+	return item.ByStatus[status.String()]
+}
+
+// StatusCount returns the number of objects with the given status, from ByStatus.
+func (item EventSummaryReportItem) StatusCount(status events.ObjectStatus) int {
+	return item.ByStatus[status.String()]
+}
+
 const (
 	NoOrderId    string = "NO_ORDER_ID"
 	NoSection    string = "NO_SECTION"
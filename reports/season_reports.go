@@ -0,0 +1,332 @@
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+)
+
+// SeasonReports combine the event reports of all events of a season.
+type SeasonReports struct {
+	Client *req.Client
+}
+
+type seasonReportParams struct {
+	concurrency int
+}
+
+type SeasonReportOption func(params *seasonReportParams)
+
+type seasonReportSupportNS struct{}
+
+var SeasonReportSupport seasonReportSupportNS
+
+// Concurrency sets how many event reports are fetched at the same time. Defaults to 4.
+func (seasonReportSupportNS) Concurrency(concurrency int) SeasonReportOption {
+	return func(params *seasonReportParams) {
+		params.concurrency = concurrency
+	}
+}
+
+// SeasonReport holds the objects of every event of a season, by label. Objects are available when they can be
+// booked, as in the event reports: free and for sale. Tables are left out; their seats are counted instead.
+type SeasonReport struct {
+	Season *seasons.Season
+	// EventKeys are the events of the season, in the order of the season.
+	EventKeys []string
+	// PartialSeasons holds the event keys of every partial season.
+	PartialSeasons map[string][]string
+	Objects        map[string]map[string]events.EventObjectInfo
+}
+
+// Load retrieves the season and its partial seasons, and fetches the report by label of all their events concurrently.
+func (seasonReports *SeasonReports) Load(ctx context.Context, seasonKey string, opts ...SeasonReportOption) (*SeasonReport, error) {
+	params := &seasonReportParams{concurrency: 4}
+	for _, opt := range opts {
+		opt(params)
+	}
+	seasonsClient := &seasons.Seasons{Client: seasonReports.Client}
+	season, err := seasonsClient.Retrieve(ctx, seasonKey)
+	if err != nil {
+		return nil, err
+	}
+	report := &SeasonReport{Season: season, PartialSeasons: map[string][]string{}}
+	for _, event := range season.Events {
+		report.EventKeys = append(report.EventKeys, event.Key)
+	}
+	for _, partialSeasonKey := range season.PartialSeasonKeys {
+		partialSeason, err := seasonsClient.Retrieve(ctx, partialSeasonKey)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving partial season %s: %w", partialSeasonKey, err)
+		}
+		eventKeys := []string{}
+		for _, event := range partialSeason.Events {
+			eventKeys = append(eventKeys, event.Key)
+		}
+		report.PartialSeasons[partialSeasonKey] = eventKeys
+	}
+	report.Objects, err = seasonReports.fetchObjects(ctx, report.EventKeys, max(params.concurrency, 1))
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (seasonReports *SeasonReports) fetchObjects(ctx context.Context, eventKeys []string, concurrency int) (map[string]map[string]events.EventObjectInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	eventReports := &EventReports{Client: seasonReports.Client}
+	objects := make([]map[string]events.EventObjectInfo, len(eventKeys))
+	var firstErr error
+	var failOnce sync.Once
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, eventKey := range eventKeys {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, eventKey string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			report, err := eventReports.ByLabel(ctx, eventKey)
+			if err != nil {
+				// the other fetches fail too once they are cancelled; the error that caused it is the one to return
+				failOnce.Do(func() {
+					firstErr = fmt.Errorf("fetching the report of event %s: %w", eventKey, err)
+					cancel()
+				})
+				return
+			}
+			objects[i] = map[string]events.EventObjectInfo{}
+			for label, infos := range report.Items {
+				if len(infos) > 0 && infos[0].ObjectType != "table" {
+					objects[i][label] = infos[0]
+				}
+			}
+		}(i, eventKey)
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	byEvent := make(map[string]map[string]events.EventObjectInfo, len(eventKeys))
+	for i, eventKey := range eventKeys {
+		byEvent[eventKey] = objects[i]
+	}
+	return byEvent, nil
+}
+
+// SeasonEventSummary counts the places of an event: 1 for every seat or booth, and the capacity of general admission
+// areas.
+type SeasonEventSummary struct {
+	EventKey     string
+	Capacity     int
+	NumBooked    int
+	NumAvailable int
+	// ByStatus counts the seats and booths per status. General admission areas are not included.
+	ByStatus map[events.ObjectStatus]int
+}
+
+// Occupancy is the share of the places that are booked, between 0 and 1.
+func (summary SeasonEventSummary) Occupancy() float64 {
+	if summary.Capacity == 0 {
+		return 0
+	}
+	return float64(summary.NumBooked) / float64(summary.Capacity)
+}
+
+func (summary *SeasonEventSummary) add(object events.EventObjectInfo) {
+	if object.ObjectType == "generalAdmission" {
+		summary.Capacity += object.Capacity
+		summary.NumBooked += object.NumBooked
+		if object.IsAvailable {
+			summary.NumAvailable += object.NumFree
+		}
+		return
+	}
+	summary.Capacity++
+	summary.ByStatus[object.ObjectStatus()]++
+	if object.Status == events.BOOKED {
+		summary.NumBooked++
+	}
+	if object.IsAvailable {
+		summary.NumAvailable++
+	}
+}
+
+// Summaries returns a summary per event, in the order of the season.
+func (report *SeasonReport) Summaries() []SeasonEventSummary {
+	summaries := make([]SeasonEventSummary, len(report.EventKeys))
+	for i, eventKey := range report.EventKeys {
+		summaries[i] = report.summary(eventKey)
+	}
+	return summaries
+}
+
+func (report *SeasonReport) summary(eventKey string) SeasonEventSummary {
+	summary := SeasonEventSummary{EventKey: eventKey, ByStatus: map[events.ObjectStatus]int{}}
+	for _, object := range report.Objects[eventKey] {
+		summary.add(object)
+	}
+	return summary
+}
+
+func (report *SeasonReport) labels() []string {
+	seen := map[string]bool{}
+	var labels []string
+	for _, eventKey := range report.EventKeys {
+		for label := range report.Objects[eventKey] {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// StatusMatrix holds the status of every object (a row) in every event (a column). The status is empty for objects
+// that are not in an event.
+type StatusMatrix struct {
+	Labels    []string
+	EventKeys []string
+	Statuses  [][]events.ObjectStatus
+}
+
+func (report *SeasonReport) StatusMatrix() *StatusMatrix {
+	matrix := &StatusMatrix{Labels: report.labels(), EventKeys: report.EventKeys}
+	matrix.Statuses = make([][]events.ObjectStatus, len(matrix.Labels))
+	for row, label := range matrix.Labels {
+		matrix.Statuses[row] = make([]events.ObjectStatus, len(report.EventKeys))
+		for column, eventKey := range report.EventKeys {
+			matrix.Statuses[row][column] = report.Objects[eventKey][label].ObjectStatus()
+		}
+	}
+	return matrix
+}
+
+// WriteCSV writes a line per object, with a column per event.
+func (matrix *StatusMatrix) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(append([]string{"label"}, matrix.EventKeys...)); err != nil {
+		return err
+	}
+	for row, label := range matrix.Labels {
+		record := []string{label}
+		for _, status := range matrix.Statuses[row] {
+			record = append(record, status.String())
+		}
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// ObjectAvailability lists the events in which an object is available, in the order of the season.
+type ObjectAvailability struct {
+	Label       string
+	AvailableIn []string
+}
+
+// Availability returns the availability of every object, ordered by label.
+func (report *SeasonReport) Availability() []ObjectAvailability {
+	labels := report.labels()
+	availability := make([]ObjectAvailability, len(labels))
+	for i, label := range labels {
+		availability[i] = ObjectAvailability{Label: label, AvailableIn: []string{}}
+		for _, eventKey := range report.EventKeys {
+			if object, ok := report.Objects[eventKey][label]; ok && object.IsAvailable {
+				availability[i].AvailableIn = append(availability[i].AvailableIn, eventKey)
+			}
+		}
+	}
+	return availability
+}
+
+// AvailableInAll returns the labels of the objects that are available in all the given events, or in all events of
+// the season when none are given.
+func (report *SeasonReport) AvailableInAll(eventKeys ...string) []string {
+	if len(eventKeys) == 0 {
+		eventKeys = report.EventKeys
+	}
+	var labels []string
+	for _, label := range report.labels() {
+		if report.availableInAll(label, eventKeys) {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+func (report *SeasonReport) availableInAll(label string, eventKeys []string) bool {
+	for _, eventKey := range eventKeys {
+		if object, ok := report.Objects[eventKey][label]; !ok || !object.IsAvailable {
+			return false
+		}
+	}
+	return len(eventKeys) > 0
+}
+
+// AvailableInAtLeast returns the objects that are available in at least a number of events.
+func (report *SeasonReport) AvailableInAtLeast(numEvents int) []ObjectAvailability {
+	var result []ObjectAvailability
+	for _, availability := range report.Availability() {
+		if len(availability.AvailableIn) >= numEvents {
+			result = append(result, availability)
+		}
+	}
+	return result
+}
+
+// PartialSeasonSummary sums the summaries of the events of a partial season.
+type PartialSeasonSummary struct {
+	PartialSeasonKey string
+	EventKeys        []string
+	Capacity         int
+	NumBooked        int
+	NumAvailable     int
+	// NumAvailableInAllEvents counts the objects that are available in every event of the partial season, i.e. the
+	// objects that could still be sold as a partial season ticket.
+	NumAvailableInAllEvents int
+}
+
+func (summary PartialSeasonSummary) Occupancy() float64 {
+	if summary.Capacity == 0 {
+		return 0
+	}
+	return float64(summary.NumBooked) / float64(summary.Capacity)
+}
+
+// ComparePartialSeasons returns a summary per partial season, ordered by key.
+func (report *SeasonReport) ComparePartialSeasons() []PartialSeasonSummary {
+	keys := make([]string, 0, len(report.PartialSeasons))
+	for key := range report.PartialSeasons {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	summaries := make([]PartialSeasonSummary, len(keys))
+	for i, key := range keys {
+		eventKeys := report.PartialSeasons[key]
+		summary := PartialSeasonSummary{PartialSeasonKey: key, EventKeys: eventKeys}
+		for _, eventKey := range eventKeys {
+			eventSummary := report.summary(eventKey)
+			summary.Capacity += eventSummary.Capacity
+			summary.NumBooked += eventSummary.NumBooked
+			summary.NumAvailable += eventSummary.NumAvailable
+		}
+		if len(eventKeys) > 0 {
+			summary.NumAvailableInAllEvents = len(report.AvailableInAll(eventKeys...))
+		}
+		summaries[i] = summary
+	}
+	return summaries
+}
@@ -0,0 +1,337 @@
+package reports
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+)
+
+func MonthOf(t time.Time) Month {
+	return Month{Year: t.Year(), Month: int(t.Month())}
+}
+
+func (month Month) String() string {
+	return formatMonth(month.Year, month.Month)
+}
+
+func (month Month) Next() Month {
+	return MonthOf(month.start().AddDate(0, 1, 0))
+}
+
+func (month Month) Before(other Month) bool {
+	return month.Year < other.Year || (month.Year == other.Year && month.Month < other.Month)
+}
+
+func (month Month) start() time.Time {
+	return time.Date(month.Year, time.Month(month.Month), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// UsageRow is the usage of one event in one month.
+type UsageRow struct {
+	Month          Month
+	Workspace      int64
+	Chart          UsageChart
+	Event          UsageEvent
+	NumUsedObjects int
+}
+
+// UsageTable holds the usage of events over a range of months, as one row per month and event.
+type UsageTable struct {
+	Rows []UsageRow
+}
+
+// UsageForMonths returns the usage of all months from one month up to and including another.
+func (usageReports *UsageReports) UsageForMonths(context context.Context, from Month, to Month) (*UsageTable, error) {
+	table := &UsageTable{}
+	for month := from; !to.Before(month); month = month.Next() {
+		details, err := usageReports.DetailsForMonth(context, month.Year, month.Month)
+		if err != nil {
+			return nil, err
+		}
+		table.Add(month, details)
+	}
+	return table, nil
+}
+
+// Add adds the usage details of a month to the table.
+func (table *UsageTable) Add(month Month, details []UsageDetails) {
+	for _, workspaceDetails := range details {
+		for _, chartUsage := range workspaceDetails.UsageByChart {
+			for _, eventUsage := range chartUsage.UsageByEvent {
+				table.Rows = append(table.Rows, UsageRow{
+					Month:          month,
+					Workspace:      workspaceDetails.Workspace,
+					Chart:          chartUsage.Chart,
+					Event:          eventUsage.Event,
+					NumUsedObjects: eventUsage.NumUsedObjects,
+				})
+			}
+		}
+	}
+}
+
+// Months returns the months that have usage, in order.
+func (table *UsageTable) Months() []Month {
+	seen := map[Month]bool{}
+	var months []Month
+	for _, row := range table.Rows {
+		if !seen[row.Month] {
+			seen[row.Month] = true
+			months = append(months, row.Month)
+		}
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Before(months[j]) })
+	return months
+}
+
+type UsageGrouping int
+
+const (
+	GroupByWorkspace UsageGrouping = iota
+	GroupByChart
+	GroupByEvent
+)
+
+// UsageTotal is the usage of a workspace, chart or event. The fields that are more specific than the grouping are
+// left empty: a total per chart has no Event.
+type UsageTotal struct {
+	Workspace      int64
+	Chart          UsageChart
+	Event          UsageEvent
+	NumUsedObjects int
+	ByMonth        map[Month]int
+}
+
+type usageKey struct {
+	workspace int64
+	chart     string
+	event     int64
+}
+
+func (grouping UsageGrouping) total(row UsageRow) UsageTotal {
+	total := UsageTotal{Workspace: row.Workspace}
+	if grouping >= GroupByChart {
+		total.Chart = row.Chart
+	}
+	if grouping >= GroupByEvent {
+		total.Event = row.Event
+	}
+	return total
+}
+
+func (total UsageTotal) key() usageKey {
+	return usageKey{workspace: total.Workspace, chart: total.Chart.Key, event: total.Event.Id}
+}
+
+// Totals sums the usage per workspace, chart or event, over all months of the table. The totals are ordered by usage,
+// highest first.
+func (table *UsageTable) Totals(grouping UsageGrouping) []UsageTotal {
+	totals := map[usageKey]*UsageTotal{}
+	var order []usageKey
+	for _, row := range table.Rows {
+		group := grouping.total(row)
+		total, ok := totals[group.key()]
+		if !ok {
+			group.ByMonth = map[Month]int{}
+			total = &group
+			totals[group.key()] = total
+			order = append(order, group.key())
+		}
+		total.NumUsedObjects += row.NumUsedObjects
+		total.ByMonth[row.Month] += row.NumUsedObjects
+	}
+	result := make([]UsageTotal, len(order))
+	for i, key := range order {
+		result[i] = *totals[key]
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].NumUsedObjects > result[j].NumUsedObjects
+	})
+	return result
+}
+
+// UsageComparison compares the usage of a workspace, chart or event in two months.
+type UsageComparison struct {
+	UsageTotal
+	Before     int
+	After      int
+	Difference int
+	// PercentChange is NaN when there was no usage before.
+	PercentChange float64
+}
+
+// Compare compares the usage of two months per workspace, chart or event, ordered by the size of the difference,
+// largest first. Totals that were used in only one of the months are included.
+func (table *UsageTable) Compare(grouping UsageGrouping, before Month, after Month) []UsageComparison {
+	var comparisons []UsageComparison
+	for _, total := range table.Totals(grouping) {
+		beforeUsage, afterUsage := total.ByMonth[before], total.ByMonth[after]
+		if beforeUsage == 0 && afterUsage == 0 {
+			continue
+		}
+		percentChange := math.NaN()
+		if beforeUsage != 0 {
+			percentChange = float64(afterUsage-beforeUsage) / float64(beforeUsage) * 100
+		}
+		comparisons = append(comparisons, UsageComparison{
+			UsageTotal:    total,
+			Before:        beforeUsage,
+			After:         afterUsage,
+			Difference:    afterUsage - beforeUsage,
+			PercentChange: percentChange,
+		})
+	}
+	sort.SliceStable(comparisons, func(i, j int) bool {
+		return abs(comparisons[i].Difference) > abs(comparisons[j].Difference)
+	})
+	return comparisons
+}
+
+func abs(value int) int {
+	if value < 0 {
+		return -value
+	}
+	return value
+}
+
+// UsageProjection is the expected usage at the end of a month, extrapolated from the usage so far at the same rate.
+type UsageProjection struct {
+	Month                   Month
+	AsOf                    time.Time
+	NumUsedObjects          int
+	ProjectedNumUsedObjects int
+}
+
+func ProjectMonthEnd(month Month, numUsedObjects int, asOf time.Time) UsageProjection {
+	start := month.start()
+	end := month.Next().start()
+	projection := UsageProjection{Month: month, AsOf: asOf, NumUsedObjects: numUsedObjects, ProjectedNumUsedObjects: numUsedObjects}
+	elapsed := asOf.Sub(start)
+	if elapsed > 0 && asOf.Before(end) {
+		projection.ProjectedNumUsedObjects = int(math.Round(float64(numUsedObjects) * float64(end.Sub(start)) / float64(elapsed)))
+	}
+	return projection
+}
+
+// ProjectCurrentMonth projects the usage of the month that the usage cutoff date falls in, which is the current month.
+func (usageReports *UsageReports) ProjectCurrentMonth(context context.Context) (*UsageProjection, error) {
+	summary, err := usageReports.SummaryForAllMonths(context)
+	if err != nil {
+		return nil, err
+	}
+	asOf := time.Now()
+	if summary.UsageCutoffDate != nil {
+		asOf = *summary.UsageCutoffDate
+	}
+	month := MonthOf(asOf.UTC())
+	numUsedObjects := 0
+	for _, usage := range summary.Usage {
+		if usage.Month == month {
+			numUsedObjects = usage.NumUsedObjects
+		}
+	}
+	projection := ProjectMonthEnd(month, numUsedObjects, asOf)
+	return &projection, nil
+}
+
+var usageCSVHeader = []string{"month", "workspace", "chartKey", "chartName", "eventId", "eventKey", "numUsedObjects"}
+
+// WriteCSV writes one line per month and event.
+func (table *UsageTable) WriteCSV(writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(usageCSVHeader); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		record := []string{row.Month.String()}
+		record = append(record, usageKeyColumns(row.Workspace, row.Chart, row.Event)...)
+		record = append(record, strconv.Itoa(row.NumUsedObjects))
+		if err := csvWriter.Write(record); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+// WriteTotalsCSV writes one line per total, with a column per month of the table and a column with the total.
+func (table *UsageTable) WriteTotalsCSV(writer io.Writer, totals []UsageTotal) error {
+	months := table.Months()
+	header := []string{"workspace", "chartKey", "chartName", "eventId", "eventKey"}
+	for _, month := range months {
+		header = append(header, month.String())
+	}
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write(append(header, "total")); err != nil {
+		return err
+	}
+	for _, total := range totals {
+		record := usageKeyColumns(total.Workspace, total.Chart, total.Event)
+		for _, month := range months {
+			record = append(record, strconv.Itoa(total.ByMonth[month]))
+		}
+		if err := csvWriter.Write(append(record, strconv.Itoa(total.NumUsedObjects))); err != nil {
+			return err
+		}
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
+
+func usageKeyColumns(workspace int64, chart UsageChart, event UsageEvent) []string {
+	eventId := ""
+	if event.Id != 0 {
+		eventId = strconv.FormatInt(event.Id, 10)
+	}
+	return []string{strconv.FormatInt(workspace, 10), chart.Key, chart.Name, eventId, event.Key}
+}
+
+// UsageForObject is the usage of an object of an event in a month. Which fields are set depends on the version of
+// the usage report: NumFirstBookings, FirstBookingDate and NumFirstSelections for version 1, UsageByReason for
+// version 2. NumUsedObjects is always set.
+type UsageForObject struct {
+	Object             string
+	NumUsedObjects     int
+	UsageByReason      map[UsageReason]int
+	NumFirstBookings   int
+	FirstBookingDate   *time.Time
+	NumFirstSelections int
+}
+
+type EventUsageReport struct {
+	Version int
+	Objects []UsageForObject
+}
+
+// EventUsageForMonth is DetailsForEventInMonth, with the version 1 and version 2 reports in one type.
+func (usageReports *UsageReports) EventUsageForMonth(context context.Context, eventId int, year int, month int) (*EventUsageReport, error) {
+	usageV1, usageV2, err := usageReports.DetailsForEventInMonth(context, eventId, year, month)
+	if err != nil {
+		return nil, err
+	}
+	report := &EventUsageReport{Version: 2, Objects: []UsageForObject{}}
+	for _, usage := range usageV2 {
+		report.Objects = append(report.Objects, UsageForObject{Object: usage.Object, NumUsedObjects: usage.NumUsedObjects, UsageByReason: usage.UsageByReason})
+	}
+	if usageV1 != nil && usageV2 == nil {
+		report.Version = 1
+	}
+	for _, usage := range usageV1 {
+		object := UsageForObject{
+			Object:             usage.Object,
+			NumUsedObjects:     usage.NumFirstBookingsOrSelections,
+			NumFirstBookings:   usage.NumFirstBookings,
+			NumFirstSelections: usage.NumFirstSelections,
+		}
+		if !usage.FirstBookingDate.IsZero() {
+			firstBookingDate := usage.FirstBookingDate
+			object.FirstBookingDate = &firstBookingDate
+		}
+		report.Objects = append(report.Objects, object)
+	}
+	return report, nil
+}
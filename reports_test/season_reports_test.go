@@ -0,0 +1,160 @@
+package reports_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/reports"
+	"github.com/seatsio/seatsio-go/v12/seasons"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func seat(status string) events.EventObjectInfo {
+	return events.EventObjectInfo{Status: status, ObjectType: "seat", IsAvailable: status == events.FREE}
+}
+
+func generalAdmission(capacity int, numBooked int) events.EventObjectInfo {
+	return events.EventObjectInfo{ObjectType: "generalAdmission", Capacity: capacity, NumBooked: numBooked, NumFree: capacity - numBooked, IsAvailable: numBooked < capacity}
+}
+
+// newSeasonReport returns the report of a season of three matches, with partial seasons for the first two matches and
+// for the last one.
+func newSeasonReport() *reports.SeasonReport {
+	return &reports.SeasonReport{
+		EventKeys:      []string{"match1", "match2", "match3"},
+		PartialSeasons: map[string][]string{"firstHalf": {"match1", "match2"}, "secondHalf": {"match3"}},
+		Objects: map[string]map[string]events.EventObjectInfo{
+			"match1": {"A-1": seat(events.FREE), "A-2": seat(events.BOOKED), "A-3": seat(events.FREE), "GA": generalAdmission(100, 40)},
+			"match2": {"A-1": seat(events.FREE), "A-2": seat(events.FREE), "A-3": seat(events.BOOKED), "GA": generalAdmission(100, 100)},
+			"match3": {"A-1": seat(events.FREE), "A-2": seat(events.FREE), "A-3": seat("checked-in"), "GA": generalAdmission(100, 10)},
+		},
+	}
+}
+
+func TestSeasonReportSummaries(t *testing.T) {
+	t.Parallel()
+	report := newSeasonReport()
+
+	summaries := report.Summaries()
+	require.Equal(t, reports.SeasonEventSummary{
+		EventKey:     "match1",
+		Capacity:     103,
+		NumBooked:    41,
+		NumAvailable: 62,
+		ByStatus:     map[events.ObjectStatus]int{events.FREE: 2, events.BOOKED: 1},
+	}, summaries[0])
+	require.Equal(t, 2, summaries[1].NumAvailable)
+	require.InDelta(t, 101.0/103, summaries[1].Occupancy(), 0.0001)
+	require.Equal(t, map[events.ObjectStatus]int{events.FREE: 2, "checked-in": 1}, summaries[2].ByStatus)
+}
+
+func TestSeasonStatusMatrix(t *testing.T) {
+	t.Parallel()
+	report := newSeasonReport()
+
+	matrix := report.StatusMatrix()
+
+	require.Equal(t, []string{"A-1", "A-2", "A-3", "GA"}, matrix.Labels)
+	require.Equal(t, []events.ObjectStatus{events.BOOKED, events.FREE, events.FREE}, matrix.Statuses[1])
+	var csv bytes.Buffer
+	require.NoError(t, matrix.WriteCSV(&csv))
+	require.Equal(t, "label,match1,match2,match3\n"+
+		"A-1,free,free,free\n"+
+		"A-2,booked,free,free\n"+
+		"A-3,free,booked,checked-in\n"+
+		"GA,,,\n", csv.String())
+}
+
+func TestSeasonAvailability(t *testing.T) {
+	t.Parallel()
+	report := newSeasonReport()
+
+	require.Equal(t, []string{"A-1"}, report.AvailableInAll())
+	require.Equal(t, []string{"A-1", "A-2"}, report.AvailableInAll("match2", "match3"))
+	require.Equal(t, []reports.ObjectAvailability{
+		{Label: "A-1", AvailableIn: []string{"match1", "match2", "match3"}},
+		{Label: "A-2", AvailableIn: []string{"match2", "match3"}},
+		{Label: "GA", AvailableIn: []string{"match1", "match3"}},
+	}, report.AvailableInAtLeast(2))
+}
+
+func TestComparePartialSeasons(t *testing.T) {
+	t.Parallel()
+	report := newSeasonReport()
+
+	require.Equal(t, []reports.PartialSeasonSummary{
+		{PartialSeasonKey: "firstHalf", EventKeys: []string{"match1", "match2"}, Capacity: 206, NumBooked: 142, NumAvailable: 64, NumAvailableInAllEvents: 1},
+		{PartialSeasonKey: "secondHalf", EventKeys: []string{"match3"}, Capacity: 103, NumBooked: 10, NumAvailable: 92, NumAvailableInAllEvents: 3},
+	}, report.ComparePartialSeasons())
+}
+
+func TestLoadSeasonReport(t *testing.T) {
+	t.Parallel()
+	company := test_util.CreateTestCompany(t)
+	chartKey := test_util.CreateTestChart(t, company.Admin.SecretKey)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	_, err := client.Seasons.CreateWithOptions(test_util.RequestContext(), chartKey, &seasons.CreateSeasonParams{Key: "season", EventKeys: []string{"match1", "match2", "match3"}})
+	require.NoError(t, err)
+	_, err = client.Seasons.CreatePartialSeasonWithOptions(test_util.RequestContext(), "season", &seasons.CreatePartialSeasonParams{Key: "firstHalf", EventKeys: []string{"match1", "match2"}})
+	require.NoError(t, err)
+	_, err = client.Events.Book(test_util.RequestContext(), "match2", "A-1")
+	require.NoError(t, err)
+	var mutex sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	client.SeasonReports.Client.WrapRoundTripFunc(func(roundTripper req.RoundTripper) req.RoundTripFunc {
+		return func(request *req.Request) (*req.Response, error) {
+			mutex.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mutex.Unlock()
+			defer func() {
+				mutex.Lock()
+				inFlight--
+				mutex.Unlock()
+			}()
+			return roundTripper.RoundTrip(request)
+		}
+	})
+
+	report, err := client.SeasonReports.Load(test_util.RequestContext(), "season", reports.SeasonReportSupport.Concurrency(2))
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"match1", "match2", "match3"}, report.EventKeys)
+	require.Equal(t, map[string][]string{"firstHalf": {"match1", "match2"}}, report.PartialSeasons)
+	require.Equal(t, events.FREE, report.Objects["match1"]["A-1"].Status)
+	require.Equal(t, events.BOOKED, report.Objects["match2"]["A-1"].Status)
+	require.Equal(t, []reports.ObjectAvailability{{Label: "A-1", AvailableIn: []string{"match1", "match3"}}}, report.AvailableInAtLeast(2)[:1])
+	require.Equal(t, 1, report.Summaries()[1].NumBooked)
+	mutex.Lock()
+	require.LessOrEqual(t, maxInFlight, 2)
+	mutex.Unlock()
+}
+
+func TestSeasonReportFailsWhenAnEventReportFails(t *testing.T) {
+	t.Parallel()
+	// the test server does not fail reports, so this one serves a season whose second event report fails
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.Header().Set("Content-Type", "application/json")
+		switch request.URL.Path {
+		case "/events/season":
+			_, _ = writer.Write([]byte(`{"key": "season", "isTopLevelSeason": true, "events": [{"key": "match1"}, {"key": "match2"}, {"key": "match3"}]}`))
+		case "/reports/events/match2/byLabel":
+			writer.WriteHeader(http.StatusInternalServerError)
+		default:
+			_, _ = writer.Write([]byte(`{}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := seatsio.NewSeatsioClient(server.URL, "secretKey")
+
+	_, err := client.SeasonReports.Load(test_util.RequestContext(), "season")
+
+	require.ErrorContains(t, err, "event match2")
+}
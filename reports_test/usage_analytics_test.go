@@ -0,0 +1,150 @@
+package reports_test
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/reports"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+func usageDetails(workspace int64, chart reports.UsageChart, usageByEvent ...reports.UsageByEvent) reports.UsageDetails {
+	return reports.UsageDetails{Workspace: workspace, UsageByChart: []reports.UsageByChart{{Chart: chart, UsageByEvent: usageByEvent}}}
+}
+
+func eventUsage(id int64, key string, numUsedObjects int) reports.UsageByEvent {
+	return reports.UsageByEvent{Event: reports.UsageEvent{Id: id, Key: key}, NumUsedObjects: numUsedObjects}
+}
+
+var arena, club, theatre = reports.UsageChart{Key: "chart1", Name: "Arena"}, reports.UsageChart{Key: "chart2", Name: "Club"}, reports.UsageChart{Key: "chart3", Name: "Theatre"}
+
+func newUsageTable() *reports.UsageTable {
+	table := &reports.UsageTable{}
+	table.Add(april, []reports.UsageDetails{
+		usageDetails(1, arena, eventUsage(11, "concert", 100), eventUsage(12, "match", 50)),
+	})
+	table.Add(may, []reports.UsageDetails{
+		usageDetails(1, arena, eventUsage(12, "match", 80)),
+		usageDetails(1, club, eventUsage(21, "gig", 30)),
+		usageDetails(2, theatre, eventUsage(31, "play", 10)),
+	})
+	return table
+}
+
+var april, may = reports.Month{Year: 2024, Month: 4}, reports.Month{Year: 2024, Month: 5}
+
+func TestUsageTotals(t *testing.T) {
+	t.Parallel()
+	table := newUsageTable()
+
+	require.Len(t, table.Rows, 5)
+	require.Equal(t, []reports.Month{april, may}, table.Months())
+
+	byWorkspace := table.Totals(reports.GroupByWorkspace)
+	require.Equal(t, []reports.UsageTotal{
+		{Workspace: 1, NumUsedObjects: 260, ByMonth: map[reports.Month]int{april: 150, may: 110}},
+		{Workspace: 2, NumUsedObjects: 10, ByMonth: map[reports.Month]int{may: 10}},
+	}, byWorkspace)
+
+	byChart := table.Totals(reports.GroupByChart)
+	require.Len(t, byChart, 3)
+	require.Equal(t, "chart1", byChart[0].Chart.Key)
+	require.Equal(t, 230, byChart[0].NumUsedObjects)
+	require.Empty(t, byChart[0].Event)
+
+	byEvent := table.Totals(reports.GroupByEvent)
+	require.Len(t, byEvent, 4)
+	require.Equal(t, "match", byEvent[0].Event.Key)
+	require.Equal(t, 130, byEvent[0].NumUsedObjects)
+}
+
+func TestCompareUsage(t *testing.T) {
+	t.Parallel()
+	table := newUsageTable()
+
+	comparisons := table.Compare(reports.GroupByChart, april, may)
+
+	require.Len(t, comparisons, 3)
+	require.Equal(t, "chart1", comparisons[0].Chart.Key)
+	require.Equal(t, 150, comparisons[0].Before)
+	require.Equal(t, 80, comparisons[0].After)
+	require.Equal(t, -70, comparisons[0].Difference)
+	require.InDelta(t, -46.67, comparisons[0].PercentChange, 0.01)
+	require.Equal(t, "chart2", comparisons[1].Chart.Key)
+	require.True(t, math.IsNaN(comparisons[1].PercentChange))
+}
+
+func TestProjectMonthEnd(t *testing.T) {
+	t.Parallel()
+
+	projection := reports.ProjectMonthEnd(may, 120, time.Date(2024, 5, 11, 0, 0, 0, 0, time.UTC))
+
+	require.Equal(t, may, projection.Month)
+	require.Equal(t, 120, projection.NumUsedObjects)
+	require.Equal(t, 372, projection.ProjectedNumUsedObjects)
+
+	afterTheMonth := reports.ProjectMonthEnd(april, 150, time.Date(2024, 5, 3, 0, 0, 0, 0, time.UTC))
+	require.Equal(t, 150, afterTheMonth.ProjectedNumUsedObjects)
+}
+
+func TestUsageCSV(t *testing.T) {
+	t.Parallel()
+	table := &reports.UsageTable{}
+	table.Add(april, []reports.UsageDetails{usageDetails(1, arena, eventUsage(11, "concert", 100), eventUsage(12, "match", 50))})
+
+	var rows, totals bytes.Buffer
+	require.NoError(t, table.WriteCSV(&rows))
+	require.NoError(t, table.WriteTotalsCSV(&totals, table.Totals(reports.GroupByChart)))
+
+	require.Equal(t, "month,workspace,chartKey,chartName,eventId,eventKey,numUsedObjects\n"+
+		"2024-04,1,chart1,Arena,11,concert,100\n"+
+		"2024-04,1,chart1,Arena,12,match,50\n", rows.String())
+	require.Equal(t, "workspace,chartKey,chartName,eventId,eventKey,2024-04,total\n"+
+		"1,chart1,Arena,,,150,150\n", totals.String())
+}
+
+func TestUsageForMonths(t *testing.T) {
+	t.Parallel()
+	test_util.AssertDemoCompanySecretKeySet(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, test_util.DemoCompanySecretKey())
+	november := reports.Month{Year: 2021, Month: 11}
+
+	table, err := client.UsageReports.UsageForMonths(test_util.RequestContext(), november, november)
+
+	require.NoError(t, err)
+	require.Equal(t, []reports.Month{november}, table.Months())
+	require.Equal(t, 143, table.Rows[0].NumUsedObjects)
+	total := 0
+	for _, row := range table.Rows {
+		total += row.NumUsedObjects
+	}
+	require.Equal(t, total, table.Totals(reports.GroupByWorkspace)[0].NumUsedObjects)
+}
+
+func TestProjectCurrentMonth(t *testing.T) {
+	t.Parallel()
+	test_util.AssertDemoCompanySecretKeySet(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, test_util.DemoCompanySecretKey())
+
+	projection, err := client.UsageReports.ProjectCurrentMonth(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Equal(t, reports.MonthOf(projection.AsOf.UTC()), projection.Month)
+	require.GreaterOrEqual(t, projection.ProjectedNumUsedObjects, projection.NumUsedObjects)
+}
+
+func TestEventUsageForMonth(t *testing.T) {
+	t.Parallel()
+	test_util.AssertDemoCompanySecretKeySet(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, test_util.DemoCompanySecretKey())
+
+	report, err := client.UsageReports.EventUsageForMonth(test_util.RequestContext(), 580293, 2021, 11)
+
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Version)
+	require.Equal(t, 1, report.Objects[0].NumFirstSelections)
+}
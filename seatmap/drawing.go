@@ -0,0 +1,153 @@
+package seatmap
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+// The types below hold the parts of a chart drawing, as returned by Charts.RetrievePublishedVersion, that are
+// needed to render it. Unknown fields are ignored.
+
+type drawing struct {
+	Categories struct {
+		List []category `json:"list"`
+	} `json:"categories"`
+	SectionScaleFactor float64    `json:"sectionScaleFactor"`
+	SubChart           *subChart  `json:"subChart"`
+	SubChartFloors     []subChart `json:"subChartFloors"`
+}
+
+type category struct {
+	Key   categoryKey `json:"key"`
+	Label string      `json:"label"`
+	Color string      `json:"color"`
+}
+
+// categoryKey is a category key as a string, whether the drawing holds it as a number or as a string.
+type categoryKey string
+
+func (key *categoryKey) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch typed := value.(type) {
+	case float64:
+		*key = categoryKey(strconv.FormatFloat(typed, 'f', -1, 64))
+	case string:
+		*key = categoryKey(typed)
+	}
+	return nil
+}
+
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type subChart struct {
+	Width                 float64   `json:"width"`
+	Height                float64   `json:"height"`
+	FloorName             string    `json:"floorName"`
+	Sections              []section `json:"sections"`
+	Shapes                []shape   `json:"shapes"`
+	Texts                 []text    `json:"texts"`
+	GeneralAdmissionAreas []area    `json:"generalAdmissionAreas"`
+	Booths                []booth   `json:"booths"`
+	Tables                []table   `json:"tables"`
+	Rows                  []row     `json:"rows"`
+}
+
+type section struct {
+	Label       string      `json:"label"`
+	LabelSize   float64     `json:"labelSize"`
+	CategoryKey categoryKey `json:"categoryKey"`
+	Points      []point     `json:"points"`
+	TopLeft     point       `json:"topLeft"`
+	SubChart    *subChart   `json:"subChart"`
+}
+
+type row struct {
+	Label string `json:"label"`
+	Seats []seat `json:"seats"`
+}
+
+type seat struct {
+	X           float64     `json:"x"`
+	Y           float64     `json:"y"`
+	Label       string      `json:"label"`
+	CategoryKey categoryKey `json:"categoryKey"`
+}
+
+type table struct {
+	Label         string      `json:"label"`
+	Type          string      `json:"type"`
+	Center        point       `json:"center"`
+	Radius        float64     `json:"radius"`
+	Width         float64     `json:"width"`
+	Height        float64     `json:"height"`
+	RotationAngle float64     `json:"rotationAngle"`
+	CategoryKey   categoryKey `json:"categoryKey"`
+	BookAsAWhole  bool        `json:"bookAsAWhole"`
+	Seats         []seat      `json:"seats"`
+}
+
+type booth struct {
+	Label         string      `json:"label"`
+	Center        point       `json:"center"`
+	Width         float64     `json:"width"`
+	Height        float64     `json:"height"`
+	RotationAngle float64     `json:"rotationAngle"`
+	CategoryKey   categoryKey `json:"categoryKey"`
+}
+
+type area struct {
+	Label         string      `json:"label"`
+	LabelShown    bool        `json:"labelShown"`
+	LabelSize     float64     `json:"labelSize"`
+	Type          string      `json:"type"`
+	Center        point       `json:"center"`
+	Radius1       float64     `json:"radius1"`
+	Radius2       float64     `json:"radius2"`
+	Width         float64     `json:"width"`
+	Height        float64     `json:"height"`
+	Points        []point     `json:"points"`
+	RotationAngle float64     `json:"rotationAngle"`
+	CategoryKey   categoryKey `json:"categoryKey"`
+}
+
+type shape struct {
+	Label         string  `json:"label"`
+	LabelSize     float64 `json:"labelSize"`
+	Type          string  `json:"type"`
+	Center        point   `json:"center"`
+	Width         float64 `json:"width"`
+	Height        float64 `json:"height"`
+	Radius        float64 `json:"radius"`
+	CornerRadius  float64 `json:"cornerRadius"`
+	Points        []point `json:"points"`
+	RotationAngle float64 `json:"rotationAngle"`
+	FillColor     string  `json:"fillColor"`
+	StrokeColor   string  `json:"strokeColor"`
+	StrokeWidth   float64 `json:"strokeWidth"`
+}
+
+type text struct {
+	Text          string  `json:"text"`
+	Center        point   `json:"center"`
+	FontSize      float64 `json:"fontSize"`
+	TextColor     string  `json:"textColor"`
+	RotationAngle float64 `json:"rotationAngle"`
+}
+
+func parseDrawing(raw map[string]interface{}) (*drawing, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return nil, err
+	}
+	var parsed drawing
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
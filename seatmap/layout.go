@@ -0,0 +1,75 @@
+package seatmap
+
+// Layout holds the positions of the objects of a chart, for callers that draw a simplified map themselves, such
+// as the mini seat map on a ticket.
+type Layout struct {
+	Width   float64
+	Height  float64
+	Objects []ObjectPosition
+}
+
+// ObjectPosition is the centre of an object in chart coordinates, with the object type as in
+// EventObjectInfo.ObjectType.
+type ObjectPosition struct {
+	Label      string
+	ObjectType string
+	X          float64
+	Y          float64
+}
+
+// NewLayout reads the positions of the seats, tables, booths and general admission areas of a drawing. Objects in
+// sections are placed in chart coordinates. floorName selects the floor of a multi-floor chart; "" is the first.
+func NewLayout(rawDrawing map[string]interface{}, floorName string) (*Layout, error) {
+	drawing, err := parseDrawing(rawDrawing)
+	if err != nil {
+		return nil, err
+	}
+	chart, err := floor(drawing, floorName)
+	if err != nil {
+		return nil, err
+	}
+	scale := 1.0
+	if drawing.SectionScaleFactor > 0 {
+		scale = drawing.SectionScaleFactor / 100
+	}
+	layout := &Layout{Width: chart.Width, Height: chart.Height}
+	layout.add(chart, nil, func(p point) point { return p }, scale)
+	return layout, nil
+}
+
+func (layout *Layout) add(chart *subChart, labelPrefix []string, transform func(point) point, scale float64) {
+	position := func(label string, objectType string, at point) {
+		at = transform(at)
+		layout.Objects = append(layout.Objects, ObjectPosition{Label: label, ObjectType: objectType, X: at.X, Y: at.Y})
+	}
+	for _, section := range chart.Sections {
+		if section.SubChart == nil {
+			continue
+		}
+		topLeft := section.TopLeft
+		layout.add(section.SubChart, []string{section.Label}, func(p point) point {
+			return transform(point{topLeft.X + p.X*scale, topLeft.Y + p.Y*scale})
+		}, scale)
+	}
+	for _, area := range chart.GeneralAdmissionAreas {
+		center := area.Center
+		if area.Type == "polygon" {
+			center = centroid(area.Points)
+		}
+		position(objectLabel(labelPrefix, area.Label), "generalAdmission", center)
+	}
+	for _, booth := range chart.Booths {
+		position(objectLabel(labelPrefix, booth.Label), "booth", booth.Center)
+	}
+	for _, table := range chart.Tables {
+		position(objectLabel(labelPrefix, table.Label), "table", table.Center)
+		for _, seat := range table.Seats {
+			position(objectLabel(labelPrefix, table.Label, seat.Label), "seat", point{seat.X, seat.Y})
+		}
+	}
+	for _, row := range chart.Rows {
+		for _, seat := range row.Seats {
+			position(objectLabel(labelPrefix, row.Label, seat.Label), "seat", point{seat.X, seat.Y})
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package seatmap
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+)
+
+const seatRadius = 5
+const padding = 10
+const defaultColor = "#aaaaaa"
+
+// Renderer draws a chart as SVG, without the JavaScript renderer: sections, rows, seats, tables, booths, general
+// admission areas, shapes, texts and labels. Objects get the colour of their category, or of their status when
+// Objects holds a status other than free. Every object has a title with its label and status, for screen readers.
+type Renderer struct {
+	// Objects holds the event objects by label, as returned by Events.RetrieveObjectInfo or ObjectsFromReport.
+	Objects map[string]events.EventObjectInfo
+	// Highlighted objects, such as the seats a customer booked, are drawn in HighlightColor.
+	Highlighted []string
+	// StatusColors sets the colour per status. Objects with another status than free that is not in StatusColors,
+	// and free objects that are not available, are drawn in UnavailableColor.
+	StatusColors     map[string]string
+	UnavailableColor string
+	HighlightColor   string
+	// Floor selects the floor of a multi-floor chart, by floor name. Defaults to the first floor.
+	Floor string
+	// SeatLabels draws the labels of seats inside them. They are small, so they are off by default.
+	SeatLabels bool
+}
+
+// ObjectsFromReport turns the items of an event report by label into the Objects of a Renderer.
+func ObjectsFromReport(items map[string][]events.EventObjectInfo) map[string]events.EventObjectInfo {
+	objects := make(map[string]events.EventObjectInfo, len(items))
+	for label, infos := range items {
+		if len(infos) > 0 {
+			objects[label] = infos[0]
+		}
+	}
+	return objects
+}
+
+type rendering struct {
+	renderer    *Renderer
+	scale       float64
+	categories  map[categoryKey]string
+	highlighted map[string]bool
+	out         bytes.Buffer
+}
+
+// Render writes the drawing, as returned by Charts.RetrievePublishedVersion or RetrieveDraftVersion, as an SVG
+// document.
+func (renderer *Renderer) Render(writer io.Writer, rawDrawing map[string]interface{}) error {
+	drawing, err := parseDrawing(rawDrawing)
+	if err != nil {
+		return err
+	}
+	chart, err := floor(drawing, renderer.Floor)
+	if err != nil {
+		return err
+	}
+	r := &rendering{
+		renderer:    renderer,
+		scale:       1,
+		categories:  map[categoryKey]string{},
+		highlighted: map[string]bool{},
+	}
+	if drawing.SectionScaleFactor > 0 {
+		r.scale = drawing.SectionScaleFactor / 100
+	}
+	for _, category := range drawing.Categories.List {
+		r.categories[category.Key] = category.Color
+	}
+	for _, label := range renderer.Highlighted {
+		r.highlighted[label] = true
+	}
+
+	width, height := chart.Width+2*padding, chart.Height+2*padding
+	fmt.Fprintf(&r.out, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%s %s %s %s" width="%s" height="%s" role="img" font-family="sans-serif">`,
+		num(-padding), num(-padding), num(width), num(height), num(width), num(height))
+	r.subChart(chart, nil, "")
+	r.out.WriteString("</svg>\n")
+	_, err = writer.Write(r.out.Bytes())
+	return err
+}
+
+func floor(drawing *drawing, floorName string) (*subChart, error) {
+	if len(drawing.SubChartFloors) > 0 {
+		if floorName == "" {
+			return &drawing.SubChartFloors[0], nil
+		}
+		for i := range drawing.SubChartFloors {
+			if drawing.SubChartFloors[i].FloorName == floorName {
+				return &drawing.SubChartFloors[i], nil
+			}
+		}
+		return nil, fmt.Errorf("the chart has no floor %s", floorName)
+	}
+	if floorName != "" {
+		return nil, fmt.Errorf("the chart has no floor %s", floorName)
+	}
+	if drawing.SubChart == nil {
+		return nil, errors.New("the drawing has no subChart")
+	}
+	return drawing.SubChart, nil
+}
+
+// subChart draws a chart or the inside of a section. Objects in a section are labelled with the section label first,
+// and take the category of the section when they have none.
+func (r *rendering) subChart(chart *subChart, labelPrefix []string, sectionCategory categoryKey) {
+	for _, shape := range chart.Shapes {
+		r.shape(shape)
+	}
+	for _, section := range chart.Sections {
+		r.section(section)
+	}
+	for _, area := range chart.GeneralAdmissionAreas {
+		label := objectLabel(labelPrefix, area.Label)
+		fill, title := r.objectStyle(label, inherit(area.CategoryKey, sectionCategory))
+		r.open("g", "data-label", label)
+		r.title(title)
+		switch area.Type {
+		case "rectangle":
+			r.rectangle(area.Center, area.Width, area.Height, 0, area.RotationAngle, fill, "", 0)
+		case "polygon":
+			r.polygon(area.Points, fill, "", 0)
+		default:
+			r.ellipse(area.Center, area.Radius1, area.Radius2, area.RotationAngle, fill)
+		}
+		if area.LabelShown {
+			r.label(area.Center, area.Label, valueOrDefault(area.LabelSize, 12), "#222222")
+		}
+		r.close("g")
+	}
+	for _, booth := range chart.Booths {
+		label := objectLabel(labelPrefix, booth.Label)
+		fill, title := r.objectStyle(label, inherit(booth.CategoryKey, sectionCategory))
+		r.open("g", "data-label", label)
+		r.title(title)
+		r.rectangle(booth.Center, booth.Width, booth.Height, 1, booth.RotationAngle, fill, "", 0)
+		r.label(booth.Center, booth.Label, 6, "#222222")
+		r.close("g")
+	}
+	for _, table := range chart.Tables {
+		r.table(table, labelPrefix, sectionCategory)
+	}
+	for _, row := range chart.Rows {
+		for _, seat := range row.Seats {
+			r.seat(seat, objectLabel(labelPrefix, row.Label, seat.Label), sectionCategory)
+		}
+	}
+	for _, text := range chart.Texts {
+		if text.Text == "" {
+			continue
+		}
+		r.open("g", "transform", rotation(text.RotationAngle, text.Center))
+		r.label(text.Center, text.Text, valueOrDefault(text.FontSize, 12), valueOrString(text.TextColor, "#222222"))
+		r.close("g")
+	}
+}
+
+func (r *rendering) section(section section) {
+	fill := r.categoryColor(section.CategoryKey)
+	r.open("g", "data-label", section.Label)
+	r.title(section.Label)
+	fmt.Fprintf(&r.out, `<polygon points="%s" fill="%s" fill-opacity="0.35" stroke="#888888" stroke-width="1"/>`, points(section.Points), fill)
+	if section.SubChart != nil {
+		r.open("g", "transform", fmt.Sprintf("translate(%s %s) scale(%s)", num(section.TopLeft.X), num(section.TopLeft.Y), num(r.scale)))
+		r.subChart(section.SubChart, []string{section.Label}, section.CategoryKey)
+		r.close("g")
+	} else {
+		r.label(centroid(section.Points), section.Label, valueOrDefault(section.LabelSize, 12), "#222222")
+	}
+	r.close("g")
+}
+
+func (r *rendering) table(table table, labelPrefix []string, sectionCategory categoryKey) {
+	label := objectLabel(labelPrefix, table.Label)
+	fill, stroke, title := "#f2f2f2", "#999999", table.Label
+	if table.BookAsAWhole {
+		fill, title = r.objectStyle(label, inherit(table.CategoryKey, sectionCategory))
+	}
+	r.open("g", "data-label", label)
+	r.title(title)
+	if table.Type == "rectangle" {
+		r.rectangle(table.Center, table.Width, table.Height, 2, table.RotationAngle, fill, stroke, 1)
+	} else {
+		fmt.Fprintf(&r.out, `<circle cx="%s" cy="%s" r="%s" fill="%s" stroke="%s" stroke-width="1"/>`,
+			num(table.Center.X), num(table.Center.Y), num(table.Radius), fill, stroke)
+	}
+	r.label(table.Center, table.Label, 8, "#222222")
+	r.close("g")
+	for _, seat := range table.Seats {
+		r.seat(seat, objectLabel(labelPrefix, table.Label, seat.Label), inherit(table.CategoryKey, sectionCategory))
+	}
+}
+
+func (r *rendering) seat(seat seat, label string, inheritedCategory categoryKey) {
+	fill, title := r.objectStyle(label, inherit(seat.CategoryKey, inheritedCategory))
+	stroke := ""
+	if r.highlighted[label] {
+		stroke = ` stroke="#222222" stroke-width="1.5"`
+	}
+	fmt.Fprintf(&r.out, `<circle cx="%s" cy="%s" r="%s" fill="%s"%s data-label="%s">`, num(seat.X), num(seat.Y), num(seatRadius), fill, stroke, escape(label))
+	r.title(title)
+	r.out.WriteString("</circle>")
+	if r.renderer.SeatLabels {
+		r.label(point{seat.X, seat.Y}, seat.Label, 5, "#222222")
+	}
+}
+
+func (r *rendering) shape(shape shape) {
+	fill := valueOrString(shape.FillColor, "#d0d1d2")
+	switch shape.Type {
+	case "rectangle":
+		r.rectangle(shape.Center, shape.Width, shape.Height, shape.CornerRadius, shape.RotationAngle, fill, shape.StrokeColor, shape.StrokeWidth)
+	case "circle":
+		radius := valueOrDefault(shape.Radius, shape.Width/2)
+		r.ellipse(shape.Center, radius, radius, 0, fill)
+	case "ellipse":
+		r.ellipse(shape.Center, shape.Width/2, shape.Height/2, shape.RotationAngle, fill)
+	case "polygon":
+		r.polygon(shape.Points, fill, shape.StrokeColor, shape.StrokeWidth)
+	default:
+		return
+	}
+	if shape.Label != "" {
+		r.label(shape.Center, shape.Label, valueOrDefault(shape.LabelSize, 12), "#222222")
+	}
+}
+
+// objectStyle returns the fill colour and the title of an object.
+func (r *rendering) objectStyle(label string, key categoryKey) (string, string) {
+	renderer := r.renderer
+	info, ok := renderer.Objects[label]
+	title := label
+	if ok && info.Status != "" && info.Status != events.FREE {
+		title += ", " + info.Status
+	} else if ok && info.AvailabilityReason != "" && !info.IsAvailable {
+		title += ", not available"
+	}
+	switch {
+	case r.highlighted[label]:
+		return valueOrString(renderer.HighlightColor, "#e4007c"), title
+	case ok && info.Status != "" && info.Status != events.FREE:
+		if color, ok := renderer.StatusColors[info.Status]; ok {
+			return color, title
+		}
+		return valueOrString(renderer.UnavailableColor, "#d6d6d6"), title
+	case ok && info.AvailabilityReason != "" && !info.IsAvailable:
+		return valueOrString(renderer.UnavailableColor, "#d6d6d6"), title
+	}
+	if ok && key == "" && info.CategoryKey.Key != nil {
+		key = categoryKey(info.CategoryKey.KeyAsString())
+	}
+	return r.categoryColor(key), title
+}
+
+func (r *rendering) categoryColor(key categoryKey) string {
+	if color, ok := r.categories[key]; ok && color != "" {
+		return color
+	}
+	return defaultColor
+}
+
+func (r *rendering) open(element string, attribute string, value string) {
+	if value == "" {
+		fmt.Fprintf(&r.out, "<%s>", element)
+		return
+	}
+	fmt.Fprintf(&r.out, `<%s %s="%s">`, element, attribute, escape(value))
+}
+
+func (r *rendering) close(element string) {
+	fmt.Fprintf(&r.out, "</%s>", element)
+}
+
+func (r *rendering) title(title string) {
+	if title != "" {
+		fmt.Fprintf(&r.out, "<title>%s</title>", escape(title))
+	}
+}
+
+func (r *rendering) label(at point, label string, size float64, color string) {
+	if label == "" {
+		return
+	}
+	fmt.Fprintf(&r.out, `<text x="%s" y="%s" font-size="%s" fill="%s" text-anchor="middle" dominant-baseline="central">%s</text>`,
+		num(at.X), num(at.Y), num(size), escape(color), escape(label))
+}
+
+func (r *rendering) rectangle(center point, width float64, height float64, cornerRadius float64, rotationAngle float64, fill string, stroke string, strokeWidth float64) {
+	fmt.Fprintf(&r.out, `<rect x="%s" y="%s" width="%s" height="%s" rx="%s" fill="%s"%s%s/>`,
+		num(center.X-width/2), num(center.Y-height/2), num(width), num(height), num(cornerRadius), escape(fill),
+		strokeAttributes(stroke, strokeWidth), transformAttribute(rotationAngle, center))
+}
+
+func (r *rendering) ellipse(center point, radiusX float64, radiusY float64, rotationAngle float64, fill string) {
+	fmt.Fprintf(&r.out, `<ellipse cx="%s" cy="%s" rx="%s" ry="%s" fill="%s"%s/>`,
+		num(center.X), num(center.Y), num(radiusX), num(radiusY), escape(fill), transformAttribute(rotationAngle, center))
+}
+
+func (r *rendering) polygon(vertices []point, fill string, stroke string, strokeWidth float64) {
+	fmt.Fprintf(&r.out, `<polygon points="%s" fill="%s"%s/>`, points(vertices), escape(fill), strokeAttributes(stroke, strokeWidth))
+}
+
+func strokeAttributes(stroke string, strokeWidth float64) string {
+	if stroke == "" || strokeWidth <= 0 {
+		return ""
+	}
+	return fmt.Sprintf(` stroke="%s" stroke-width="%s"`, escape(stroke), num(strokeWidth))
+}
+
+func transformAttribute(rotationAngle float64, center point) string {
+	if rotationAngle == 0 {
+		return ""
+	}
+	return fmt.Sprintf(` transform="%s"`, rotation(rotationAngle, center))
+}
+
+func rotation(rotationAngle float64, center point) string {
+	if rotationAngle == 0 {
+		return ""
+	}
+	return fmt.Sprintf("rotate(%s %s %s)", num(rotationAngle), num(center.X), num(center.Y))
+}
+
+func points(vertices []point) string {
+	parts := make([]string, 0, len(vertices))
+	for _, vertex := range vertices {
+		parts = append(parts, num(vertex.X)+","+num(vertex.Y))
+	}
+	return strings.Join(parts, " ")
+}
+
+func centroid(vertices []point) point {
+	var center point
+	for _, vertex := range vertices {
+		center.X += vertex.X / float64(len(vertices))
+		center.Y += vertex.Y / float64(len(vertices))
+	}
+	return center
+}
+
+// objectLabel builds the label of an object the way the API does, for instance Section A-B-12.
+func objectLabel(prefix []string, parts ...string) string {
+	var nonEmpty []string
+	for _, part := range append(append([]string{}, prefix...), parts...) {
+		if part != "" {
+			nonEmpty = append(nonEmpty, part)
+		}
+	}
+	return strings.Join(nonEmpty, "-")
+}
+
+func inherit(key categoryKey, inherited categoryKey) categoryKey {
+	if key == "" {
+		return inherited
+	}
+	return key
+}
+
+func num(value float64) string {
+	return strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64)
+}
+
+func escape(value string) string {
+	return html.EscapeString(value)
+}
+
+func valueOrDefault(value float64, defaultValue float64) float64 {
+	if value == 0 {
+		return defaultValue
+	}
+	return value
+}
+
+func valueOrString(value string, defaultValue string) string {
+	if value == "" {
+		return defaultValue
+	}
+	return value
+}
@@ -0,0 +1,94 @@
+package seatmap_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seatmap"
+	"github.com/stretchr/testify/require"
+)
+
+func readDrawing(t *testing.T, fileName string) map[string]interface{} {
+	data, err := os.ReadFile("../test_util/charts/" + fileName)
+	require.NoError(t, err)
+	var drawing map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &drawing))
+	return drawing
+}
+
+func render(t *testing.T, renderer *seatmap.Renderer, fileName string) string {
+	var svg bytes.Buffer
+	require.NoError(t, renderer.Render(&svg, readDrawing(t, fileName)))
+	require.NoError(t, xml.Unmarshal(svg.Bytes(), new(interface{})), "the SVG is not well-formed")
+	return svg.String()
+}
+
+func TestRenderSeatsGeneralAdmissionAndShapes(t *testing.T) {
+	t.Parallel()
+
+	svg := render(t, &seatmap.Renderer{}, "sampleChart.json")
+
+	require.True(t, strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-10 -10 462.44 206"`))
+	require.Contains(t, svg, `<circle cx="151.94" cy="99.56" r="5" fill="#87A9CD" data-label="A-1"><title>A-1</title></circle>`)
+	require.Contains(t, svg, `<g data-label="GA1"><title>GA1</title><ellipse cx="53.22" cy="139.45" rx="52.22" ry="45.56" fill="#87A9CD"/>`)
+	require.Contains(t, svg, `>Stage</text>`)
+	require.Equal(t, 32, strings.Count(svg, "<circle"))
+}
+
+func TestRenderColoursObjectsByStatusAndHighlights(t *testing.T) {
+	t.Parallel()
+	renderer := &seatmap.Renderer{
+		Objects: map[string]events.EventObjectInfo{
+			"A-1": {Status: events.BOOKED},
+			"A-2": {Status: events.HELD},
+			"A-3": {Status: events.FREE, IsAvailable: false, AvailabilityReason: "not_for_sale"},
+			"A-4": {Status: events.BOOKED},
+		},
+		StatusColors: map[string]string{events.HELD: "#ffcc00"},
+		Highlighted:  []string{"A-4"},
+	}
+
+	svg := render(t, renderer, "sampleChart.json")
+
+	require.Contains(t, svg, `fill="#d6d6d6" data-label="A-1"><title>A-1, booked</title>`)
+	require.Contains(t, svg, `fill="#ffcc00" data-label="A-2"><title>A-2, reservedByToken</title>`)
+	require.Contains(t, svg, `fill="#d6d6d6" data-label="A-3"><title>A-3, not available</title>`)
+	require.Contains(t, svg, `fill="#e4007c" stroke="#222222" stroke-width="1.5" data-label="A-4">`)
+	require.Contains(t, svg, `fill="#87A9CD" data-label="A-5">`)
+}
+
+func TestRenderSectionsAndTables(t *testing.T) {
+	t.Parallel()
+
+	sections := render(t, &seatmap.Renderer{}, "sampleChartWithSections.json")
+	tables := render(t, &seatmap.Renderer{}, "sampleChartWithTables.json")
+
+	require.Contains(t, sections, `<g data-label="Section A"><title>Section A</title><polygon points="0.75,0.75 0.75,240.75 132.75,240.75 132.75,0.75" fill="#AEDB54"`)
+	require.Contains(t, sections, `fill="#AEDB54" data-label="Section A-A-1">`)
+	require.Contains(t, tables, `<g data-label="T1"><title>T1</title><circle cx="35.74" cy="39.87" r="23.87" fill="#f2f2f2"`)
+	require.Contains(t, tables, `data-label="T1-1">`)
+}
+
+func TestRenderFloors(t *testing.T) {
+	t.Parallel()
+	drawing := readDrawing(t, "sampleChartWithFloors.json")
+	floors := drawing["subChartFloors"].([]interface{})
+	secondFloor := floors[1].(map[string]interface{})["floorName"].(string)
+
+	var svg bytes.Buffer
+	require.NoError(t, (&seatmap.Renderer{Floor: secondFloor}).Render(&svg, drawing))
+	require.EqualError(t, (&seatmap.Renderer{Floor: "unknown"}).Render(&svg, drawing), "the chart has no floor unknown")
+}
+
+func TestObjectsFromReport(t *testing.T) {
+	t.Parallel()
+
+	objects := seatmap.ObjectsFromReport(map[string][]events.EventObjectInfo{"A-1": {{Label: "A-1", Status: events.BOOKED}}})
+
+	require.Equal(t, map[string]events.EventObjectInfo{"A-1": {Label: "A-1", Status: events.BOOKED}}, objects)
+}
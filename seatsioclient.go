@@ -12,6 +12,7 @@ import (
 	"github.com/seatsio/seatsio-go/v12/shared"
 	"github.com/seatsio/seatsio-go/v12/ticketbuyers"
 	"github.com/seatsio/seatsio-go/v12/workspaces"
+	"sync"
 )
 
 type seatsioClientNS struct {
@@ -31,51 +32,61 @@ const (
 )
 
 type SeatsioClient struct {
-	baseUrl      string
-	secretKey    string
-	workspaceKey string
-	Workspaces   *workspaces.Workspaces
-	Charts       *charts.Charts
-	Events       *events.Events
-	HoldTokens   *holdtokens.HoldTokens
-	ChartReports *reports.ChartReports
-	EventReports *reports.EventReports
-	UsageReports *reports.UsageReports
-	Channels     *events.Channels
-	Seasons      *seasons.Seasons
-	EventLog     *eventlog.EventLog
-	TicketBuyers *ticketbuyers.TicketBuyers
+	baseUrl         string
+	secretKey       string
+	workspaceKey    string
+	apiClient       *req.Client
+	credentials     *swappableProvider
+	credentialsOnce sync.Once
+	Workspaces      *workspaces.Workspaces
+	Charts          *charts.Charts
+	Events          *events.Events
+	HoldTokens      *holdtokens.HoldTokens
+	ChartReports    *reports.ChartReports
+	EventReports    *reports.EventReports
+	UsageReports    *reports.UsageReports
+	SeasonReports   *reports.SeasonReports
+	Channels        *events.Channels
+	Seasons         *seasons.Seasons
+	EventLog        *eventlog.EventLog
+	TicketBuyers    *ticketbuyers.TicketBuyers
 }
 
 func NewSeatsioClient(baseUrl string, secretKey string, additionalHeaders ...shared.AdditionalHeader) *SeatsioClient {
 	apiClient := shared.ApiClient(secretKey, baseUrl, additionalHeaders...)
-	client := &SeatsioClient{
+	client := newSeatsioClient(baseUrl, secretKey, apiClient)
+	ClientSupport.apiClient = apiClient
+	return client
+}
+
+func newSeatsioClient(baseUrl string, secretKey string, apiClient *req.Client) *SeatsioClient {
+	return &SeatsioClient{
 		baseUrl:    baseUrl,
 		secretKey:  secretKey,
+		apiClient:  apiClient,
 		Workspaces: &workspaces.Workspaces{Client: apiClient},
 		Charts: &charts.Charts{
 			Client:  apiClient,
 			Archive: &charts.Archive{Client: apiClient},
 		},
-		Events:       &events.Events{Client: apiClient},
-		HoldTokens:   &holdtokens.HoldTokens{Client: apiClient},
-		ChartReports: &reports.ChartReports{Client: apiClient},
-		EventReports: &reports.EventReports{Client: apiClient},
-		UsageReports: &reports.UsageReports{Client: apiClient},
-		Channels:     &events.Channels{Client: apiClient},
-		Seasons:      &seasons.Seasons{Client: apiClient},
-		EventLog:     &eventlog.EventLog{Client: apiClient},
-		TicketBuyers: &ticketbuyers.TicketBuyers{Client: apiClient},
+		Events:        &events.Events{Client: apiClient},
+		HoldTokens:    &holdtokens.HoldTokens{Client: apiClient},
+		ChartReports:  &reports.ChartReports{Client: apiClient},
+		EventReports:  &reports.EventReports{Client: apiClient},
+		UsageReports:  &reports.UsageReports{Client: apiClient},
+		SeasonReports: &reports.SeasonReports{Client: apiClient},
+		Channels:      &events.Channels{Client: apiClient},
+		Seasons:       &seasons.Seasons{Client: apiClient},
+		EventLog:      &eventlog.EventLog{Client: apiClient},
+		TicketBuyers:  &ticketbuyers.TicketBuyers{Client: apiClient},
 	}
-	ClientSupport.apiClient = apiClient
-	return client
 }
 
 func (c *SeatsioClient) SetMaxRetries(count int) error {
 	if count < 0 {
 		return errors.New("retry count must not be negative")
 	}
-	ClientSupport.apiClient.SetCommonRetryCount(count)
+	c.apiClient.SetCommonRetryCount(count)
 	return nil
 }
 
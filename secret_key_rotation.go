@@ -0,0 +1,56 @@
+package seatsio
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/seatsio/seatsio-go/v12/credentials"
+	"github.com/seatsio/seatsio-go/v12/shared"
+)
+
+// NewSeatsioClientWithCredentials creates a client that asks the provider for its credentials before every request.
+func NewSeatsioClientWithCredentials(baseUrl string, provider credentials.Provider, additionalHeaders ...shared.AdditionalHeader) *SeatsioClient {
+	client := NewSeatsioClient(baseUrl, "", additionalHeaders...)
+	client.SetCredentialsProvider(provider)
+	return client
+}
+
+// SetCredentialsProvider makes a running client take its credentials from the provider from now on. Calling it again
+// replaces the provider.
+func (c *SeatsioClient) SetCredentialsProvider(provider credentials.Provider) {
+	c.credentialsOnce.Do(func() {
+		c.credentials = &swappableProvider{}
+		c.credentials.current.Store(&provider)
+		shared.UseCredentials(c.apiClient, c.credentials)
+	})
+	c.credentials.current.Store(&provider)
+}
+
+type swappableProvider struct {
+	current atomic.Pointer[credentials.Provider]
+}
+
+func (provider *swappableProvider) Credentials(context context.Context) (credentials.Credentials, error) {
+	return (*provider.current.Load()).Credentials(context)
+}
+
+// RotateSecretKey regenerates the secret key of a workspace, hands it to store, and then swaps it into the given
+// credentials, which running clients use through SetCredentialsProvider. The client must use a company admin key.
+// The old key stops working as soon as it is regenerated; requests that are rejected in the meantime are retried
+// with the new key. If store fails, the new key is swapped in anyway, because the old one no longer works, and the
+// error is returned together with the new key so that it is not lost.
+func RotateSecretKey(context context.Context, client *SeatsioClient, workspaceKey string, store func(context context.Context, secretKey string) error, targets ...*credentials.Rotating) (string, error) {
+	secretKey, err := client.Workspaces.RegenerateSecretKey(context, workspaceKey)
+	if err != nil {
+		return "", err
+	}
+	storeErr := store(context, *secretKey)
+	for _, target := range targets {
+		target.SetSecretKey(*secretKey)
+	}
+	if storeErr != nil {
+		return *secretKey, fmt.Errorf("storing the new secret key of workspace %s: %w", workspaceKey, storeErr)
+	}
+	return *secretKey, nil
+}
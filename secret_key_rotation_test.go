@@ -0,0 +1,148 @@
+package seatsio
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/credentials"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/stretchr/testify/require"
+)
+
+// rotationServer accepts one secret key at a time for hold tokens, and swaps it when the company admin key
+// regenerates it.
+func rotationServer(t *testing.T, onUnauthorized func()) (*httptest.Server, *atomic.Value) {
+	accepted := &atomic.Value{}
+	accepted.Store("key1")
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		secretKey, _, _ := request.BasicAuth()
+		writer.Header().Set("Content-Type", "application/json")
+		if request.URL.Path == "/workspaces/ws1/actions/regenerate-secret-key" && secretKey == "adminKey" {
+			accepted.Store("key2")
+			_, _ = writer.Write([]byte(`{"secretKey": "key2"}`))
+			return
+		}
+		if secretKey != accepted.Load() || request.Header.Get("X-Workspace-Key") != "ws1" {
+			if onUnauthorized != nil {
+				onUnauthorized()
+			}
+			writer.WriteHeader(http.StatusUnauthorized)
+			_, _ = writer.Write([]byte(`{"errors": [{"code": "UNAUTHORIZED", "message": "unauthorized"}]}`))
+			return
+		}
+		_, _ = writer.Write([]byte(`{"holdToken": "token", "expiresInSeconds": 900}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, accepted
+}
+
+func TestRotateSecretKey(t *testing.T) {
+	t.Parallel()
+	server, _ := rotationServer(t, nil)
+	rotating := credentials.NewRotating(credentials.Credentials{SecretKey: "key1", WorkspaceKey: "ws1"})
+	client := NewSeatsioClientWithCredentials(server.URL, rotating)
+	_, err := client.HoldTokens.Create(test_util.RequestContext())
+	require.NoError(t, err)
+
+	var stored string
+	secretKey, err := RotateSecretKey(test_util.RequestContext(), NewSeatsioClient(server.URL, "adminKey"), "ws1", func(context context.Context, secretKey string) error {
+		stored = secretKey
+		return nil
+	}, rotating)
+
+	require.NoError(t, err)
+	require.Equal(t, "key2", secretKey)
+	require.Equal(t, "key2", stored)
+	_, err = client.HoldTokens.Create(test_util.RequestContext())
+	require.NoError(t, err)
+}
+
+func TestRotateSecretKeySwapsTheKeyWhenStoringFails(t *testing.T) {
+	t.Parallel()
+	server, _ := rotationServer(t, nil)
+	rotating := credentials.NewRotating(credentials.Credentials{SecretKey: "key1", WorkspaceKey: "ws1"})
+
+	secretKey, err := RotateSecretKey(test_util.RequestContext(), NewSeatsioClient(server.URL, "adminKey"), "ws1", func(context context.Context, secretKey string) error {
+		return errors.New("vault is down")
+	}, rotating)
+
+	require.ErrorContains(t, err, "vault is down")
+	require.Equal(t, "key2", secretKey)
+	current, _ := rotating.Credentials(test_util.RequestContext())
+	require.Equal(t, credentials.Credentials{SecretKey: "key2", WorkspaceKey: "ws1"}, current)
+}
+
+func TestRequestsRejectedDuringRotationAreRetriedWithTheNewKey(t *testing.T) {
+	t.Parallel()
+	rotating := credentials.NewRotating(credentials.Credentials{SecretKey: "key1", WorkspaceKey: "ws1"})
+	server, accepted := rotationServer(t, func() {
+		rotating.SetSecretKey("key2")
+	})
+	accepted.Store("key2")
+	client := NewSeatsioClientWithCredentials(server.URL, rotating)
+
+	_, err := client.HoldTokens.Create(test_util.RequestContext())
+
+	require.NoError(t, err)
+}
+
+func TestRequestsRejectedWithTheCurrentKeyAreNotRetried(t *testing.T) {
+	t.Parallel()
+	var unauthorized atomic.Int32
+	server, _ := rotationServer(t, func() {
+		unauthorized.Add(1)
+	})
+	client := NewSeatsioClientWithCredentials(server.URL, credentials.Static{SecretKey: "wrong", WorkspaceKey: "ws1"})
+
+	_, err := client.HoldTokens.Create(test_util.RequestContext())
+
+	require.Error(t, err)
+	require.Equal(t, int32(1), unauthorized.Load())
+}
+
+func TestSetCredentialsProviderReplacesTheProvider(t *testing.T) {
+	t.Parallel()
+	server, _ := rotationServer(t, nil)
+	client := NewSeatsioClientWithCredentials(server.URL, credentials.Static{SecretKey: "wrong", WorkspaceKey: "ws1"})
+	require.NoError(t, client.SetMaxRetries(0))
+
+	client.SetCredentialsProvider(credentials.Static{SecretKey: "key1", WorkspaceKey: "ws1"})
+	_, err := client.HoldTokens.Create(test_util.RequestContext())
+
+	require.NoError(t, err)
+}
+
+type countingProvider struct {
+	calls atomic.Int64
+}
+
+func (provider *countingProvider) Credentials(context.Context) (credentials.Credentials, error) {
+	provider.calls.Add(1)
+	return credentials.Credentials{SecretKey: "key1", WorkspaceKey: "ws1"}, nil
+}
+
+func TestSetCredentialsProviderConcurrently(t *testing.T) {
+	t.Parallel()
+	server, _ := rotationServer(t, nil)
+	client := NewSeatsioClient(server.URL, "")
+	provider := &countingProvider{}
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.SetCredentialsProvider(provider)
+		}()
+	}
+	wg.Wait()
+
+	_, err := client.HoldTokens.Create(test_util.RequestContext())
+
+	require.NoError(t, err)
+	require.Equal(t, int64(1), provider.calls.Load())
+}
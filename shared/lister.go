@@ -1,6 +1,11 @@
 package shared
 
-import "strconv"
+import (
+	"errors"
+	"strconv"
+)
+
+var ErrStopIteration = errors.New("stop iteration")
 
 type Lister[T interface{}] struct {
 	PageFetcher *PageFetcher[T]
@@ -23,6 +28,28 @@ func (lister *Lister[T]) All(opts ...PaginationParamsOption) ([]T, error) {
 	return result, nil
 }
 
+// ForEach fetches one page at a time and calls fn for every item. Returning ErrStopIteration from fn stops without an error.
+func (lister *Lister[T]) ForEach(fn func(item T) error, opts ...PaginationParamsOption) error {
+	currentPage, err := lister.ListFirstPage(opts...)
+	for {
+		if err != nil {
+			return err
+		}
+		for _, item := range currentPage.Items {
+			if err := fn(item); err != nil {
+				if errors.Is(err, ErrStopIteration) {
+					return nil
+				}
+				return err
+			}
+		}
+		if currentPage.NextPageStartsAfter == 0 {
+			return nil
+		}
+		currentPage, err = lister.ListPageAfter(currentPage.NextPageStartsAfter, opts...)
+	}
+}
+
 func (lister *Lister[T]) ListFirstPage(opts ...PaginationParamsOption) (*Page[T], error) {
 	return lister.PageFetcher.fetchPage(opts...)
 }
@@ -12,6 +12,7 @@ type PageFetcher[T interface{}] struct {
 	UrlParams   map[string]string
 	QueryParams map[string]string
 	Context     *context.Context
+	Filter      func(item T) bool
 }
 
 type Page[T interface{}] struct {
@@ -80,7 +81,20 @@ func (pageFetcher *PageFetcher[T]) fetchPage(opts ...PaginationParamsOption) (*P
 		return nil, err
 	}
 
-	return &Page[T]{page.Items, nextPageStartsAfterInt, previousPageEndsBeforeInt}, nil
+	return &Page[T]{pageFetcher.filter(page.Items), nextPageStartsAfterInt, previousPageEndsBeforeInt}, nil
+}
+
+func (pageFetcher *PageFetcher[T]) filter(items []T) []T {
+	if pageFetcher.Filter == nil {
+		return items
+	}
+	filtered := make([]T, 0, len(items))
+	for _, item := range items {
+		if pageFetcher.Filter(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
 }
 
 func optionalIdToInt(id string) (int64, error) {
@@ -1,5 +1,10 @@
 package shared
 
+import (
+	"errors"
+	"strings"
+)
+
 type SeatsioErrorTO struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
@@ -17,3 +22,8 @@ type SeatsioError struct {
 func (m *SeatsioError) Error() string {
 	return m.Message
 }
+
+func IsNotFound(err error) bool {
+	var seatsioError *SeatsioError
+	return errors.As(err, &seatsioError) && strings.HasSuffix(seatsioError.Code, "_NOT_FOUND")
+}
@@ -1,9 +1,12 @@
 package shared
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12/credentials"
+	"net/http"
 	"strings"
 	"time"
 )
@@ -29,6 +32,48 @@ func ApiClient(secretKey string, baseUrl string, additionalHeaders ...Additional
 	return client
 }
 
+// ApiClientWithRoundTripper is ApiClient, but sends its requests through roundTripper instead of opening its own
+// connections, so that many clients can share one connection pool.
+func ApiClientWithRoundTripper(secretKey string, baseUrl string, roundTripper http.RoundTripper, additionalHeaders ...AdditionalHeader) *req.Client {
+	client := ApiClient(secretKey, baseUrl, additionalHeaders...)
+	client.GetTransport().WrapRoundTripFunc(func(http.RoundTripper) req.HttpRoundTripFunc {
+		return roundTripper.RoundTrip
+	})
+	return client
+}
+
+// UseCredentials makes the client ask the provider for credentials before every request, instead of using the secret
+// key it was created with. A request that is rejected with 401 is retried when the provider returns other
+// credentials by then, so that requests that were in flight during a key rotation succeed.
+func UseCredentials(client *req.Client, provider credentials.Provider) {
+	client.OnBeforeRequest(func(client *req.Client, request *req.Request) error {
+		current, err := provider.Credentials(request.Context())
+		if err != nil {
+			return err
+		}
+		request.SetBasicAuth(current.SecretKey, "")
+		if current.WorkspaceKey != "" {
+			request.SetHeader("X-Workspace-Key", current.WorkspaceKey)
+		}
+		return nil
+	})
+	client.AddCommonRetryCondition(func(response *req.Response, err error) bool {
+		if err != nil || response == nil || response.StatusCode != http.StatusUnauthorized || response.Request == nil {
+			return false
+		}
+		current, err := provider.Credentials(response.Request.Context())
+		if err != nil {
+			return false
+		}
+		sent := response.Request.Headers.Get("Authorization")
+		return sent != "" && sent != basicAuth(current.SecretKey)
+	})
+}
+
+func basicAuth(secretKey string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(secretKey+":"))
+}
+
 func AssertOk[T interface{}](result *req.Response, err error, data *T) (*T, error) {
 	err = AssertOkWithoutResult(result, err)
 	if err != nil {
@@ -0,0 +1,143 @@
+package ticketbuyers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type bulkParams struct {
+	chunkSize   int
+	concurrency int
+}
+
+type BulkOption func(params *bulkParams)
+
+type ticketBuyerSupportNS struct{}
+
+var TicketBuyerSupport ticketBuyerSupportNS
+
+// ChunkSize sets how many ids are sent per request. Defaults to 1000.
+func (ticketBuyerSupportNS) ChunkSize(chunkSize int) BulkOption {
+	return func(params *bulkParams) {
+		params.chunkSize = chunkSize
+	}
+}
+
+// Concurrency sets how many requests are sent at the same time. Defaults to 4.
+func (ticketBuyerSupportNS) Concurrency(concurrency int) BulkOption {
+	return func(params *bulkParams) {
+		params.concurrency = concurrency
+	}
+}
+
+func newBulkParams(opts []BulkOption) *bulkParams {
+	params := &bulkParams{chunkSize: 1000, concurrency: 4}
+	for _, opt := range opts {
+		opt(params)
+	}
+	params.chunkSize = max(params.chunkSize, 1)
+	params.concurrency = max(params.concurrency, 1)
+	return params
+}
+
+// BulkError is returned by AddAll and RemoveAll when some chunks failed. The response that is returned with it holds
+// the results of the chunks that succeeded; Failed holds the ids of the chunks that did not.
+type BulkError struct {
+	Failed []uuid.UUID
+	Err    error
+}
+
+func (err *BulkError) Error() string {
+	return fmt.Sprintf("%d ticket buyer ids were not processed: %v", len(err.Failed), err.Err)
+}
+
+func (err *BulkError) Unwrap() error {
+	return err.Err
+}
+
+// AddAll adds any number of ids, in chunks that are sent concurrently. Duplicate ids are only sent once. The results
+// are in the order of the ids.
+func (ticketBuyers *TicketBuyers) AddAll(context context.Context, ids []uuid.UUID, opts ...BulkOption) (*AddTicketBuyerIdsResponse, error) {
+	chunks, err := forEachChunk(ids, newBulkParams(opts), func(chunk []uuid.UUID) (*AddTicketBuyerIdsResponse, error) {
+		return ticketBuyers.Add(context, &TicketBuyerParams{Ids: chunk})
+	})
+	response := &AddTicketBuyerIdsResponse{Added: []uuid.UUID{}, AlreadyPresent: []uuid.UUID{}}
+	for _, chunk := range chunks {
+		response.Added = append(response.Added, chunk.Added...)
+		response.AlreadyPresent = append(response.AlreadyPresent, chunk.AlreadyPresent...)
+	}
+	return response, err
+}
+
+// RemoveAll removes any number of ids, in chunks that are sent concurrently. Duplicate ids are only sent once. The
+// results are in the order of the ids.
+func (ticketBuyers *TicketBuyers) RemoveAll(context context.Context, ids []uuid.UUID, opts ...BulkOption) (*RemoveTicketBuyerIdsResponse, error) {
+	chunks, err := forEachChunk(ids, newBulkParams(opts), func(chunk []uuid.UUID) (*RemoveTicketBuyerIdsResponse, error) {
+		return ticketBuyers.Remove(context, &TicketBuyerParams{Ids: chunk})
+	})
+	response := &RemoveTicketBuyerIdsResponse{Removed: []uuid.UUID{}, NotPresent: []uuid.UUID{}}
+	for _, chunk := range chunks {
+		response.Removed = append(response.Removed, chunk.Removed...)
+		response.NotPresent = append(response.NotPresent, chunk.NotPresent...)
+	}
+	return response, err
+}
+
+// forEachChunk calls fn for the chunks of the distinct ids, concurrently, and returns the results of the chunks that
+// succeeded, in order.
+func forEachChunk[T any](ids []uuid.UUID, params *bulkParams, fn func(chunk []uuid.UUID) (T, error)) ([]T, error) {
+	chunks := chunked(distinct(ids), params.chunkSize)
+	results := make([]T, len(chunks))
+	errs := make([]error, len(chunks))
+	semaphore := make(chan struct{}, params.concurrency)
+	var wg sync.WaitGroup
+	for i := range chunks {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			results[i], errs[i] = fn(chunks[i])
+		}(i)
+	}
+	wg.Wait()
+
+	succeeded := make([]T, 0, len(chunks))
+	bulkError := &BulkError{}
+	for i := range chunks {
+		if errs[i] != nil {
+			bulkError.Failed = append(bulkError.Failed, chunks[i]...)
+			bulkError.Err = errors.Join(bulkError.Err, errs[i])
+			continue
+		}
+		succeeded = append(succeeded, results[i])
+	}
+	if bulkError.Err != nil {
+		return succeeded, bulkError
+	}
+	return succeeded, nil
+}
+
+func distinct(ids []uuid.UUID) []uuid.UUID {
+	seen := make(map[uuid.UUID]bool, len(ids))
+	result := make([]uuid.UUID, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			result = append(result, id)
+		}
+	}
+	return result
+}
+
+func chunked(ids []uuid.UUID, size int) [][]uuid.UUID {
+	var chunks [][]uuid.UUID
+	for start := 0; start < len(ids); start += size {
+		chunks = append(chunks, ids[start:min(start+size, len(ids))])
+	}
+	return chunks
+}
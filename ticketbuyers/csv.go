@@ -0,0 +1,81 @@
+package ticketbuyers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const csvIdColumn = "id"
+
+// ReadIdsCSV reads ticket buyer ids from CSV. When the first line has an "id" column, the ids are read from that
+// column; otherwise they are read from the first column, starting at the first line. Empty lines are skipped.
+func ReadIdsCSV(reader io.Reader) ([]uuid.UUID, error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	var ids []uuid.UUID
+	column := 0
+	for first := true; ; first = false {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			return ids, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if first {
+			if headerColumn := indexOf(record, csvIdColumn); headerColumn >= 0 {
+				column = headerColumn
+				continue
+			}
+		}
+		if column >= len(record) || strings.TrimSpace(record[column]) == "" {
+			continue
+		}
+		id, err := uuid.Parse(strings.TrimSpace(record[column]))
+		if err != nil {
+			line, _ := csvReader.FieldPos(column)
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		ids = append(ids, id)
+	}
+}
+
+func indexOf(record []string, column string) int {
+	for i, value := range record {
+		if strings.EqualFold(strings.TrimSpace(value), column) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ImportCSV adds the ids that ReadIdsCSV reads.
+func (ticketBuyers *TicketBuyers) ImportCSV(context context.Context, reader io.Reader, opts ...BulkOption) (*AddTicketBuyerIdsResponse, error) {
+	ids, err := ReadIdsCSV(reader)
+	if err != nil {
+		return nil, err
+	}
+	return ticketBuyers.AddAll(context, ids, opts...)
+}
+
+// ExportCSV writes all ticket buyer ids as CSV with an "id" column, one page at a time.
+func (ticketBuyers *TicketBuyers) ExportCSV(context context.Context, writer io.Writer) error {
+	csvWriter := csv.NewWriter(writer)
+	if err := csvWriter.Write([]string{csvIdColumn}); err != nil {
+		return err
+	}
+	err := ticketBuyers.ForEach(context, func(id uuid.UUID) error {
+		return csvWriter.Write([]string{id.String()})
+	})
+	if err != nil {
+		return err
+	}
+	csvWriter.Flush()
+	return csvWriter.Error()
+}
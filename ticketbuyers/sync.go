@@ -0,0 +1,79 @@
+package ticketbuyers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// SyncPlan holds the ids that need to be added and removed to make the ticket buyers equal to a desired set of ids.
+type SyncPlan struct {
+	ToAdd    []uuid.UUID
+	ToRemove []uuid.UUID
+}
+
+func (plan *SyncPlan) IsEmpty() bool {
+	return len(plan.ToAdd) == 0 && len(plan.ToRemove) == 0
+}
+
+type SyncResult struct {
+	Plan    SyncPlan
+	Added   *AddTicketBuyerIdsResponse
+	Removed *RemoveTicketBuyerIdsResponse
+}
+
+// PlanSync compares the current ticket buyers with the desired ids, without changing anything. ToAdd is in the order
+// of the desired ids, ToRemove in the order in which the ticket buyers are listed.
+func (ticketBuyers *TicketBuyers) PlanSync(context context.Context, desiredIds []uuid.UUID) (*SyncPlan, error) {
+	desired := make(map[uuid.UUID]bool, len(desiredIds))
+	for _, id := range desiredIds {
+		desired[id] = true
+	}
+	current := map[uuid.UUID]bool{}
+	plan := &SyncPlan{ToAdd: []uuid.UUID{}, ToRemove: []uuid.UUID{}}
+	err := ticketBuyers.ForEach(context, func(id uuid.UUID) error {
+		current[id] = true
+		if !desired[id] {
+			plan.ToRemove = append(plan.ToRemove, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range distinct(desiredIds) {
+		if !current[id] {
+			plan.ToAdd = append(plan.ToAdd, id)
+		}
+	}
+	return plan, nil
+}
+
+// Sync makes the ticket buyers equal to the desired ids, by adding the ones that are missing and removing the ones
+// that are not desired. Ids that are already in place are not sent.
+func (ticketBuyers *TicketBuyers) Sync(context context.Context, desiredIds []uuid.UUID, opts ...BulkOption) (*SyncResult, error) {
+	plan, err := ticketBuyers.PlanSync(context, desiredIds)
+	if err != nil {
+		return nil, err
+	}
+	return ticketBuyers.ApplySync(context, plan, opts...)
+}
+
+func (ticketBuyers *TicketBuyers) ApplySync(context context.Context, plan *SyncPlan, opts ...BulkOption) (*SyncResult, error) {
+	result := &SyncResult{
+		Plan:    *plan,
+		Added:   &AddTicketBuyerIdsResponse{Added: []uuid.UUID{}, AlreadyPresent: []uuid.UUID{}},
+		Removed: &RemoveTicketBuyerIdsResponse{Removed: []uuid.UUID{}, NotPresent: []uuid.UUID{}},
+	}
+	var err error
+	if len(plan.ToAdd) > 0 {
+		result.Added, err = ticketBuyers.AddAll(context, plan.ToAdd, opts...)
+		if err != nil {
+			return result, err
+		}
+	}
+	if len(plan.ToRemove) > 0 {
+		result.Removed, err = ticketBuyers.RemoveAll(context, plan.ToRemove, opts...)
+	}
+	return result, err
+}
@@ -58,3 +58,20 @@ func (ticketBuyers *TicketBuyers) lister(context context.Context) *shared.Lister
 func (ticketBuyers *TicketBuyers) ListAll(context context.Context) ([]uuid.UUID, error) {
 	return ticketBuyers.lister(context).All()
 }
+
+func (ticketBuyers *TicketBuyers) ListFirstPage(context context.Context, opts ...shared.PaginationParamsOption) (*shared.Page[uuid.UUID], error) {
+	return ticketBuyers.lister(context).ListFirstPage(opts...)
+}
+
+func (ticketBuyers *TicketBuyers) ListPageAfter(context context.Context, id int64, opts ...shared.PaginationParamsOption) (*shared.Page[uuid.UUID], error) {
+	return ticketBuyers.lister(context).ListPageAfter(id, opts...)
+}
+
+func (ticketBuyers *TicketBuyers) ListPageBefore(context context.Context, id int64, opts ...shared.PaginationParamsOption) (*shared.Page[uuid.UUID], error) {
+	return ticketBuyers.lister(context).ListPageBefore(id, opts...)
+}
+
+// ForEach fetches one page at a time and calls fn for every id. Returning shared.ErrStopIteration from fn stops without an error.
+func (ticketBuyers *TicketBuyers) ForEach(context context.Context, fn func(id uuid.UUID) error, opts ...shared.PaginationParamsOption) error {
+	return ticketBuyers.lister(context).ForEach(fn, opts...)
+}
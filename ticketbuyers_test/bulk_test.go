@@ -0,0 +1,210 @@
+package ticketbuyers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/imroc/req/v3"
+	"github.com/seatsio/seatsio-go/v12"
+	"github.com/seatsio/seatsio-go/v12/shared"
+	"github.com/seatsio/seatsio-go/v12/test_util"
+	"github.com/seatsio/seatsio-go/v12/ticketbuyers"
+	"github.com/stretchr/testify/require"
+)
+
+// requestRecorder records the number of ids of every request that adds or removes ticket buyers, and the number of
+// pages that were requested.
+type requestRecorder struct {
+	mutex        sync.Mutex
+	requestSizes []int
+	pageRequests int
+}
+
+func (recorder *requestRecorder) sizes() []int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return slices.Clone(recorder.requestSizes)
+}
+
+func (recorder *requestRecorder) pages() int {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.pageRequests
+}
+
+// newTicketBuyers returns a client of a new company that has the given ticket buyers.
+func newTicketBuyers(t *testing.T, ids ...uuid.UUID) (*requestRecorder, *seatsio.SeatsioClient) {
+	company := test_util.CreateTestCompany(t)
+	client := seatsio.NewSeatsioClient(test_util.BaseUrl, company.Admin.SecretKey)
+	if len(ids) > 0 {
+		_, err := client.TicketBuyers.Add(test_util.RequestContext(), &ticketbuyers.TicketBuyerParams{Ids: ids})
+		require.NoError(t, err)
+	}
+	recorder := &requestRecorder{}
+	client.TicketBuyers.Client.OnAfterResponse(func(_ *req.Client, response *req.Response) error {
+		if response.Request == nil || response.Request.RawRequest == nil {
+			return nil
+		}
+		recorder.mutex.Lock()
+		defer recorder.mutex.Unlock()
+		if response.Request.RawRequest.Method == http.MethodGet {
+			recorder.pageRequests++
+			return nil
+		}
+		var params ticketbuyers.TicketBuyerParams
+		_ = json.Unmarshal(response.Request.Body, &params)
+		recorder.requestSizes = append(recorder.requestSizes, len(params.Ids))
+		return nil
+	})
+	return recorder, client
+}
+
+func current(t *testing.T, client *seatsio.SeatsioClient) []uuid.UUID {
+	ids, err := client.TicketBuyers.ListAll(test_util.RequestContext())
+	require.NoError(t, err)
+	return ids
+}
+
+// newRejectingTicketBuyers fails the requests that add the rejected id, which the test server never does.
+func newRejectingTicketBuyers(t *testing.T, rejected uuid.UUID) *seatsio.SeatsioClient {
+	server := httptest.NewServer(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		var params ticketbuyers.TicketBuyerParams
+		_ = json.NewDecoder(request.Body).Decode(&params)
+		writer.Header().Set("Content-Type", "application/json")
+		if slices.Contains(params.Ids, rejected) {
+			writer.WriteHeader(http.StatusBadRequest)
+			_, _ = writer.Write([]byte(`{"errors": [{"code": "GENERAL_ERROR", "message": "rejected"}]}`))
+			return
+		}
+		_ = json.NewEncoder(writer).Encode(ticketbuyers.AddTicketBuyerIdsResponse{Added: params.Ids, AlreadyPresent: []uuid.UUID{}})
+	}))
+	t.Cleanup(server.Close)
+	return seatsio.NewSeatsioClient(server.URL, "secretKey")
+}
+
+func newIds(count int) []uuid.UUID {
+	ids := make([]uuid.UUID, count)
+	for i := range ids {
+		ids[i] = uuid.New()
+	}
+	return ids
+}
+
+func TestAddAllAndRemoveAllInChunks(t *testing.T) {
+	t.Parallel()
+	ids := newIds(7)
+	recorder, client := newTicketBuyers(t, ids[0])
+	chunkSize := ticketbuyers.TicketBuyerSupport.ChunkSize(3)
+
+	added, err := client.TicketBuyers.AddAll(test_util.RequestContext(), slices.Concat(ids, ids[1:2]), chunkSize)
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids[1:], added.Added)
+	require.Equal(t, ids[:1], added.AlreadyPresent)
+	require.ElementsMatch(t, []int{3, 3, 1}, recorder.sizes())
+	require.ElementsMatch(t, ids, current(t, client))
+
+	unknown := uuid.New()
+	removed, err := client.TicketBuyers.RemoveAll(test_util.RequestContext(), slices.Concat(ids[:4], []uuid.UUID{unknown}), chunkSize, ticketbuyers.TicketBuyerSupport.Concurrency(1))
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids[:4], removed.Removed)
+	require.Equal(t, []uuid.UUID{unknown}, removed.NotPresent)
+	require.ElementsMatch(t, ids[4:], current(t, client))
+}
+
+func TestAddAllReportsFailedChunks(t *testing.T) {
+	t.Parallel()
+	ids := newIds(4)
+	client := newRejectingTicketBuyers(t, ids[3])
+
+	added, err := client.TicketBuyers.AddAll(test_util.RequestContext(), ids, ticketbuyers.TicketBuyerSupport.ChunkSize(2))
+
+	var bulkError *ticketbuyers.BulkError
+	require.True(t, errors.As(err, &bulkError))
+	require.Equal(t, ids[2:], bulkError.Failed)
+	require.ErrorContains(t, err, "rejected")
+	require.Equal(t, ids[:2], added.Added)
+}
+
+func TestListPagesOfTicketBuyers(t *testing.T) {
+	t.Parallel()
+	ids := newIds(5)
+	recorder, client := newTicketBuyers(t, ids...)
+
+	firstPage, err := client.TicketBuyers.ListFirstPage(test_util.RequestContext(), shared.Pagination.PageSize(3))
+	require.NoError(t, err)
+	require.Len(t, firstPage.Items, 3)
+
+	secondPage, err := client.TicketBuyers.ListPageAfter(test_util.RequestContext(), firstPage.NextPageStartsAfter, shared.Pagination.PageSize(3))
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids, slices.Concat(firstPage.Items, secondPage.Items))
+	require.Zero(t, secondPage.NextPageStartsAfter)
+
+	var streamed []uuid.UUID
+	err = client.TicketBuyers.ForEach(test_util.RequestContext(), func(id uuid.UUID) error {
+		streamed = append(streamed, id)
+		if len(streamed) == 3 {
+			return shared.ErrStopIteration
+		}
+		return nil
+	}, shared.Pagination.PageSize(2))
+	require.NoError(t, err)
+	require.Equal(t, firstPage.Items, streamed)
+	require.Equal(t, 4, recorder.pages())
+}
+
+func TestSyncTicketBuyers(t *testing.T) {
+	t.Parallel()
+	ids := newIds(6)
+	recorder, client := newTicketBuyers(t, ids[:4]...)
+	desired := []uuid.UUID{ids[1], ids[4], ids[3], ids[5]}
+
+	plan, err := client.TicketBuyers.PlanSync(test_util.RequestContext(), desired)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{ids[4], ids[5]}, plan.ToAdd)
+	require.ElementsMatch(t, []uuid.UUID{ids[0], ids[2]}, plan.ToRemove)
+	require.Len(t, current(t, client), 4)
+
+	result, err := client.TicketBuyers.Sync(test_util.RequestContext(), desired)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []uuid.UUID{ids[4], ids[5]}, result.Added.Added)
+	require.ElementsMatch(t, []uuid.UUID{ids[0], ids[2]}, result.Removed.Removed)
+	require.ElementsMatch(t, desired, current(t, client))
+	require.Equal(t, []int{2, 2}, recorder.sizes())
+
+	result, err = client.TicketBuyers.Sync(test_util.RequestContext(), desired)
+	require.NoError(t, err)
+	require.True(t, result.Plan.IsEmpty())
+	require.Equal(t, []int{2, 2}, recorder.sizes())
+}
+
+func TestImportAndExportTicketBuyersCSV(t *testing.T) {
+	t.Parallel()
+	ids := newIds(3)
+	_, client := newTicketBuyers(t)
+
+	csvWithHeader := "name,id\nAda," + ids[0].String() + "\nGrace, " + ids[1].String() + "\n\nAlan," + ids[2].String() + "\n"
+	added, err := client.TicketBuyers.ImportCSV(test_util.RequestContext(), strings.NewReader(csvWithHeader))
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids, added.Added)
+
+	withoutHeader, err := ticketbuyers.ReadIdsCSV(strings.NewReader(ids[2].String() + "\n" + ids[0].String() + "\n"))
+	require.NoError(t, err)
+	require.Equal(t, []uuid.UUID{ids[2], ids[0]}, withoutHeader)
+
+	_, err = ticketbuyers.ReadIdsCSV(strings.NewReader("id\n\nnot-a-uuid\n"))
+	require.ErrorContains(t, err, "line 3")
+
+	var exported bytes.Buffer
+	require.NoError(t, client.TicketBuyers.ExportCSV(test_util.RequestContext(), &exported))
+	lines := strings.Split(strings.TrimSpace(exported.String()), "\n")
+	require.Equal(t, "id", lines[0])
+	require.ElementsMatch(t, []string{ids[0].String(), ids[1].String(), ids[2].String()}, lines[1:])
+}
@@ -0,0 +1,66 @@
+package tickets
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+const payloadVersion = "T1"
+
+var ErrMalformedPayload = errors.New("malformed ticket payload")
+var ErrInvalidSignature = errors.New("the ticket payload has an invalid signature")
+
+// Payload is what a ticket barcode encodes by default: the event, the object and the order, signed with an HMAC so
+// that tickets cannot be forged without the secret.
+type Payload struct {
+	EventKey    string
+	ObjectLabel string
+	OrderId     string
+}
+
+// Sign encodes the payload as printable ASCII, for instance T1|event1|A-1|order1|<signature>.
+func (payload Payload) Sign(secret []byte) string {
+	unsigned := strings.Join([]string{
+		payloadVersion,
+		url.QueryEscape(payload.EventKey),
+		url.QueryEscape(payload.ObjectLabel),
+		url.QueryEscape(payload.OrderId),
+	}, "|")
+	return unsigned + "|" + signature(secret, unsigned)
+}
+
+// ParsePayload decodes a payload encoded by Sign, and verifies its signature.
+func ParsePayload(secret []byte, signed string) (Payload, error) {
+	separator := strings.LastIndex(signed, "|")
+	if separator < 0 {
+		return Payload{}, ErrMalformedPayload
+	}
+	unsigned := signed[:separator]
+	fields := strings.Split(unsigned, "|")
+	if len(fields) != 4 || fields[0] != payloadVersion {
+		return Payload{}, ErrMalformedPayload
+	}
+	if !hmac.Equal([]byte(signed[separator+1:]), []byte(signature(secret, unsigned))) {
+		return Payload{}, ErrInvalidSignature
+	}
+	var unescaped [3]string
+	for i, field := range fields[1:] {
+		value, err := url.QueryUnescape(field)
+		if err != nil {
+			return Payload{}, ErrMalformedPayload
+		}
+		unescaped[i] = value
+	}
+	return Payload{EventKey: unescaped[0], ObjectLabel: unescaped[1], OrderId: unescaped[2]}, nil
+}
+
+// signature is a truncated HMAC-SHA256, which keeps the barcode short enough to scan.
+func signature(secret []byte, unsigned string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(unsigned))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil)[:12])
+}
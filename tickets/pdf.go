@@ -0,0 +1,190 @@
+package tickets
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// pdfDocument writes a minimal PDF with the standard Helvetica fonts, which every reader has, so that no font needs
+// to be embedded.
+type pdfDocument struct {
+	width  float64
+	height float64
+	pages  []*pdfPage
+}
+
+type pdfPage struct {
+	content bytes.Buffer
+}
+
+const (
+	regular = "F1"
+	bold    = "F2"
+)
+
+func (document *pdfDocument) addPage() *pdfPage {
+	page := &pdfPage{}
+	document.pages = append(document.pages, page)
+	return page
+}
+
+type color struct {
+	r, g, b float64
+}
+
+var black = color{0, 0, 0}
+var grey = color{0.45, 0.45, 0.45}
+var lightGrey = color{0.82, 0.82, 0.82}
+var highlight = color{0.89, 0, 0.49}
+
+// text draws text with its baseline starting at x, y. PDF coordinates start at the bottom left.
+func (page *pdfPage) text(x float64, y float64, font string, size float64, fill color, text string) {
+	fmt.Fprintf(&page.content, "BT /%s %s Tf %s rg %s %s Td (%s) Tj ET\n",
+		font, pdfNum(size), fill.operands(), pdfNum(x), pdfNum(y), pdfString(text))
+}
+
+func (page *pdfPage) rectangle(x float64, y float64, width float64, height float64, fill color) {
+	fmt.Fprintf(&page.content, "%s rg %s %s %s %s re f\n", fill.operands(), pdfNum(x), pdfNum(y), pdfNum(width), pdfNum(height))
+}
+
+func (page *pdfPage) circle(x float64, y float64, radius float64, fill color) {
+	// A circle made of four Bézier curves.
+	k := radius * 0.5523
+	fmt.Fprintf(&page.content, "%s rg %s %s m %s %s %s %s %s %s c %s %s %s %s %s %s c %s %s %s %s %s %s c %s %s %s %s %s %s c f\n",
+		fill.operands(),
+		pdfNum(x+radius), pdfNum(y),
+		pdfNum(x+radius), pdfNum(y+k), pdfNum(x+k), pdfNum(y+radius), pdfNum(x), pdfNum(y+radius),
+		pdfNum(x-k), pdfNum(y+radius), pdfNum(x-radius), pdfNum(y+k), pdfNum(x-radius), pdfNum(y),
+		pdfNum(x-radius), pdfNum(y-k), pdfNum(x-k), pdfNum(y-radius), pdfNum(x), pdfNum(y-radius),
+		pdfNum(x+k), pdfNum(y-radius), pdfNum(x+radius), pdfNum(y-k), pdfNum(x+radius), pdfNum(y))
+}
+
+func (page *pdfPage) dashedLine(x1 float64, y1 float64, x2 float64, y2 float64, stroke color) {
+	fmt.Fprintf(&page.content, "q %s RG 0.5 w [3 3] 0 d %s %s m %s %s l S Q\n",
+		stroke.operands(), pdfNum(x1), pdfNum(y1), pdfNum(x2), pdfNum(y2))
+}
+
+func (page *pdfPage) strokedRectangle(x float64, y float64, width float64, height float64, stroke color) {
+	fmt.Fprintf(&page.content, "q %s RG 0.5 w %s %s %s %s re S Q\n", stroke.operands(), pdfNum(x), pdfNum(y), pdfNum(width), pdfNum(height))
+}
+
+func (fill color) operands() string {
+	return pdfNum(fill.r) + " " + pdfNum(fill.g) + " " + pdfNum(fill.b)
+}
+
+func (document *pdfDocument) write(writer io.Writer) error {
+	var out bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, out.Len())
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+	out.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+
+	// 1: catalog, 2: page tree, 3 and 4: fonts, then a page and its content stream per page.
+	const firstPage = 5
+	kids := make([]string, len(document.pages))
+	for i := range document.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+	object("<< /Type /Catalog /Pages 2 0 R >>")
+	object(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(document.pages)))
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	object("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	for i, page := range document.pages {
+		object(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /%s 3 0 R /%s 4 0 R >> >> /Contents %d 0 R >>",
+			pdfNum(document.width), pdfNum(document.height), regular, bold, firstPage+2*i+1))
+		var compressed bytes.Buffer
+		zlibWriter := zlib.NewWriter(&compressed)
+		if _, err := zlibWriter.Write(page.content.Bytes()); err != nil {
+			return err
+		}
+		if err := zlibWriter.Close(); err != nil {
+			return err
+		}
+		object(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", compressed.Len(), compressed.String()))
+	}
+
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+	_, err := writer.Write(out.Bytes())
+	return err
+}
+
+func pdfNum(value float64) string {
+	return strconv.FormatFloat(math.Round(value*1000)/1000, 'f', -1, 64)
+}
+
+// pdfString escapes text for a PDF string in WinAnsiEncoding. Characters that it cannot encode become '?'.
+func pdfString(text string) string {
+	var out strings.Builder
+	for _, r := range text {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			out.WriteByte('\\')
+			out.WriteRune(r)
+		case r >= 32 && r < 127:
+			out.WriteRune(r)
+		case r == '€':
+			out.WriteString(`\200`)
+		case r >= 0xA0 && r <= 0xFF:
+			fmt.Fprintf(&out, `\%03o`, r)
+		default:
+			out.WriteByte('?')
+		}
+	}
+	return out.String()
+}
+
+// helveticaWidths holds the widths of the printable ASCII characters in Helvetica, in thousandths of the font size.
+var helveticaWidths = [...]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// textWidth estimates the width of text. Bold text is about 8% wider than regular text.
+func textWidth(text string, font string, size float64) float64 {
+	width := 0
+	for _, r := range text {
+		if r >= 32 && r < 127 {
+			width += helveticaWidths[r-32]
+		} else {
+			width += 556
+		}
+	}
+	result := float64(width) * size / 1000
+	if font == bold {
+		result *= 1.08
+	}
+	return result
+}
+
+// fit returns the largest size up to size, but at least minSize, at which text fits in maxWidth, and truncates the
+// text when it does not fit at minSize.
+func fit(text string, font string, size float64, minSize float64, maxWidth float64) (string, float64) {
+	for size > minSize && textWidth(text, font, size) > maxWidth {
+		size -= 0.5
+	}
+	runes := []rune(text)
+	for len(runes) > 1 && textWidth(string(runes), font, size) > maxWidth {
+		runes = runes[:len(runes)-1]
+		text = string(runes) + "..."
+		if textWidth(text, font, size) <= maxWidth {
+			return text, size
+		}
+	}
+	return text, size
+}
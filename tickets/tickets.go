@@ -0,0 +1,293 @@
+package tickets
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/seatmap"
+)
+
+// Ticket is one booked object of an event.
+type Ticket struct {
+	Event  events.Event
+	Object events.EventObjectInfo
+}
+
+// TicketsForOrder returns the tickets of the objects that were booked with an order id, sorted by label. objects is
+// typically the result of Events.RetrieveObjectInfo or seatmap.ObjectsFromReport.
+func TicketsForOrder(event events.Event, objects map[string]events.EventObjectInfo, orderId string) []Ticket {
+	var tickets []Ticket
+	for label, object := range objects {
+		if object.OrderId == orderId && object.Status == events.BOOKED {
+			if object.Label == "" {
+				object.Label = label
+			}
+			tickets = append(tickets, Ticket{Event: event, Object: object})
+		}
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		return tickets[i].Object.Label < tickets[j].Object.Label
+	})
+	return tickets
+}
+
+// Field is a line of a ticket, such as the row or the entrance. An empty value leaves the field out.
+type Field func(ticket Ticket) (name string, value string)
+
+// DefaultFields prints the section, the row or table, the seat, the entrance, the floor, the category and the ticket
+// type of an object.
+var DefaultFields = []Field{
+	func(ticket Ticket) (string, string) {
+		return "Section", ticket.Object.Labels.Section
+	},
+	func(ticket Ticket) (string, string) {
+		return objectTypeName(ticket.Object.Labels.Parent.Type), ticket.Object.Labels.Parent.Label
+	},
+	func(ticket Ticket) (string, string) {
+		return objectTypeName(ticket.Object.Labels.Own.Type), ticket.Object.Labels.Own.Label
+	},
+	func(ticket Ticket) (string, string) {
+		return "Entrance", ticket.Object.Entrance
+	},
+	func(ticket Ticket) (string, string) {
+		return "Floor", valueOrDefault(ticket.Object.Floor.DisplayName, ticket.Object.Floor.Name)
+	},
+	func(ticket Ticket) (string, string) {
+		return "Category", ticket.Object.CategoryLabel
+	},
+	func(ticket Ticket) (string, string) {
+		return "Ticket type", ticket.Object.TicketType
+	},
+}
+
+func objectTypeName(objectType string) string {
+	switch objectType {
+	case "generalAdmission":
+		return "Area"
+	case "":
+		return "Seat"
+	}
+	return strings.ToUpper(objectType[:1]) + objectType[1:]
+}
+
+// Template sets the layout of the tickets.
+type Template struct {
+	// Width and Height are the size of a ticket in points. They default to 595 by 280, a third of an A4 page.
+	Width  float64
+	Height float64
+	// Header is printed above the event name, for instance the name of the venue.
+	Header string
+	// Footer is printed at the bottom, for instance the terms of sale.
+	Footer string
+	// DateLayout formats the date of the event. Defaults to "Monday 2 January 2006".
+	DateLayout string
+	// Fields defaults to DefaultFields.
+	Fields []Field
+}
+
+// Generator renders tickets as PDF, without external services or fonts.
+type Generator struct {
+	Template Template
+	// Secret signs the default barcode payload, a Payload of the event key, object label and order id.
+	Secret []byte
+	// Payload replaces the default barcode payload. It must be printable ASCII.
+	Payload func(ticket Ticket) (string, error)
+	// Drawing adds a mini seat map that shows where the object is. It is a chart drawing as returned by
+	// Charts.RetrievePublishedVersion.
+	Drawing map[string]interface{}
+}
+
+// WriteTicket writes a PDF with one ticket.
+func (generator *Generator) WriteTicket(writer io.Writer, ticket Ticket) error {
+	return generator.WriteTickets(writer, []Ticket{ticket})
+}
+
+// WriteTickets writes a PDF with one page per ticket, for instance the tickets of an order.
+func (generator *Generator) WriteTickets(writer io.Writer, tickets []Ticket) error {
+	if len(tickets) == 0 {
+		return errors.New("there are no tickets")
+	}
+	template := generator.Template
+	document := &pdfDocument{width: valueOrDefault(template.Width, 595), height: valueOrDefault(template.Height, 280)}
+	var layouts map[string]*seatmap.Layout
+	if generator.Drawing != nil {
+		layouts = map[string]*seatmap.Layout{}
+	}
+	for _, ticket := range tickets {
+		var layout *seatmap.Layout
+		if layouts != nil {
+			floor := ticket.Object.Floor.Name
+			if layouts[floor] == nil {
+				var err error
+				if layouts[floor], err = seatmap.NewLayout(generator.Drawing, floor); err != nil {
+					return err
+				}
+			}
+			layout = layouts[floor]
+		}
+		if err := generator.render(document.addPage(), document, ticket, layout); err != nil {
+			return fmt.Errorf("ticket for %s: %w", ticket.Object.Label, err)
+		}
+	}
+	return document.write(writer)
+}
+
+func (generator *Generator) payload(ticket Ticket) (string, error) {
+	if generator.Payload != nil {
+		return generator.Payload(ticket)
+	}
+	if len(generator.Secret) == 0 {
+		return "", errors.New("a Secret or a Payload function is needed")
+	}
+	payload := Payload{EventKey: ticket.Event.Key, ObjectLabel: ticket.Object.Label, OrderId: ticket.Object.OrderId}
+	return payload.Sign(generator.Secret), nil
+}
+
+const margin = 20
+const stubWidth = 190
+
+func (generator *Generator) render(page *pdfPage, document *pdfDocument, ticket Ticket, layout *seatmap.Layout) error {
+	template := generator.Template
+	width, height := document.width, document.height
+	mainWidth := width - stubWidth - 2*margin
+	top := height - margin
+
+	if template.Header != "" {
+		header, size := fit(template.Header, regular, 9, 6, mainWidth)
+		page.text(margin, top-9, regular, size, grey, header)
+	}
+	name, size := fit(ticket.Event.Name, bold, 20, 11, mainWidth)
+	page.text(margin, top-34, bold, size, black, name)
+	if date := formatDate(ticket.Event.Date, template.DateLayout); date != "" {
+		page.text(margin, top-52, regular, 11, black, date)
+	}
+
+	fields := template.Fields
+	if fields == nil {
+		fields = DefaultFields
+	}
+	columnWidth := mainWidth / 4
+	column, y := 0, top-82
+	for _, field := range fields {
+		fieldName, value := field(ticket)
+		if value == "" {
+			continue
+		}
+		x := margin + float64(column)*columnWidth
+		page.text(x, y, regular, 7, grey, strings.ToUpper(fieldName))
+		value, size := fit(value, bold, 13, 7, columnWidth-6)
+		page.text(x, y-15, bold, size, black, value)
+		if column++; column == 4 {
+			column, y = 0, y-34
+		}
+	}
+
+	payload, err := generator.payload(ticket)
+	if err != nil {
+		return err
+	}
+	if err := drawBarcode(page, payload, margin, margin+12, width-2*margin, 40); err != nil {
+		return err
+	}
+	reference, _ := fit(strings.Trim(ticket.Object.Label+"  "+ticket.Object.OrderId, " "), regular, 7, 5, width-2*margin)
+	page.text(margin, margin+2, regular, 7, grey, reference)
+	if template.Footer != "" {
+		footer, size := fit(template.Footer, regular, 6, 4, width-2*margin)
+		page.text(margin, margin-12, regular, size, grey, footer)
+	}
+
+	stubX := width - stubWidth
+	stubBottom := margin + 60.0
+	page.dashedLine(stubX, stubBottom, stubX, height, lightGrey)
+	stubName, stubSize := fit(ticket.Event.Name, bold, 10, 6, stubWidth-2*margin)
+	page.text(stubX+margin, top-10, bold, stubSize, black, stubName)
+	stubLabel, stubLabelSize := fit(ticket.Object.Label, bold, 14, 7, stubWidth-2*margin)
+	page.text(stubX+margin, top-30, bold, stubLabelSize, black, stubLabel)
+	if layout != nil {
+		drawSeatMap(page, layout, ticket.Object.Label, stubX+margin, stubBottom+6, stubWidth-2*margin, top-42-stubBottom-6)
+	}
+	return nil
+}
+
+// drawBarcode draws a Code 128 barcode across the width, with quiet zones of 10 modules.
+func drawBarcode(page *pdfPage, payload string, x float64, y float64, width float64, height float64) error {
+	widths, err := code128(payload)
+	if err != nil {
+		return err
+	}
+	modules := 20
+	for _, w := range widths {
+		modules += w
+	}
+	moduleWidth := min(width/float64(modules), 1.5)
+	if moduleWidth < 0.4 {
+		return fmt.Errorf("the payload is too long for a barcode of %s points: %d characters", pdfNum(width), len(payload))
+	}
+	position := x + 10*moduleWidth
+	for i, w := range widths {
+		if i%2 == 0 {
+			page.rectangle(position, y, float64(w)*moduleWidth, height, black)
+		}
+		position += float64(w) * moduleWidth
+	}
+	return nil
+}
+
+// drawSeatMap draws every object as a dot, and the ticket's object as a larger, highlighted dot.
+func drawSeatMap(page *pdfPage, layout *seatmap.Layout, objectLabel string, x float64, y float64, width float64, height float64) {
+	if layout.Width <= 0 || layout.Height <= 0 || height <= 0 {
+		return
+	}
+	page.strokedRectangle(x, y, width, height, lightGrey)
+	scale := min((width-8)/layout.Width, (height-8)/layout.Height)
+	offsetX := x + (width-layout.Width*scale)/2
+	offsetY := y + (height-layout.Height*scale)/2
+	// Chart coordinates start at the top left, PDF coordinates at the bottom left.
+	toPage := func(object seatmap.ObjectPosition) (float64, float64) {
+		return offsetX + object.X*scale, offsetY + (layout.Height-object.Y)*scale
+	}
+	var found *seatmap.ObjectPosition
+	for i, object := range layout.Objects {
+		if object.Label == objectLabel {
+			found = &layout.Objects[i]
+			continue
+		}
+		if object.ObjectType == "table" {
+			continue
+		}
+		radius := max(5*scale, 0.6)
+		if object.ObjectType == "generalAdmission" {
+			radius = max(20*scale, 2)
+		}
+		px, py := toPage(object)
+		page.circle(px, py, radius, lightGrey)
+	}
+	if found != nil {
+		px, py := toPage(*found)
+		page.circle(px, py, max(5*scale, 3), highlight)
+	}
+}
+
+func formatDate(date string, layout string) string {
+	if date == "" {
+		return ""
+	}
+	parsed, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return date
+	}
+	return parsed.Format(valueOrDefault(layout, "Monday 2 January 2006"))
+}
+
+func valueOrDefault[T comparable](value T, defaultValue T) T {
+	var zero T
+	if value == zero {
+		return defaultValue
+	}
+	return value
+}
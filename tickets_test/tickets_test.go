@@ -0,0 +1,199 @@
+package tickets_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/seatsio/seatsio-go/v12/events"
+	"github.com/seatsio/seatsio-go/v12/tickets"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("secret")
+
+func bookedObject(label string, orderId string) events.EventObjectInfo {
+	return events.EventObjectInfo{
+		Label:         label,
+		Labels:        events.Labels{Own: events.LabelAndType{Label: label[2:], Type: "seat"}, Parent: events.LabelAndType{Label: label[:1], Type: "row"}},
+		Status:        events.BOOKED,
+		OrderId:       orderId,
+		CategoryLabel: "Cat1",
+		Entrance:      "Gate (North)",
+	}
+}
+
+// contentStreams returns the decompressed content of every page.
+func contentStreams(t *testing.T, pdf []byte) []string {
+	var streams []string
+	for _, match := range regexp.MustCompile(`(?s)stream\n(.*?)\nendstream`).FindAllSubmatch(pdf, -1) {
+		reader, err := zlib.NewReader(bytes.NewReader(match[1]))
+		require.NoError(t, err)
+		content, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		streams = append(streams, string(content))
+	}
+	return streams
+}
+
+func requireValidXref(t *testing.T, pdf []byte) {
+	startxref := regexp.MustCompile(`startxref\n(\d+)\n%%EOF\n$`).FindSubmatch(pdf)
+	require.NotNil(t, startxref)
+	offset, _ := strconv.Atoi(string(startxref[1]))
+	require.True(t, bytes.HasPrefix(pdf[offset:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[offset:], -1)
+	for i, entry := range entries {
+		objectOffset, _ := strconv.Atoi(string(entry[1]))
+		require.True(t, bytes.HasPrefix(pdf[objectOffset:], []byte(strconv.Itoa(i+1)+" 0 obj")), "object %d", i+1)
+	}
+}
+
+func TestPayloadRoundTrip(t *testing.T) {
+	t.Parallel()
+	payload := tickets.Payload{EventKey: "event|1", ObjectLabel: "Section A-B-12", OrderId: "order 1"}
+
+	signed := payload.Sign(secret)
+	parsed, err := tickets.ParsePayload(secret, signed)
+
+	require.NoError(t, err)
+	require.Equal(t, payload, parsed)
+	require.Regexp(t, `^[ -~]+$`, signed)
+}
+
+func TestPayloadWithWrongSignature(t *testing.T) {
+	t.Parallel()
+	signed := tickets.Payload{EventKey: "event1", ObjectLabel: "A-1", OrderId: "order1"}.Sign(secret)
+
+	_, err := tickets.ParsePayload([]byte("other secret"), signed)
+	require.ErrorIs(t, err, tickets.ErrInvalidSignature)
+
+	_, err = tickets.ParsePayload(secret, strings.Replace(signed, "A-1", "A-2", 1))
+	require.ErrorIs(t, err, tickets.ErrInvalidSignature)
+
+	_, err = tickets.ParsePayload(secret, "not a ticket")
+	require.ErrorIs(t, err, tickets.ErrMalformedPayload)
+}
+
+func TestTicketsForOrder(t *testing.T) {
+	t.Parallel()
+	event := events.Event{Key: "event1"}
+	objects := map[string]events.EventObjectInfo{
+		"A-2": bookedObject("A-2", "order1"),
+		"A-1": bookedObject("A-1", "order1"),
+		"A-3": bookedObject("A-3", "order2"),
+		"A-4": {Label: "A-4", Status: events.FREE, OrderId: "order1"},
+	}
+
+	result := tickets.TicketsForOrder(event, objects, "order1")
+
+	require.Len(t, result, 2)
+	require.Equal(t, "A-1", result[0].Object.Label)
+	require.Equal(t, "A-2", result[1].Object.Label)
+}
+
+func TestWriteTickets(t *testing.T) {
+	t.Parallel()
+	generator := &tickets.Generator{
+		Secret:   secret,
+		Template: tickets.Template{Header: "Théâtre Royal", Footer: "No refunds"},
+	}
+	event := events.Event{Key: "event1", Name: "A night at the opera", Date: "2026-11-07"}
+
+	var pdf bytes.Buffer
+	err := generator.WriteTickets(&pdf, []tickets.Ticket{
+		{Event: event, Object: bookedObject("A-1", "order1")},
+		{Event: event, Object: bookedObject("A-2", "order1")},
+	})
+
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-1.4\n")))
+	require.Contains(t, pdf.String(), "/Count 2")
+	requireValidXref(t, pdf.Bytes())
+	pages := contentStreams(t, pdf.Bytes())
+	require.Len(t, pages, 2)
+	require.Contains(t, pages[0], "(A night at the opera) Tj")
+	require.Contains(t, pages[0], "(Saturday 7 November 2026) Tj")
+	require.Contains(t, pages[0], `(Th\351\342tre Royal) Tj`)
+	require.Contains(t, pages[0], `(Gate \(North\)) Tj`)
+	require.Contains(t, pages[0], "(ROW) Tj")
+	require.Contains(t, pages[1], "(A-2) Tj")
+}
+
+// TestBarcodeIsValidCode128 reads the bars back from the page, and checks the start and stop symbols, and that every
+// symbol is 11 modules wide with an even number of bar modules.
+func TestBarcodeIsValidCode128(t *testing.T) {
+	t.Parallel()
+	payload := "T1|event1|A-1|order1"
+	generator := &tickets.Generator{Payload: func(ticket tickets.Ticket) (string, error) {
+		return payload, nil
+	}}
+
+	var pdf bytes.Buffer
+	require.NoError(t, generator.WriteTicket(&pdf, tickets.Ticket{Event: events.Event{Key: "event1"}, Object: bookedObject("A-1", "order1")}))
+
+	type bar struct{ x, width float64 }
+	var bars []bar
+	for _, match := range regexp.MustCompile(`0 0 0 rg ([\d.]+) [\d.]+ ([\d.]+) 40 re f`).FindAllStringSubmatch(contentStreams(t, pdf.Bytes())[0], -1) {
+		x, _ := strconv.ParseFloat(match[1], 64)
+		width, _ := strconv.ParseFloat(match[2], 64)
+		bars = append(bars, bar{x, width})
+	}
+	moduleWidth := bars[0].width / 2
+	var widths []int
+	for i, b := range bars {
+		widths = append(widths, int(math.Round(b.width/moduleWidth)))
+		if i+1 < len(bars) {
+			widths = append(widths, int(math.Round((bars[i+1].x-b.x-b.width)/moduleWidth)))
+		}
+	}
+
+	symbols := len(payload) + 2
+	require.Len(t, widths, symbols*6+7)
+	require.Equal(t, []int{2, 1, 1, 2, 1, 4}, widths[:6], "start B")
+	require.Equal(t, []int{2, 3, 3, 1, 1, 1, 2}, widths[len(widths)-7:], "stop")
+	for symbol := 0; symbol < symbols; symbol++ {
+		sum, barModules := 0, 0
+		for i, width := range widths[symbol*6 : symbol*6+6] {
+			sum += width
+			if i%2 == 0 {
+				barModules += width
+			}
+		}
+		require.Equal(t, 11, sum, "symbol %d", symbol)
+		require.Equal(t, 0, barModules%2, "symbol %d", symbol)
+	}
+}
+
+func TestMiniSeatMapHighlightsTheObject(t *testing.T) {
+	t.Parallel()
+	data, err := os.ReadFile("../test_util/charts/sampleChart.json")
+	require.NoError(t, err)
+	var drawing map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &drawing))
+	generator := &tickets.Generator{Secret: secret, Drawing: drawing}
+
+	var pdf bytes.Buffer
+	require.NoError(t, generator.WriteTicket(&pdf, tickets.Ticket{Event: events.Event{Key: "event1"}, Object: bookedObject("A-1", "order1")}))
+
+	page := contentStreams(t, pdf.Bytes())[0]
+	require.Equal(t, 1, strings.Count(page, "0.89 0 0.49 rg"))
+	require.Equal(t, 33, strings.Count(page, "0.82 0.82 0.82 rg"))
+}
+
+func TestPayloadThatCannotBeEncoded(t *testing.T) {
+	t.Parallel()
+	generator := &tickets.Generator{Payload: func(ticket tickets.Ticket) (string, error) {
+		return "tïcket", nil
+	}}
+
+	err := generator.WriteTicket(io.Discard, tickets.Ticket{Object: bookedObject("A-1", "order1")})
+
+	require.ErrorContains(t, err, "code 128 cannot encode")
+}